@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"io"
 
 	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/dcos"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
 	"github.com/dcos/dcos-cli/pkg/login"
 	"github.com/dcos/dcos-cli/pkg/open"
@@ -36,6 +38,12 @@ type Context interface {
 	// Fs returns the filesystem.
 	Fs() afero.Fs
 
+	// JSONOutput returns whether or not commands should print their output in JSON format.
+	JSONOutput() bool
+
+	// BaseContext returns the context HTTP requests are derived from.
+	BaseContext() context.Context
+
 	// Logger returns the CLI logger.
 	Logger() *logrus.Logger
 
@@ -51,6 +59,15 @@ type Context interface {
 	// Clusters returns the configured clusters.
 	Clusters() []*config.Cluster
 
+	// ClusterVersion returns the DC/OS version of a cluster, it can be served from a short-lived cache.
+	ClusterVersion(c *config.Cluster, opts ...httpclient.Option) (*dcos.Version, error)
+
+	// CheckClusterVersion warns when the CLI version is known to be incompatible with the version of a cluster.
+	CheckClusterVersion(c *config.Cluster)
+
+	// ACSToken returns the ACS token for a cluster, taking the --token global flag into account.
+	ACSToken(c *config.Cluster) (string, error)
+
 	// HTTPClient creates an httpclient.Client for a given cluster.
 	HTTPClient(c *config.Cluster, opts ...httpclient.Option) *httpclient.Client
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -11,13 +12,17 @@ import (
 	"github.com/dcos/dcos-cli/pkg/cli"
 	"github.com/dcos/dcos-cli/pkg/cli/version"
 	"github.com/dcos/dcos-cli/pkg/cmd"
-	"github.com/dcos/dcos-cli/pkg/dcos"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/interrupt"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 )
 
 func main() {
 	if err := run(cli.NewOsEnvironment()); err != nil {
+		if err == interrupt.ErrInterrupted {
+			os.Exit(interrupt.ExitCode)
+		}
 		os.Exit(1)
 	}
 }
@@ -25,12 +30,19 @@ func main() {
 // run launches the DC/OS CLI with a given environment.
 func run(env *cli.Environment) error {
 	globalFlags := &cli.GlobalFlags{}
-	env.Args = append(env.Args[:1], globalFlags.Parse(env.Args[1:])...)
+	args, err := globalFlags.Parse(env.Args[1:])
+	if err != nil {
+		fmt.Fprintln(env.ErrOut, "Error:", err)
+		return err
+	}
+	env.Args = append(env.Args[:1], args...)
 
-	if globalFlags.Verbosity == 0 {
+	// Command-line flags take precedence over the DCOS_VERBOSITY and DCOS_LOG_LEVEL env vars.
+	if globalFlags.Verbosity == 0 && globalFlags.LogLevel == "" {
 		if envVerbosity, ok := env.EnvLookup("DCOS_VERBOSITY"); ok {
 			globalFlags.Verbosity, _ = strconv.Atoi(envVerbosity)
 		}
+		globalFlags.LogLevel, _ = env.EnvLookup("DCOS_LOG_LEVEL")
 	}
 	if globalFlags.Debug {
 		globalFlags.LogLevel = "debug"
@@ -38,42 +50,97 @@ func run(env *cli.Environment) error {
 	}
 
 	ctx := cli.NewContext(env)
-	ctx.Logger().SetLevel(logrusLevel(env.ErrOut, globalFlags.Verbosity, globalFlags.LogLevel))
+	ctx.SetGlobalFlags(globalFlags)
+
+	// SIGINT and SIGTERM cancel the base context, in-flight HTTP requests are thus aborted.
+	interruptHandler := interrupt.New(context.Background(), env.ErrOut)
+	interruptHandler.Start()
+	defer interruptHandler.Stop()
+	ctx.SetInterruptHandler(interruptHandler)
+
+	// The timeout bounds the whole command, including retries and multi-request flows such as login.
+	baseCtx := interruptHandler.Context()
+	if globalFlags.Timeout > 0 {
+		var cancel context.CancelFunc
+		baseCtx, cancel = httpclient.WithTimeout(baseCtx, globalFlags.Timeout)
+		defer cancel()
+	}
+	ctx.SetBaseContext(baseCtx)
+	logLevel, err := logrusLevel(env.ErrOut, globalFlags.Verbosity, globalFlags.LogLevel)
+	if err != nil {
+		fmt.Fprintln(env.ErrOut, "Error:", err)
+		return err
+	}
+	ctx.Logger().SetLevel(logLevel)
+
+	ctx.MigrateLegacyConfig()
 
 	if globalFlags.Version {
 		printVersion(ctx)
 		return nil
 	}
 	dcosCmd := cmd.NewDCOSCommand(ctx)
+
+	// --yes is also accepted after the command name (eg. `dcos cluster remove <cluster> --yes`).
+	dcosCmd.PersistentFlags().BoolVarP(&globalFlags.Yes, "yes", "y", globalFlags.Yes, "Answer yes to confirmation prompts")
 	dcosCmd.SetArgs(env.Args[1:])
-	return dcosCmd.Execute()
+	silenceInterruptedErrors(dcosCmd, interruptHandler)
+
+	err = dcosCmd.Execute()
+	if interruptHandler.Interrupted() {
+		fmt.Fprintln(env.ErrOut, "Interrupted.")
+		return interrupt.ErrInterrupted
+	}
+	return err
+}
+
+// silenceInterruptedErrors prevents commands which have been interrupted from printing their error
+// (eg. "context canceled"), a single "Interrupted." message is printed instead.
+func silenceInterruptedErrors(cmd *cobra.Command, interruptHandler *interrupt.Handler) {
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			err := runE(cmd, args)
+			if err != nil && interruptHandler.Interrupted() {
+				cmd.SilenceErrors = true
+			}
+			return err
+		}
+	}
+	for _, subCmd := range cmd.Commands() {
+		silenceInterruptedErrors(subCmd, interruptHandler)
+	}
 }
 
-// logrusLevel returns the log level for the CLI based on the verbosity. The default verbosity is 0.
-func logrusLevel(errout io.Writer, verbosity int, logLevel string) logrus.Level {
+// logrusLevel returns the log level for the CLI based on the verbosity and the log level. The verbosity
+// takes precedence over the log level, the default level is error.
+func logrusLevel(errout io.Writer, verbosity int, logLevel string) (logrus.Level, error) {
 	if verbosity > 1 {
 		// -vv sets the logger level to debug. This also happens for -vvv
 		// and above, in such cases we set the logging level to its maximum.
-		return logrus.DebugLevel
+		return logrus.DebugLevel, nil
 	}
 
 	if verbosity == 1 {
 		// -v sets the logger level to info.
-		return logrus.InfoLevel
+		return logrus.InfoLevel, nil
 	}
 
 	switch logLevel {
+	case "", "error":
+		return logrus.ErrorLevel, nil
+	case "info":
+		return logrus.InfoLevel, nil
 	case "debug":
-		fmt.Fprintln(errout, "The --log-level flag is deprecated. Please use the -vv flag.")
-		return logrus.DebugLevel
-	case "info", "warning":
-		fmt.Fprintln(errout, "The --log-level flag is deprecated. Please use the -v flag.")
-		return logrus.InfoLevel
-	case "error", "critical":
-		fmt.Fprintf(errout, "The --log-level=%s flag is deprecated. It is enabled by default.\n", logLevel)
+		return logrus.DebugLevel, nil
+	case "warning":
+		fmt.Fprintln(errout, "The 'warning' log level is deprecated. Please use 'info'.")
+		return logrus.InfoLevel, nil
+	case "critical":
+		fmt.Fprintln(errout, "The 'critical' log level is deprecated. Please use 'error'.")
+		return logrus.ErrorLevel, nil
+	default:
+		return logrus.ErrorLevel, fmt.Errorf("invalid log level '%s', expected error, info or debug", logLevel)
 	}
-	// Without the verbose flag, default to error level.
-	return logrus.ErrorLevel
 }
 
 // printVersion prints CLI version information.
@@ -85,8 +152,7 @@ func printVersion(ctx api.Context) {
 		return
 	}
 
-	dcosClient := dcos.NewClient(ctx.HTTPClient(cluster, httpclient.Timeout(3*time.Second)))
-	if dcosVersion, err := dcosClient.Version(); err == nil {
+	if dcosVersion, err := ctx.ClusterVersion(cluster, httpclient.Timeout(3*time.Second)); err == nil {
 		fmt.Fprintln(ctx.Out(), "dcos.version="+dcosVersion.Version)
 		fmt.Fprintln(ctx.Out(), "dcos.commit="+dcosVersion.DCOSImageCommit)
 		fmt.Fprintln(ctx.Out(), "dcos.bootstrap-id="+dcosVersion.BootstrapID)
@@ -0,0 +1,66 @@
+// Package cache persists values to the filesystem for a limited period of time.
+package cache
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/fsutil"
+	"github.com/spf13/afero"
+)
+
+// entry is the JSON document stored in a cache file.
+type entry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Cache is a JSON file holding a single value, which is considered fresh during a TTL.
+type Cache struct {
+	fs   afero.Fs
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// New returns a cache stored at a given path.
+func New(fs afero.Fs, path string, ttl time.Duration) *Cache {
+	return &Cache{
+		fs:   fs,
+		path: path,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// Get unmarshals the cached value into v. It returns false when the cache is missing, expired,
+// or can't be read, in which case the value is expected to be fetched again and stored.
+func (c *Cache) Get(v interface{}) bool {
+	data, err := afero.ReadFile(c.fs, c.path)
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	age := c.now().Sub(e.FetchedAt)
+	if age < 0 || age > c.ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Set stores a value in the cache. The cache file is written to a temporary
+// file which is then renamed into place, readers thus never see a partial file.
+func (c *Cache) Set(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	cacheData, err := json.Marshal(&entry{FetchedAt: c.now(), Data: data})
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(c.fs, c.path, cacheData, 0600)
+}
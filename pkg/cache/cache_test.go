@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/dcos", 0755))
+
+	now := time.Now()
+	c := New(fs, "/dcos/metadata.json", 5*time.Minute)
+	c.now = func() time.Time { return now }
+
+	var val map[string]string
+	require.False(t, c.Get(&val))
+
+	require.NoError(t, c.Set(map[string]string{"version": "1.13.0"}))
+	require.True(t, c.Get(&val))
+	require.Equal(t, "1.13.0", val["version"])
+
+	// Only the cache file is left in the directory.
+	entries, err := afero.ReadDir(fs, "/dcos")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// The value expires after the TTL.
+	c.now = func() time.Time { return now.Add(6 * time.Minute) }
+	require.False(t, c.Get(&val))
+}
+
+func TestCorruptCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/dcos/metadata.json", []byte("{not json"), 0600))
+
+	var val map[string]string
+	require.False(t, New(fs, "/dcos/metadata.json", time.Minute).Get(&val))
+}
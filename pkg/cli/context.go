@@ -1,31 +1,53 @@
 package cli
 
 import (
-	"crypto/tls"
+	"context"
+	"fmt"
 	"io"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/dcos/dcos-cli/pkg/cache"
+	cliversion "github.com/dcos/dcos-cli/pkg/cli/version"
 	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/dcos"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/interrupt"
 	"github.com/dcos/dcos-cli/pkg/log"
 	"github.com/dcos/dcos-cli/pkg/login"
 	"github.com/dcos/dcos-cli/pkg/open"
 	"github.com/dcos/dcos-cli/pkg/plugin"
+	"github.com/dcos/dcos-cli/pkg/progress"
 	"github.com/dcos/dcos-cli/pkg/prompt"
 	"github.com/dcos/dcos-cli/pkg/setup"
-	"github.com/mitchellh/go-homedir"
+	"github.com/dcos/dcos-cli/pkg/version"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
+// metadataCacheTTL is the duration during which the cached metadata of a cluster is considered fresh.
+const metadataCacheTTL = 5 * time.Minute
+
+// versionCheckCacheTTL is the duration during which the cached version check of a cluster is reused.
+const versionCheckCacheTTL = 24 * time.Hour
+
+// cliVersion returns the version of the DC/OS CLI, it is a variable so that tests can override it.
+var cliVersion = cliversion.Version
+
 // Context provides an implementation of api.Context. It relies on an Environment and is used to create
 // various objects across the project and is being passed to every command as a constructor argument.
 type Context struct {
-	env      *Environment
-	logger   *logrus.Logger
-	loggerMu sync.Mutex
+	env         *Environment
+	baseCtx     context.Context
+	interrupt   *interrupt.Handler
+	globalFlags *GlobalFlags
+	logger      *logrus.Logger
+	loggerMu    sync.Mutex
+	transports  map[string]*http.Transport
+	transportMu sync.Mutex
 }
 
 // NewContext creates a new context from a given environment.
@@ -33,6 +55,36 @@ func NewContext(env *Environment) *Context {
 	return &Context{env: env}
 }
 
+// SetGlobalFlags sets the global flags the CLI has been invoked with.
+func (ctx *Context) SetGlobalFlags(globalFlags *GlobalFlags) {
+	ctx.globalFlags = globalFlags
+}
+
+// SetBaseContext sets the context HTTP requests are derived from. It
+// is used to bound the total time spent by a command on HTTP requests.
+func (ctx *Context) SetBaseContext(baseCtx context.Context) {
+	ctx.baseCtx = baseCtx
+}
+
+// SetInterruptHandler sets the handler of SIGINT and SIGTERM. Components creating temporary files
+// (eg. the plugin manager) register their removal with it in case the CLI gets force-exited.
+func (ctx *Context) SetInterruptHandler(handler *interrupt.Handler) {
+	ctx.interrupt = handler
+}
+
+// BaseContext returns the context HTTP requests are derived from.
+func (ctx *Context) BaseContext() context.Context {
+	if ctx.baseCtx == nil {
+		return context.Background()
+	}
+	return ctx.baseCtx
+}
+
+// JSONOutput returns whether or not commands should print their output in JSON format.
+func (ctx *Context) JSONOutput() bool {
+	return ctx.globalFlags != nil && ctx.globalFlags.JSON
+}
+
 // Args returns the command-line arguments, starting with the program name.
 func (ctx *Context) Args() []string {
 	return ctx.env.Args
@@ -81,30 +133,43 @@ func (ctx *Context) Logger() *logrus.Logger {
 // PluginManager returns a plugin manager.
 func (ctx *Context) PluginManager(cluster *config.Cluster) *plugin.Manager {
 	pluginManager := plugin.NewManager(ctx.Fs(), ctx.Logger())
+	pluginManager.SetContext(ctx.BaseContext())
+	pluginManager.SetProgressOutput(ctx.progressOutput())
+	pluginManager.SetEnvLookup(ctx.EnvLookup)
+	pluginManager.SetACSTokenResolver(ctx.ACSToken)
+	if ctx.interrupt != nil {
+		pluginManager.SetCleaner(ctx.interrupt)
+	}
 	if cluster != nil {
 		pluginManager.SetCluster(cluster)
 	}
 	return pluginManager
 }
 
+// progressOutput returns the writer to report the progress of transfers to. It returns nil when
+// the progress shouldn't be reported, that is when stderr is not a terminal or with --json.
+func (ctx *Context) progressOutput() io.Writer {
+	if ctx.JSONOutput() || !progress.Enabled(ctx.env.ErrOut) {
+		return nil
+	}
+	return ctx.env.ErrOut
+}
+
 // DCOSDir returns the root directory for the DC/OS CLI.
-// It defaults to `~/.dcos` and can be overriden by the `DCOS_DIR` env var.
+// It defaults to `~/.dcos` and can be overriden by the `--config-dir` flag or the `DCOS_DIR` env var.
 func (ctx *Context) DCOSDir() string {
-	if dcosDir, ok := ctx.env.EnvLookup("DCOS_DIR"); ok {
-		return dcosDir
+	var configDir string
+	if ctx.globalFlags != nil {
+		configDir = ctx.globalFlags.ConfigDir
 	}
-
-	// We use github.com/mitchellh/go-homedir as os/user doesn't work well with cross-compilation.
-	// In the future this could instead be done through the "osusergo" build tag (added in Go 1.11).
-	// See https://tip.golang.org/doc/go1.11#os/user.
-	homeDir, err := homedir.Dir()
+	dcosDir, err := config.Dir(configDir, ctx.env.EnvLookup)
 	if err != nil {
 		// Not being able to detect the homedir is not critical. While it is
 		// very unlikely to happen, we can fallback to the current directory.
-		ctx.Logger().Debugf("Couldn't detect the home directory: %s", err)
+		ctx.Logger().Debugf("Couldn't detect the DC/OS CLI directory: %s", err)
 		return ""
 	}
-	return filepath.Join(homeDir, ".dcos")
+	return dcosDir
 }
 
 // ConfigManager returns the ConfigManager for the context.
@@ -136,30 +201,206 @@ func (ctx *Context) Clusters() []*config.Cluster {
 	return clusters
 }
 
-// HTTPClient creates an httpclient.Client for a given cluster.
+// MigrateLegacyConfig migrates the config of CLIs which only supported a single cluster, if any.
+// The cluster ID is read from the cluster metadata, a one-line notice is printed once migrated.
+func (ctx *Context) MigrateLegacyConfig() {
+	manager := ctx.ConfigManager()
+	cluster, err := manager.MigrateLegacy(func(c *config.Cluster) (string, error) {
+		httpClient := ctx.HTTPClient(c, httpclient.Timeout(5*time.Second), httpclient.FailOnErrStatus(true))
+		metadata, err := dcos.NewClient(httpClient).Metadata()
+		if err != nil {
+			ctx.Logger().Debugf("Couldn't read the cluster ID from the metadata: %s", err)
+			return "", err
+		}
+		return metadata.ClusterID, nil
+	})
+	if err != nil {
+		ctx.Logger().Warnf("Couldn't migrate the legacy config at %s: %s", manager.LegacyConfigPath(), err)
+		return
+	}
+	if cluster != nil {
+		fmt.Fprintf(
+			ctx.ErrOut(), "Migrated the config of %s to cluster %s, the old config was moved to %s.bak.\n",
+			cluster.URL(), cluster.ID(), manager.LegacyConfigPath(),
+		)
+	}
+}
+
+// ClusterVersion returns the DC/OS version of a cluster. It is cached in the `metadata.json` file of the
+// cluster directory for 5 minutes, the --refresh global flag bypasses the cache. Cache files which can't
+// be read are ignored, the version is then fetched again.
+func (ctx *Context) ClusterVersion(cluster *config.Cluster, opts ...httpclient.Option) (*dcos.Version, error) {
+	var metadataCache *cache.Cache
+	if cluster.Config().Path() != "" {
+		metadataCache = cache.New(ctx.Fs(), filepath.Join(cluster.Dir(), "metadata.json"), metadataCacheTTL)
+
+		var version dcos.Version
+		refresh := ctx.globalFlags != nil && ctx.globalFlags.Refresh
+		if !refresh && metadataCache.Get(&version) {
+			return &version, nil
+		}
+	}
+
+	version, err := dcos.NewClient(ctx.HTTPClient(cluster, opts...)).Version()
+	if err != nil {
+		return nil, err
+	}
+	if metadataCache != nil {
+		if err := metadataCache.Set(version); err != nil {
+			ctx.Logger().Debugf("Couldn't cache the cluster metadata: %s", err)
+		}
+	}
+	return version, nil
+}
+
+// versionCheck is the result of a version check, as cached in the `version_check.json` file of a cluster directory.
+type versionCheck struct {
+	CLIVersion  string `json:"cli_version"`
+	DCOSVersion string `json:"dcos_version,omitempty"`
+	Warning     string `json:"warning,omitempty"`
+}
+
+// CheckClusterVersion prints a one-line warning when the CLI version is known to be incompatible with the
+// version of a cluster, it never fails the command. The check is skipped with the --no-version-check global
+// flag or when "core.version_check" is false. Its result is cached in the `version_check.json` file of the
+// cluster directory for 24 hours, the --refresh global flag bypasses the cache. Clusters whose version can't
+// be fetched are considered compatible, they are checked again on the next command.
+func (ctx *Context) CheckClusterVersion(cluster *config.Cluster) {
+	if (ctx.globalFlags != nil && ctx.globalFlags.NoVersionCheck) || !cluster.VersionCheck() {
+		return
+	}
+
+	var check versionCheck
+	var checkCache *cache.Cache
+	if cluster.Config().Path() != "" {
+		checkCache = cache.New(ctx.Fs(), filepath.Join(cluster.Dir(), "version_check.json"), versionCheckCacheTTL)
+
+		refresh := ctx.globalFlags != nil && ctx.globalFlags.Refresh
+		if !refresh && checkCache.Get(&check) && check.CLIVersion == cliVersion() {
+			ctx.printVersionWarning(check.Warning)
+			return
+		}
+	}
+
+	check = versionCheck{CLIVersion: cliVersion()}
+	dcosVersion, err := ctx.ClusterVersion(cluster, httpclient.Timeout(3*time.Second))
+	if err != nil {
+		ctx.Logger().Debugf("Couldn't get the cluster version, skipping the version check: %s", err)
+		return
+	}
+	check.DCOSVersion = dcosVersion.Version
+	if err := version.Check(check.CLIVersion, check.DCOSVersion); err != nil {
+		check.Warning = err.Error()
+	}
+	if checkCache != nil && check.DCOSVersion != "" {
+		if err := checkCache.Set(check); err != nil {
+			ctx.Logger().Debugf("Couldn't cache the version check: %s", err)
+		}
+	}
+	ctx.printVersionWarning(check.Warning)
+}
+
+// printVersionWarning prints the warning of a version check, if any.
+func (ctx *Context) printVersionWarning(warning string) {
+	if warning != "" {
+		fmt.Fprintf(ctx.ErrOut(), "Warning: %s.\n", warning)
+	}
+}
+
+// HTTPClient creates an httpclient.Client for a given cluster. Clients for the same cluster share
+// an HTTP transport, its connection pool can be tuned through the "core.http_*" config keys.
+//
+// The ACS token is resolved from the --token global flag, the DCOS_CLUSTER_TOKEN env var, the file
+// referenced by "core.dcos_acs_token_file", then the cluster config. When it can't be resolved, requests
+// fail instead of being sent unauthenticated. A token stored in the cluster config is checked before each
+// request, it is refreshed when it is about to expire and service account credentials are available in
+// the environment.
 func (ctx *Context) HTTPClient(c *config.Cluster, opts ...httpclient.Option) *httpclient.Client {
 	var baseOpts []httpclient.Option
 
-	if c.ACSToken() != "" {
-		baseOpts = append(baseOpts, httpclient.ACSToken(c.ACSToken()))
-	}
 	if c.Timeout() > 0 {
 		baseOpts = append(baseOpts, httpclient.Timeout(c.Timeout()))
 	}
-	tlsOpt := httpclient.TLS(&tls.Config{
-		InsecureSkipVerify: c.TLS().Insecure,
-		RootCAs:            c.TLS().RootCAs,
-	})
+	baseOpts = append(
+		baseOpts,
+		httpclient.Transport(ctx.transport(c)),
+		httpclient.Logger(ctx.Logger()),
+		httpclient.Context(ctx.BaseContext()),
+	)
+
+	// Retry idempotent requests on transient failures, eg. during a master failover.
+	baseOpts = append(baseOpts, httpclient.Retries(3, 250*time.Millisecond))
 
-	baseOpts = append(baseOpts, tlsOpt, httpclient.Logger(ctx.Logger()))
+	acsToken, err := ctx.ACSToken(c)
+	switch {
+	case err != nil:
+		baseOpts = append(baseOpts, httpclient.BeforeRequest(func(req *http.Request) error {
+			return err
+		}))
+	case acsToken != "" && acsToken == c.ACSToken():
+		refresher := login.NewRefresher(login.RefresherOpts{
+			Cluster:    c,
+			Flags:      login.NewFlags(ctx.Fs(), ctx.EnvLookup, ctx.Logger()),
+			HTTPClient: httpclient.New(c.URL(), baseOpts...),
+			Logger:     ctx.Logger(),
+			Window:     c.TokenRefreshWindow(),
+		})
+		baseOpts = append(
+			baseOpts,
+			httpclient.ACSToken(acsToken),
+			httpclient.BeforeRequest(refresher.BeforeRequest),
+		)
+	case acsToken != "":
+		baseOpts = append(baseOpts, httpclient.ACSToken(acsToken))
+	}
 	opts = append(baseOpts, opts...)
 
 	return httpclient.New(c.URL(), opts...)
 }
 
+// transport returns the HTTP transport for a cluster. It is created once per cluster and shared
+// by its HTTP clients, connections are thus reused across the requests made by a command.
+func (ctx *Context) transport(c *config.Cluster) *http.Transport {
+	ctx.transportMu.Lock()
+	defer ctx.transportMu.Unlock()
+
+	key := c.Config().Path() + "|" + c.URL()
+	if transport, ok := ctx.transports[key]; ok {
+		return transport
+	}
+	transport := httpclient.NewTransport(
+		httpclient.TLS(c.TLSConfig()),
+		httpclient.Proxy(c.Proxy()),
+		httpclient.EnvLookup(ctx.EnvLookup),
+		httpclient.IdleConns(c.MaxIdleConns(), c.MaxIdleConnsPerHost(), c.IdleConnTimeout()),
+	)
+	if ctx.transports == nil {
+		ctx.transports = make(map[string]*http.Transport)
+	}
+	ctx.transports[key] = transport
+	return transport
+}
+
+// ACSToken returns the ACS token for a cluster, the --token global flag takes precedence over
+// the token resolved from the environment and the cluster config (see Cluster.ResolveACSToken).
+func (ctx *Context) ACSToken(c *config.Cluster) (string, error) {
+	if ctx.globalFlags != nil && ctx.globalFlags.Token != "" {
+		return ctx.globalFlags.Token, nil
+	}
+	return c.ResolveACSToken()
+}
+
 // Prompt is able to prompt for input, password or choices.
+// With JSON output, prompts are written to ErrOut in order to keep Out machine-readable.
+// With the --yes global flag, confirmations are accepted without reading the input.
 func (ctx *Context) Prompt() *prompt.Prompt {
-	return prompt.New(ctx.Input(), ctx.Out())
+	out := ctx.Out()
+	if ctx.JSONOutput() {
+		out = ctx.ErrOut()
+	}
+	p := prompt.New(ctx.Input(), out)
+	p.SetAssumeYes(ctx.globalFlags != nil && ctx.globalFlags.Yes)
+	return p
 }
 
 // Opener returns a new OS Opener.
@@ -169,20 +410,32 @@ func (ctx *Context) Opener() open.Opener {
 
 // Login initiates a login based on a set of flags and HTTP client. On success it returns an ACS token.
 func (ctx *Context) Login(flags *login.Flags, httpClient *httpclient.Client) (string, error) {
-	return ctx.loginFlow().Start(flags, httpClient)
+	loginFlow := ctx.loginFlow()
+	if cluster, err := ctx.Cluster(); err == nil {
+		loginFlow.SetProxy(cluster.Proxy())
+	}
+	return loginFlow.Start(flags, httpClient)
 }
 
 // Setup configures a given cluster based on its URL and setup flags.
 func (ctx *Context) Setup(flags *setup.Flags, clusterURL string, attach bool) (*config.Cluster, error) {
-	if !strings.HasPrefix(clusterURL, "https://") && !strings.HasPrefix(clusterURL, "http://") {
+	if clusterURL != "" && !strings.HasPrefix(clusterURL, "https://") && !strings.HasPrefix(clusterURL, "http://") {
 		ctx.Logger().Info("Missing scheme in cluster URL, assuming HTTPS.")
 		clusterURL = "https://" + clusterURL
 	}
+	if clusterURL != "" {
+		var err error
+		if clusterURL, err = config.NormalizeURL(clusterURL); err != nil {
+			return nil, err
+		}
+	}
 
 	return setup.New(setup.Opts{
+		Context:       ctx.BaseContext(),
 		Fs:            ctx.Fs(),
 		Errout:        ctx.ErrOut(),
 		EnvLookup:     ctx.EnvLookup,
+		ACSToken:      ctx.ACSToken,
 		Prompt:        ctx.Prompt(),
 		Logger:        ctx.Logger(),
 		LoginFlow:     ctx.loginFlow(),
@@ -193,9 +446,11 @@ func (ctx *Context) Setup(flags *setup.Flags, clusterURL string, attach bool) (*
 
 func (ctx *Context) loginFlow() *login.Flow {
 	return login.NewFlow(login.FlowOpts{
-		Errout: ctx.ErrOut(),
-		Prompt: ctx.Prompt(),
-		Logger: ctx.Logger(),
-		Opener: ctx.Opener(),
+		Errout:    ctx.ErrOut(),
+		Prompt:    ctx.Prompt(),
+		Logger:    ctx.Logger(),
+		Opener:    ctx.Opener(),
+		Context:   ctx.BaseContext(),
+		EnvLookup: ctx.EnvLookup,
 	})
 }
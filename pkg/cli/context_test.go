@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClusterVersion(t *testing.T) {
+	defaultCLIVersion := cliVersion
+	cliVersion = func() string { return "0.8.3" }
+	defer func() { cliVersion = defaultCLIVersion }()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/dcos-metadata/dcos-version.json", r.URL.Path)
+		requests++
+		json.NewEncoder(w).Encode(map[string]string{"version": "2.0.1"})
+	}))
+	defer ts.Close()
+
+	var errout bytes.Buffer
+	env := &Environment{
+		ErrOut: &errout,
+		Fs:     afero.NewMemMapFs(),
+		EnvLookup: func(key string) (string, bool) {
+			return "", false
+		},
+	}
+	ctx := NewContext(env)
+	ctx.SetGlobalFlags(&GlobalFlags{})
+
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.SetPath("/dcos/clusters/a8f1dfbb-5d6b-4a11-bd3e-0bd5d6ae0cfc/dcos.toml")
+	conf.Set("core.dcos_url", ts.URL)
+	cluster := config.NewCluster(conf)
+
+	ctx.CheckClusterVersion(cluster)
+	require.Equal(t, "Warning: the DC/OS CLI 0.8.3 is too old for DC/OS 2.0.1, please update it to 1.0.0 or later.\n", errout.String())
+	require.Equal(t, 1, requests)
+
+	// The result of the check is cached.
+	errout.Reset()
+	ctx.CheckClusterVersion(cluster)
+	require.Equal(t, "Warning: the DC/OS CLI 0.8.3 is too old for DC/OS 2.0.1, please update it to 1.0.0 or later.\n", errout.String())
+	require.Equal(t, 1, requests)
+
+	// The cache doesn't apply to other CLI versions.
+	cliVersion = func() string { return "1.0.0" }
+	errout.Reset()
+	ctx.CheckClusterVersion(cluster)
+	require.Empty(t, errout.String())
+
+	// The check can be disabled through the --no-version-check global flag or the config.
+	cliVersion = func() string { return "0.8.3" }
+	ctx.SetGlobalFlags(&GlobalFlags{NoVersionCheck: true})
+	ctx.CheckClusterVersion(cluster)
+	require.Empty(t, errout.String())
+
+	ctx.SetGlobalFlags(&GlobalFlags{})
+	conf.Set("core.version_check", false)
+	ctx.CheckClusterVersion(cluster)
+	require.Empty(t, errout.String())
+}
+
+func TestCheckClusterVersionUnreachable(t *testing.T) {
+	defaultCLIVersion := cliVersion
+	cliVersion = func() string { return "0.8.3" }
+	defer func() { cliVersion = defaultCLIVersion }()
+
+	reachable := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !reachable {
+			w.WriteHeader(503)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"version": "2.0.1"})
+	}))
+	defer ts.Close()
+
+	var errout bytes.Buffer
+	env := &Environment{
+		ErrOut: &errout,
+		Fs:     afero.NewMemMapFs(),
+		EnvLookup: func(key string) (string, bool) {
+			return "", false
+		},
+	}
+	ctx := NewContext(env)
+
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.SetPath("/dcos/clusters/a8f1dfbb-5d6b-4a11-bd3e-0bd5d6ae0cfc/dcos.toml")
+	conf.Set("core.dcos_url", ts.URL)
+
+	// The check never fails the command.
+	ctx.CheckClusterVersion(config.NewCluster(conf))
+	require.Empty(t, errout.String())
+
+	// The failed check isn't cached, the cluster is checked again once it is reachable.
+	reachable = true
+	ctx.CheckClusterVersion(config.NewCluster(conf))
+	require.Equal(t, "Warning: the DC/OS CLI 0.8.3 is too old for DC/OS 2.0.1, please update it to 1.0.0 or later.\n", errout.String())
+}
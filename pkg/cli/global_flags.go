@@ -1,23 +1,40 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 )
 
 // GlobalFlags represents the DC/OS CLI global flags.
 type GlobalFlags struct {
-	Verbosity int
-	LogLevel  string
-	Debug     bool
-	Version   bool
+	Verbosity      int
+	LogLevel       string
+	Debug          bool
+	Version        bool
+	JSON           bool
+	Yes            bool
+	Refresh        bool
+	NoVersionCheck bool
+	Token          string
+	ConfigDir      string
+	Timeout        time.Duration
 }
 
 // Parse parses the DC/OS CLI global flags, it accepts the following:
 //   - `-v`, `-vv`: defines the verbosity level.
-//   - `--log-level=[level]` (deprecated): sets the log-level.
+//   - `--log-level=[level]`: sets the log level (error, info or debug).
 //   - `--debug` (deprecated): sets the log-level to "debug".
 //   - `--version`: displays the DC/OS CLI and cluster versions.
-func (gf *GlobalFlags) Parse(args []string) []string {
+//   - `--json`: prints the output of commands in JSON format.
+//   - `--yes`, `-y`: answers yes to confirmation prompts.
+//   - `--refresh`: fetches cluster metadata instead of reading it from the cache.
+//   - `--no-version-check`: doesn't warn when the CLI and cluster versions are incompatible.
+//   - `--timeout=[duration]`: bounds the time spent by the command (eg. "30s").
+//   - `--token=[token]`: authenticates requests to the cluster with a given ACS token.
+//   - `--config-dir=[dir]`: uses a given directory instead of `~/.dcos` (eg. for isolated CI jobs).
+func (gf *GlobalFlags) Parse(args []string) ([]string, error) {
 	var i int
 ParseLoop:
 	for argsLen := len(args); i < argsLen; i++ {
@@ -30,18 +47,64 @@ ParseLoop:
 			gf.Version = true
 		case "--debug":
 			gf.Debug = true
+		case "--json":
+			gf.JSON = true
+		case "--yes", "-y":
+			gf.Yes = true
+		case "--refresh":
+			gf.Refresh = true
+		case "--no-version-check":
+			gf.NoVersionCheck = true
 		case "--log-level":
 			if len(args) >= i+2 {
 				gf.LogLevel = args[i+1]
 				i++
 			}
+		case "--timeout":
+			if len(args) < i+2 {
+				return nil, errors.New("--timeout requires a duration")
+			}
+			if err := gf.parseTimeout(args[i+1]); err != nil {
+				return nil, err
+			}
+			i++
+		case "--token":
+			if len(args) < i+2 {
+				return nil, errors.New("--token requires a value")
+			}
+			gf.Token = args[i+1]
+			i++
+		case "--config-dir":
+			if len(args) < i+2 {
+				return nil, errors.New("--config-dir requires a directory")
+			}
+			gf.ConfigDir = args[i+1]
+			i++
 		default:
 			if strings.HasPrefix(args[i], "--log-level=") {
 				gf.LogLevel = strings.TrimPrefix(args[i], "--log-level=")
+			} else if strings.HasPrefix(args[i], "--timeout=") {
+				if err := gf.parseTimeout(strings.TrimPrefix(args[i], "--timeout=")); err != nil {
+					return nil, err
+				}
+			} else if strings.HasPrefix(args[i], "--token=") {
+				gf.Token = strings.TrimPrefix(args[i], "--token=")
+			} else if strings.HasPrefix(args[i], "--config-dir=") {
+				gf.ConfigDir = strings.TrimPrefix(args[i], "--config-dir=")
 			} else {
 				break ParseLoop
 			}
 		}
 	}
-	return args[i:]
+	return args[i:], nil
+}
+
+// parseTimeout parses the value of the --timeout flag.
+func (gf *GlobalFlags) parseTimeout(val string) error {
+	timeout, err := time.ParseDuration(val)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("invalid --timeout value '%s', expected a positive duration (eg. 30s)", val)
+	}
+	gf.Timeout = timeout
+	return nil
 }
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -38,6 +39,74 @@ func TestParseGlobalFlags(t *testing.T) {
 				Verbosity: 2,
 			},
 		},
+		{
+			[]string{"--json", "cluster", "list"},
+			[]string{"cluster", "list"},
+			GlobalFlags{
+				JSON: true,
+			},
+		},
+		{
+			[]string{"-y", "--json", "cluster", "remove", "--yes"},
+			[]string{"cluster", "remove", "--yes"},
+			GlobalFlags{
+				JSON: true,
+				Yes:  true,
+			},
+		},
+		{
+			[]string{"--refresh", "--version"},
+			[]string{},
+			GlobalFlags{
+				Version: true,
+				Refresh: true,
+			},
+		},
+		{
+			[]string{"--no-version-check", "-v", "task", "list"},
+			[]string{"task", "list"},
+			GlobalFlags{
+				NoVersionCheck: true,
+				Verbosity:      1,
+			},
+		},
+		{
+			[]string{"--token", "abc", "--token=def", "auth", "whoami"},
+			[]string{"auth", "whoami"},
+			GlobalFlags{
+				Token: "def",
+			},
+		},
+		{
+			[]string{"--config-dir", "/tmp/dcos-staging", "cluster", "list"},
+			[]string{"cluster", "list"},
+			GlobalFlags{
+				ConfigDir: "/tmp/dcos-staging",
+			},
+		},
+		{
+			[]string{"--config-dir=/tmp/dcos-prod", "--json", "config", "show"},
+			[]string{"config", "show"},
+			GlobalFlags{
+				ConfigDir: "/tmp/dcos-prod",
+				JSON:      true,
+			},
+		},
+		{
+			[]string{"--timeout", "30s", "cluster", "list"},
+			[]string{"cluster", "list"},
+			GlobalFlags{
+				Timeout: 30 * time.Second,
+			},
+		},
+		{
+			[]string{"--timeout=1m", "-v", "cluster"},
+			[]string{"cluster"},
+			GlobalFlags{
+				Timeout:   time.Minute,
+				Verbosity: 1,
+			},
+		},
 		{
 			[]string{"--log-level=warning", "cluster", "-vv"},
 			[]string{"cluster", "-vv"},
@@ -49,8 +118,23 @@ func TestParseGlobalFlags(t *testing.T) {
 
 	for _, fixture := range fixtures {
 		var gf GlobalFlags
-		args := gf.Parse(fixture.args)
+		args, err := gf.Parse(fixture.args)
+		require.NoError(t, err)
 		require.Equal(t, fixture.flags, gf)
 		require.Equal(t, fixture.argsWoFlags, args)
 	}
 }
+
+func TestParseInvalidTimeout(t *testing.T) {
+	for _, args := range [][]string{{"--timeout"}, {"--timeout", "soon"}, {"--timeout=-5s"}, {"--timeout=0"}} {
+		var gf GlobalFlags
+		_, err := gf.Parse(args)
+		require.Error(t, err, args)
+	}
+}
+
+func TestParseMissingConfigDir(t *testing.T) {
+	var gf GlobalFlags
+	_, err := gf.Parse([]string{"--config-dir"})
+	require.EqualError(t, err, "--config-dir requires a directory")
+}
@@ -0,0 +1,13 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// PrintJSON writes the JSON encoding of v to a writer, indented with 4 spaces.
+func PrintJSON(writer io.Writer, v interface{}) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "    ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, PrintJSON(&out, map[string][]string{"clusters": {"prod"}}))
+
+	expectedJSON := `{
+    "clusters": [
+        "prod"
+    ]
+}
+`
+	require.Equal(t, expectedJSON, out.String())
+}
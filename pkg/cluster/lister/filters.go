@@ -5,6 +5,7 @@ type Filters struct {
 	AttachedOnly bool
 	Status       string
 	Linked       bool
+	SkipStatus   bool
 }
 
 // Filter is a functional option for list filters.
@@ -30,3 +31,11 @@ func Linked() Filter {
 		filters.Linked = true
 	}
 }
+
+// SkipStatus indicates that the status and version of clusters shouldn't be fetched,
+// this avoids a request to each cluster when these fields are not needed.
+func SkipStatus() Filter {
+	return func(filters *Filters) {
+		filters.SkipStatus = true
+	}
+}
@@ -1,7 +1,9 @@
 package lister
 
 import (
-	"crypto/tls"
+	"context"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -21,6 +23,9 @@ const (
 
 	// StatusUnconfigured refers to an unconfigured cluster (a linked cluster).
 	StatusUnconfigured = "UNCONFIGURED"
+
+	// StatusUnknown refers to a cluster whose status hasn't been fetched.
+	StatusUnknown = "UNKNOWN"
 )
 
 // Item represents a cluster item in the list.
@@ -39,12 +44,19 @@ func (i *Item) Cluster() *config.Cluster {
 	return i.cluster
 }
 
+// DefaultParallelism is the default number of clusters whose status is fetched concurrently.
+const DefaultParallelism = 8
+
 // Lister is able to retrieve locally configured clusters as well as linked clusters.
 type Lister struct {
 	configManager  *config.Manager
 	linker         *linker.Linker
 	currentCluster *config.Cluster
 	logger         *logrus.Logger
+	ctx            context.Context
+	parallelism    int
+	envLookup      func(key string) (string, bool)
+	acsToken       func(cluster *config.Cluster) (string, error)
 }
 
 // New creates a new cluster lister.
@@ -52,6 +64,8 @@ func New(configManager *config.Manager, logger *logrus.Logger) *Lister {
 	lister := &Lister{
 		configManager: configManager,
 		logger:        logger,
+		parallelism:   DefaultParallelism,
+		acsToken:      (*config.Cluster).ResolveACSToken,
 	}
 	if currentConfig, err := configManager.Current(); err == nil {
 		lister.currentCluster = config.NewCluster(currentConfig)
@@ -60,107 +74,162 @@ func New(configManager *config.Manager, logger *logrus.Logger) *Lister {
 	return lister
 }
 
+// SetContext sets the context status requests are derived from, it usually carries the global timeout.
+func (l *Lister) SetContext(ctx context.Context) {
+	l.ctx = ctx
+	if l.currentCluster != nil {
+		l.linker = linker.New(l.httpClient(l.currentCluster), nil)
+	}
+}
+
+// SetEnvLookup sets the function used to lookup env vars, eg. the proxy ones for status requests.
+func (l *Lister) SetEnvLookup(envLookup func(key string) (string, bool)) {
+	l.envLookup = envLookup
+	if l.currentCluster != nil {
+		l.linker = linker.New(l.httpClient(l.currentCluster), nil)
+	}
+}
+
+// SetACSTokenResolver sets the function resolving the ACS token for requests to a cluster.
+// It defaults to Cluster.ResolveACSToken.
+func (l *Lister) SetACSTokenResolver(acsToken func(cluster *config.Cluster) (string, error)) {
+	l.acsToken = acsToken
+	if l.currentCluster != nil {
+		l.linker = linker.New(l.httpClient(l.currentCluster), nil)
+	}
+}
+
+// SetParallelism sets the maximum number of clusters whose status is fetched concurrently.
+func (l *Lister) SetParallelism(parallelism int) {
+	if parallelism > 0 {
+		l.parallelism = parallelism
+	}
+}
+
 // List retrieves all known clusters.
 func (l *Lister) List(filters ...Filter) []*Item {
-	items := []*Item{}
-
 	listFilters := Filters{}
 	for _, filter := range filters {
 		filter(&listFilters)
 	}
 
-	clusters := make(chan *config.Cluster)
-	go func() {
-		l.logger.Info("Reading configured clusters...")
-		configuredClusterIDs := make(map[string]bool)
-		configs := l.configManager.All()
-
-		for _, conf := range configs {
-			cluster := config.NewCluster(conf)
-			clusters <- cluster
-			configuredClusterIDs[cluster.ID()] = true
-		}
-
-		if listFilters.Linked && l.linker != nil {
-			l.logger.Info("Fetching linked clusters...")
-			links, err := l.linker.Links()
-			if err != nil {
-				l.logger.Debug(err)
-			} else {
-				for _, link := range links {
-					if _, ok := configuredClusterIDs[link.ID]; !ok {
-						clusters <- link.ToCluster()
-					}
-				}
-			}
-		}
-		close(clusters)
-	}()
+	clusters := l.clusters(listFilters)
 
-	var mu sync.Mutex
+	// Fetch the status of clusters through a bounded pool of goroutines. Each item is stored
+	// at the index of its cluster, the result thus doesn't depend on the order probes complete.
+	results := make([]*Item, len(clusters))
+	sem := make(chan struct{}, l.parallelism)
 
 	var wg sync.WaitGroup
-	for cluster := range clusters {
+	for i, cluster := range clusters {
 		wg.Add(1)
-		go func(cluster *config.Cluster) {
+		sem <- struct{}{}
+		go func(i int, cluster *config.Cluster) {
 			defer wg.Done()
-			item := &Item{
-				ID:      cluster.ID(),
-				Name:    cluster.Name(),
-				URL:     cluster.URL(),
-				Status:  StatusUnavailable,
-				Version: "UNKNOWN",
-				cluster: cluster,
-			}
-			if l.currentCluster != nil {
-				item.Attached = (cluster.Config().Path() == l.currentCluster.Config().Path())
-			}
-
-			if listFilters.AttachedOnly && !item.Attached {
-				return
-			}
+			defer func() { <-sem }()
+			results[i] = l.item(cluster, listFilters)
+		}(i, cluster)
+	}
+	wg.Wait()
 
-			httpClient := l.httpClient(cluster)
-			version, err := dcos.NewClient(httpClient).Version()
-			if err == nil {
-				item.Status = StatusAvailable
-				item.Version = version.Version
-			}
+	items := []*Item{}
+	for _, item := range results {
+		if item != nil {
+			items = append(items, item)
+		}
+	}
 
-			if cluster.Config().Path() == "" {
-				item.Status = StatusUnconfigured
-			}
+	// Order clusters by name, this guarantees a stable list.
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
 
-			if listFilters.Status != "" && item.Status != listFilters.Status {
-				return
-			}
+// clusters returns the configured clusters, followed by linked clusters which are not configured.
+func (l *Lister) clusters(listFilters Filters) []*config.Cluster {
+	var clusters []*config.Cluster
 
-			mu.Lock()
-			defer mu.Unlock()
+	l.logger.Info("Reading configured clusters...")
+	configuredClusterIDs := make(map[string]bool)
+	for _, conf := range l.configManager.All() {
+		cluster := config.NewCluster(conf)
+		clusters = append(clusters, cluster)
+		configuredClusterIDs[cluster.ID()] = true
+	}
 
-			// Order clusters by name, this guarantees a stable list.
-			for i := range items {
-				if items[i].Name > item.Name {
-					items = append(items[:i], append([]*Item{item}, items[i:]...)...)
-					return
+	if listFilters.Linked && l.linker != nil {
+		l.logger.Info("Fetching linked clusters...")
+		links, err := l.linker.Links()
+		if err != nil {
+			l.logger.Debug(err)
+		} else {
+			for _, link := range links {
+				if _, ok := configuredClusterIDs[link.ID]; !ok {
+					clusters = append(clusters, link.ToCluster())
 				}
 			}
-			items = append(items, item)
-		}(cluster)
+		}
 	}
-	wg.Wait()
-	return items
+	return clusters
+}
+
+// item creates the list item for a cluster, it returns nil when the item is filtered out.
+func (l *Lister) item(cluster *config.Cluster, listFilters Filters) *Item {
+	item := &Item{
+		ID:      cluster.ID(),
+		Name:    cluster.Name(),
+		URL:     cluster.URL(),
+		Status:  StatusUnavailable,
+		Version: "UNKNOWN",
+		cluster: cluster,
+	}
+	if l.currentCluster != nil {
+		item.Attached = (cluster.Config().Path() == l.currentCluster.Config().Path())
+	}
+
+	if listFilters.AttachedOnly && !item.Attached {
+		return nil
+	}
+
+	if listFilters.SkipStatus && listFilters.Status == "" {
+		item.Status = StatusUnknown
+	} else {
+		httpClient := l.httpClient(cluster)
+		version, err := dcos.NewClient(httpClient).Version()
+		if err == nil {
+			item.Status = StatusAvailable
+			item.Version = version.Version
+		}
+	}
+
+	if cluster.Config().Path() == "" {
+		item.Status = StatusUnconfigured
+	}
+
+	if listFilters.Status != "" && item.Status != listFilters.Status {
+		return nil
+	}
+	return item
 }
 
 func (l *Lister) httpClient(cluster *config.Cluster) *httpclient.Client {
-	return httpclient.New(
-		cluster.URL(),
+	httpOpts := []httpclient.Option{
 		httpclient.Logger(l.logger),
-		httpclient.ACSToken(cluster.ACSToken()),
-		httpclient.Timeout(3*time.Second),
-		httpclient.TLS(&tls.Config{
-			InsecureSkipVerify: cluster.TLS().Insecure,
-			RootCAs:            cluster.TLS().RootCAs,
-		}),
-	)
+		httpclient.Timeout(3 * time.Second),
+		httpclient.TLS(cluster.TLSConfig()),
+		httpclient.Proxy(cluster.Proxy()),
+		httpclient.EnvLookup(l.envLookup),
+		httpclient.Context(l.ctx),
+	}
+	acsToken, err := l.acsToken(cluster)
+	switch {
+	case err != nil:
+		httpOpts = append(httpOpts, httpclient.BeforeRequest(func(req *http.Request) error {
+			return err
+		}))
+	case acsToken != "":
+		httpOpts = append(httpOpts, httpclient.ACSToken(acsToken))
+	}
+	return httpclient.New(cluster.URL(), httpOpts...)
 }
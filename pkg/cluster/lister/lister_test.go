@@ -2,9 +2,14 @@ package lister
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
 
@@ -119,3 +124,112 @@ func TestListIgnoresLegacyConfig(t *testing.T) {
 	require.Len(t, lister.List(AttachedOnly()), 0)
 	require.Len(t, lister.List(), 0)
 }
+
+func TestListParallelism(t *testing.T) {
+	env := mock.NewEnvironment()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"version": "1.13"}`))
+	}))
+	defer ts.Close()
+
+	names := []string{"d", "b", "a", "c", "e"}
+	for i, name := range names {
+		conf := config.New(config.Opts{Fs: env.Fs})
+		conf.Set("core.dcos_url", ts.URL)
+		conf.Set("cluster.name", name)
+		conf.SetPath(filepath.Join("clusters", fmt.Sprintf("cluster-%d", i), "dcos.toml"))
+		require.NoError(t, conf.Persist())
+	}
+
+	logger, _ := test.NewNullLogger()
+	lister := New(config.NewManager(config.ManagerOpts{
+		Fs:        env.Fs,
+		EnvLookup: env.EnvLookup,
+	}), logger)
+	lister.SetParallelism(2)
+
+	items := lister.List()
+	require.Len(t, items, len(names))
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		require.Equal(t, name, items[i].Name)
+		require.Equal(t, StatusAvailable, items[i].Status)
+	}
+	require.True(t, maxInFlight <= 2, "%d status requests were sent concurrently", maxInFlight)
+}
+
+func TestListContext(t *testing.T) {
+	env := mock.NewEnvironment()
+
+	ts := mock.NewTestServer(mock.Cluster{Version: "1.12"})
+	defer ts.Close()
+
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.Set("core.dcos_url", ts.URL)
+	conf.SetPath(filepath.Join("clusters", "1234-56789-01234", "dcos.toml"))
+	require.NoError(t, conf.Persist())
+
+	logger, _ := test.NewNullLogger()
+	lister := New(config.NewManager(config.ManagerOpts{
+		Fs:        env.Fs,
+		EnvLookup: env.EnvLookup,
+	}), logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lister.SetContext(ctx)
+
+	items := lister.List()
+	require.Len(t, items, 1)
+	require.Equal(t, StatusUnavailable, items[0].Status)
+}
+
+func TestListACSTokenResolver(t *testing.T) {
+	env := mock.NewEnvironment()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "token=flag-token" {
+			w.WriteHeader(401)
+			return
+		}
+		w.Write([]byte(`{"version": "1.13"}`))
+	}))
+	defer ts.Close()
+
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.Set("core.dcos_url", ts.URL)
+	conf.Set("core.dcos_acs_token", "stored-token")
+	conf.SetPath(filepath.Join("clusters", "1234-56789-01234", "dcos.toml"))
+	require.NoError(t, conf.Persist())
+
+	logger, _ := test.NewNullLogger()
+	lister := New(config.NewManager(config.ManagerOpts{
+		Fs:        env.Fs,
+		EnvLookup: env.EnvLookup,
+	}), logger)
+
+	items := lister.List()
+	require.Len(t, items, 1)
+	require.Equal(t, StatusUnavailable, items[0].Status)
+
+	lister.SetACSTokenResolver(func(cluster *config.Cluster) (string, error) {
+		return "flag-token", nil
+	})
+	items = lister.List()
+	require.Len(t, items, 1)
+	require.Equal(t, StatusAvailable, items[0].Status)
+}
@@ -15,6 +15,7 @@ func NewCommand(ctx api.Context) *cobra.Command {
 		newCmdAuthListProviders(ctx),
 		newCmdAuthLogin(ctx),
 		newCmdAuthLogout(ctx),
+		newCmdAuthWhoami(ctx),
 	)
 	return cmd
 }
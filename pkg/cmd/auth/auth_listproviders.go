@@ -1,8 +1,6 @@
 package auth
 
 import (
-	"encoding/json"
-
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-cli/pkg/cli"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
@@ -35,10 +33,8 @@ func newCmdAuthListProviders(ctx api.Context) *cobra.Command {
 				return err
 			}
 
-			if jsonOutput {
-				enc := json.NewEncoder(ctx.Out())
-				enc.SetIndent("", "    ")
-				return enc.Encode(providers)
+			if jsonOutput || ctx.JSONOutput() {
+				return cli.PrintJSON(ctx.Out(), providers)
 			}
 
 			table := cli.NewTable(ctx.Out(), []string{"PROVIDER ID", "LOGIN METHOD"})
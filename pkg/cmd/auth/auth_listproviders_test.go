@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/dcos/dcos-cli/pkg/login"
@@ -62,3 +63,23 @@ func TestAuthListProvidersTable(t *testing.T) {
 		require.Equal(t, exp.String(), out.String())
 	}
 }
+
+func TestAuthListProvidersGlobalJSON(t *testing.T) {
+	ts := mock.NewTestServer(mock.Cluster{AuthChallenge: "acsjwt"})
+	defer ts.Close()
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+
+	ctx := cli.NewContext(env)
+	ctx.SetGlobalFlags(&cli.GlobalFlags{JSON: true})
+
+	cmd := newCmdAuthListProviders(ctx)
+	cmd.SetArgs([]string{ts.URL})
+	require.NoError(t, cmd.Execute())
+
+	var providers login.Providers
+	require.NoError(t, json.Unmarshal(out.Bytes(), &providers))
+	require.Contains(t, providers, "dcos-users")
+}
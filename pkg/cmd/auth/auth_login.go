@@ -2,6 +2,8 @@ package auth
 
 import (
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
 	"github.com/dcos/dcos-cli/pkg/login"
 	"github.com/spf13/cobra"
 )
@@ -18,12 +20,15 @@ func newCmdAuthLogin(ctx api.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			acsToken, err := ctx.Login(flags, ctx.HTTPClient(cluster))
+			// The current ACS token might be expired, don't check it as we're about to replace it.
+			httpClient := ctx.HTTPClient(cluster, httpclient.BeforeRequest(nil))
+			acsToken, err := ctx.Login(flags, httpClient)
 			if err != nil {
 				return err
 			}
-			cluster.SetACSToken(acsToken)
-			err = cluster.Config().Persist()
+			err = cluster.Config().Update(func(conf *config.Config) {
+				cluster.SetACSToken(acsToken)
+			})
 			if err != nil {
 				return err
 			}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +18,9 @@ func newCmdAuthLogout(ctx api.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			conf.Unset("core.dcos_acs_token")
-			err = conf.Persist()
+			err = conf.Update(func(conf *config.Config) {
+				conf.Unset("core.dcos_acs_token")
+			})
 			if err != nil {
 				return err
 			}
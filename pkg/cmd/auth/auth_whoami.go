@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-cli/pkg/login"
+	"github.com/spf13/cobra"
+)
+
+// whoami is the output of the `dcos auth whoami` subcommand.
+type whoami struct {
+	UID       string      `json:"uid"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	Expired   bool        `json:"expired"`
+	User      *login.User `json:"user,omitempty"`
+}
+
+// newCmdAuthWhoami creates the `dcos auth whoami` subcommand.
+// The ACS token of the current cluster is decoded locally, its signature is not verified.
+func newCmdAuthWhoami(ctx api.Context) *cobra.Command {
+	var details bool
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Print the user the CLI is logged in as on the current cluster",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cluster, err := ctx.Cluster()
+			if err != nil {
+				return err
+			}
+			acsToken, err := ctx.ACSToken(cluster)
+			if err != nil {
+				return err
+			}
+			if acsToken == "" {
+				return errors.New("not logged in, run `dcos auth login`")
+			}
+			claims, err := login.ParseToken(acsToken)
+			if err != nil {
+				return fmt.Errorf("couldn't decode the ACS token: %s", err)
+			}
+
+			output := whoami{
+				UID:     claims.Subject(),
+				Expired: claims.Expired(time.Now()),
+			}
+			if expiry := claims.Expiry(); !expiry.IsZero() {
+				output.ExpiresAt = &expiry
+			}
+
+			if details {
+				client := login.NewClient(ctx.HTTPClient(cluster), ctx.Logger())
+				output.User, err = client.User(output.UID)
+				if err != nil {
+					return err
+				}
+			}
+
+			if ctx.JSONOutput() {
+				return cli.PrintJSON(ctx.Out(), output)
+			}
+
+			fmt.Fprintf(ctx.Out(), "UID: %s\n", output.UID)
+			if output.ExpiresAt != nil {
+				expiry := output.ExpiresAt.Local().Format(time.RFC1123)
+				if output.Expired {
+					expiry += " (expired)"
+				}
+				fmt.Fprintf(ctx.Out(), "Expires: %s\n", expiry)
+			} else {
+				fmt.Fprintln(ctx.Out(), "Expires: never")
+			}
+			if output.User != nil {
+				fmt.Fprintf(ctx.Out(), "Description: %s\n", output.User.Description)
+				fmt.Fprintf(ctx.Out(), "Service account: %t\n", output.User.IsService)
+				if output.User.ProviderType != "" {
+					fmt.Fprintf(ctx.Out(), "Provider: %s\n", output.User.ProviderType)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&details, "details", false, "Fetch the user account details from the cluster")
+	return cmd
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthWhoamiExpired(t *testing.T) {
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	ctx := mock.NewContext(env)
+	ctx.SetCluster(whoamiCluster(t, "", time.Now().Add(-time.Hour)))
+
+	cmd := newCmdAuthWhoami(ctx)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "UID: ci-account\n")
+	require.Contains(t, out.String(), "(expired)")
+}
+
+func TestAuthWhoamiDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/acs/api/v1/users/ci-account", req.URL.Path)
+		w.Write([]byte(`{"uid":"ci-account","description":"CI account","is_service":true}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	ctx := mock.NewContext(env)
+	ctx.SetGlobalFlags(&cli.GlobalFlags{JSON: true})
+	ctx.SetCluster(whoamiCluster(t, ts.URL, time.Now().Add(time.Hour)))
+
+	cmd := newCmdAuthWhoami(ctx)
+	cmd.SetArgs([]string{"--details"})
+	require.NoError(t, cmd.Execute())
+
+	var output whoami
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	require.Equal(t, "ci-account", output.UID)
+	require.False(t, output.Expired)
+	require.NotNil(t, output.ExpiresAt)
+	require.Equal(t, "CI account", output.User.Description)
+	require.True(t, output.User.IsService)
+}
+
+func whoamiCluster(t *testing.T, url string, expiry time.Time) *config.Cluster {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid": "ci-account",
+		"exp": expiry.Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	cluster := config.NewCluster(nil)
+	cluster.SetURL(url)
+	cluster.SetACSToken(token)
+	return cluster
+}
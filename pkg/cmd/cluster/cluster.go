@@ -13,6 +13,7 @@ func NewCommand(ctx api.Context) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newCmdClusterAttach(ctx),
+		newCmdClusterExport(ctx),
 		newCmdClusterLink(ctx),
 		newCmdClusterList(ctx),
 		newCmdClusterRemove(ctx),
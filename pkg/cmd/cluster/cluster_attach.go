@@ -1,8 +1,6 @@
 package cluster
 
 import (
-	"strings"
-
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-cli/pkg/cluster/linker"
 	"github.com/dcos/dcos-cli/pkg/config"
@@ -15,14 +13,15 @@ func newCmdClusterAttach(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "attach <cluster>",
 		Short: "Attach the CLI to a cluster",
+		Long:  "Attach the CLI to a cluster.\n\nThe cluster can be referred to by its ID or name, a unique prefix of its ID, or a part of its name.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			manager := ctx.ConfigManager()
 
 			// We try to find a Config matching the argument given.
-			matchingConf, err := manager.Find(args[0], false)
-			if err == config.ErrTooManyConfigs {
-				return err
+			matchingConf, resolveErr := manager.ResolveCluster(args[0])
+			if _, ok := resolveErr.(*config.AmbiguousClusterError); ok {
+				return resolveErr
 			}
 
 			attachTo := func(conf *config.Config) error {
@@ -35,10 +34,14 @@ func newCmdClusterAttach(ctx api.Context) *cobra.Command {
 
 			currentCluster, err := ctx.Cluster()
 			if err != nil {
-				if err == config.ErrConfigNotFound {
+				if err != config.ErrConfigNotFound && err != config.ErrNoClusterAttached && err != config.ErrTooManyClustersAttached {
+					return err
+				}
+				// Linked clusters can only be retrieved through the current cluster.
+				if matchingConf != nil {
 					return attachTo(matchingConf)
 				}
-				return err
+				return resolveErr
 			}
 
 			clusterLinker := linker.New(ctx.HTTPClient(currentCluster), ctx.Logger())
@@ -47,36 +50,43 @@ func newCmdClusterAttach(ctx api.Context) *cobra.Command {
 				ctx.Logger().Info(err)
 			}
 
-			// We try to find a Link matching the argument given.
+			// We try to find a Link matching the argument given, links to
+			// the cluster which has already been found locally are skipped.
 			ctx.Logger().Info("Looking for linked cluster...")
-			matchingLinkedClusters := linkedClusters[:0]
+			var matchingLinkedClusters []*linker.Link
 			for _, linkedCluster := range linkedClusters {
-				if args[0] == linkedCluster.Name || strings.HasPrefix(linkedCluster.ID, args[0]) {
+				if matchingConf != nil && linkedCluster.ID == config.NewCluster(matchingConf).ID() {
+					continue
+				}
+				if config.MatchCluster(args[0], linkedCluster.ID, linkedCluster.Name) != config.MatchNone {
 					matchingLinkedClusters = append(matchingLinkedClusters, linkedCluster)
 				}
 			}
 
 			// We act depending on the clusters we have found.
-			switch len(matchingLinkedClusters) {
-			case 0:
+			switch {
+			case len(matchingLinkedClusters) == 0 && matchingConf != nil:
 				// No matching linked cluster, one matching cluster.
-				if matchingConf != nil {
-					return attachTo(matchingConf)
-				}
+				return attachTo(matchingConf)
+			case len(matchingLinkedClusters) == 0:
 				// No matching linked cluster, no matching cluster.
-				return config.ErrConfigNotFound
-			case 1:
-				// One matching linked cluster, one matching cluster.
-				if matchingConf != nil {
-					return config.ErrTooManyConfigs
-				}
+				return resolveErr
+			case len(matchingLinkedClusters) == 1 && matchingConf == nil:
 				// One matching linked cluster, no matching cluster.
 				flags := setup.NewFlags(ctx.Fs(), ctx.EnvLookup, ctx.Logger())
 				flags.LoginFlags().SetProviderID(matchingLinkedClusters[0].LoginProvider.ID)
 				_, err := ctx.Setup(flags, matchingLinkedClusters[0].URL, true)
 				return err
 			default:
-				return config.ErrTooManyConfigs
+				// Several clusters and / or linked clusters are matching.
+				var candidates []*config.Cluster
+				if matchingConf != nil {
+					candidates = append(candidates, config.NewCluster(matchingConf))
+				}
+				for _, linkedCluster := range matchingLinkedClusters {
+					candidates = append(candidates, linkedCluster.ToCluster())
+				}
+				return &config.AmbiguousClusterError{Query: args[0], Candidates: candidates}
 			}
 		},
 	}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/dcos/dcos-cli/pkg/mock"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
@@ -37,3 +38,34 @@ func TestClusterAttach(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, clusterID, cluster.ID())
 }
+
+func TestClusterAttachCurrentClusterError(t *testing.T) {
+	dcosDir := filepath.Join("testdata", "cluster_attach", ".dcos")
+
+	env := mock.NewEnvironment()
+	env.Fs = afero.NewCopyOnWriteFs(
+		afero.NewReadOnlyFs(afero.NewOsFs()),
+		afero.NewMemMapFs(),
+	)
+	env.EnvLookup = func(key string) (string, bool) {
+		switch key {
+		case "DCOS_DIR":
+			return dcosDir, true
+		case "DCOS_CLUSTER":
+			return "single_config_unattached", true
+		}
+		return "", false
+	}
+
+	// Another cluster with the same name makes DCOS_CLUSTER ambiguous.
+	otherConfDir := filepath.Join(dcosDir, "clusters", "97193161-f7f1-2295-2514-a6b3918043b6")
+	require.NoError(t, env.Fs.MkdirAll(otherConfDir, 0755))
+	require.NoError(t, afero.WriteFile(env.Fs, filepath.Join(otherConfDir, "dcos.toml"), []byte("[cluster]\nname = \"single_config_unattached\"\n"), 0600))
+
+	cmd := newCmdClusterAttach(mock.NewContext(env))
+	cmd.SetArgs([]string{"79893270-f9f1-4293-9225-e6e3900043a9"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	require.Equal(t, config.ErrTooManyConfigs, cmd.Execute())
+}
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// newCmdClusterExport exports the connection details of the current cluster to a bundle file.
+// It can then be imported with `dcos cluster setup --from-bundle <file>`.
+func newCmdClusterExport(ctx api.Context) *cobra.Command {
+	var includeToken bool
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Export the connection details of the current cluster to a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cluster, err := ctx.Cluster()
+			if err != nil {
+				return err
+			}
+			bundle, err := config.NewBundle(cluster, includeToken)
+			if err != nil {
+				return err
+			}
+			if err := bundle.Write(ctx.Fs(), args[0]); err != nil {
+				return err
+			}
+			ctx.Logger().Infof("Exported cluster %s to %s", cluster.ID(), args[0])
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&includeToken, "include-token", false, "include the ACS token of the current user (it is per-user, don't share it)")
+	return cmd
+}
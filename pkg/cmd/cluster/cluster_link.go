@@ -26,9 +26,9 @@ func newCmdClusterLink(ctx api.Context) *cobra.Command {
 
 			var linkableCluster *config.Cluster
 			manager := ctx.ConfigManager()
-			linkableClusterConfig, err := manager.Find(args[0], false)
+			linkableClusterConfig, err := manager.ResolveCluster(args[0])
 			if err != nil {
-				if err != config.ErrConfigNotFound {
+				if _, ok := err.(*config.ClusterNotFoundError); !ok {
 					return err
 				}
 
@@ -1,8 +1,9 @@
 package cluster
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/dcos/dcos-cli/pkg/cli"
@@ -10,15 +11,41 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// listColumns are the columns `dcos cluster list` can display, mapped to their header.
+var listColumns = map[string]string{
+	"attached": "",
+	"name":     "NAME",
+	"id":       "ID",
+	"status":   "STATUS",
+	"version":  "VERSION",
+	"url":      "URL",
+}
+
+// defaultListColumns are the columns displayed when --columns is not passed.
+var defaultListColumns = []string{"attached", "name", "id", "status", "version", "url"}
+
 // newCmdClusterList lists the clusters.
 func newCmdClusterList(ctx api.Context) *cobra.Command {
 	var attachedOnly bool
 	var jsonOutput bool
+	var noHeader bool
+	var columns []string
+	var parallelism int
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List the clusters configured and the ones linked to the current cluster",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, column := range columns {
+				if _, ok := listColumns[column]; !ok {
+					return fmt.Errorf("unknown column '%s', expected one of attached, name, id, status, version, url", column)
+				}
+			}
+
+			if parallelism < 1 {
+				return fmt.Errorf("invalid --parallel value %d, it must be at least 1", parallelism)
+			}
+
 			var filters []lister.Filter
 			if attachedOnly {
 				filters = append(filters, lister.AttachedOnly())
@@ -26,24 +53,41 @@ func newCmdClusterList(ctx api.Context) *cobra.Command {
 				filters = append(filters, lister.Linked())
 			}
 
-			items := lister.New(ctx.ConfigManager(), ctx.Logger()).List(filters...)
+			jsonOutput = jsonOutput || ctx.JSONOutput()
+
+			// The status and version require a request to each cluster, skip it when they are not displayed.
+			if !jsonOutput && !containsColumn(columns, "status") && !containsColumn(columns, "version") {
+				filters = append(filters, lister.SkipStatus())
+			}
+
+			clusterLister := lister.New(ctx.ConfigManager(), ctx.Logger())
+			clusterLister.SetContext(ctx.BaseContext())
+			clusterLister.SetEnvLookup(ctx.EnvLookup)
+			clusterLister.SetACSTokenResolver(ctx.ACSToken)
+			clusterLister.SetParallelism(parallelism)
+
+			items := clusterLister.List(filters...)
 			if attachedOnly && len(items) == 0 {
 				return errors.New("no cluster is attached. Please run `dcos cluster attach <cluster-name>`")
 			}
 
 			if jsonOutput {
-				enc := json.NewEncoder(ctx.Out())
-				enc.SetIndent("", "    ")
-				return enc.Encode(items)
+				return cli.PrintJSON(ctx.Out(), items)
 			}
 
-			table := cli.NewTable(ctx.Out(), []string{"", "NAME", "ID", "STATUS", "VERSION", "URL"})
+			var header []string
+			if !noHeader {
+				for _, column := range columns {
+					header = append(header, listColumns[column])
+				}
+			}
+			table := cli.NewTable(ctx.Out(), header)
 			for _, item := range items {
-				var attached string
-				if item.Attached {
-					attached = "*"
+				row := make([]string, len(columns))
+				for i, column := range columns {
+					row[i] = listColumn(item, column)
 				}
-				table.Append([]string{attached, item.Name, item.ID, item.Status, item.Version, item.URL})
+				table.Append(row)
 			}
 			table.Render()
 
@@ -52,5 +96,39 @@ func newCmdClusterList(ctx api.Context) *cobra.Command {
 	}
 	cmd.Flags().BoolVar(&attachedOnly, "attached", false, "returns attached cluster only")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "returns clusters in json format")
+	cmd.Flags().StringSliceVar(&columns, "columns", defaultListColumns, "comma-separated list of columns to display ("+strings.Join(defaultListColumns, ",")+")")
+	cmd.Flags().BoolVar(&noHeader, "no-header", false, "do not print the header")
+	cmd.Flags().IntVar(&parallelism, "parallel", lister.DefaultParallelism, "maximum number of clusters to check concurrently")
 	return cmd
 }
+
+// listColumn returns the value of a column for a cluster item.
+func listColumn(item *lister.Item, column string) string {
+	switch column {
+	case "attached":
+		if item.Attached {
+			return "*"
+		}
+		return ""
+	case "name":
+		return item.Name
+	case "id":
+		return item.ID
+	case "status":
+		return item.Status
+	case "version":
+		return item.Version
+	default:
+		return item.URL
+	}
+}
+
+// containsColumn indicates whether or not a column is part of a list of columns.
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
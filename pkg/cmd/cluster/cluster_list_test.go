@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterListColumns(t *testing.T) {
+	var versionRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&versionRequests, 1)
+		w.Write([]byte(`{"version": "1.13.0"}`))
+	}))
+	defer ts.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Fs = afero.NewMemMapFs()
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "DCOS_DIR" {
+			return ".dcos", true
+		}
+		return "", false
+	}
+	env.Out = &out
+
+	for id, cluster := range map[string][]string{
+		"79893270": {"dev", ts.URL},
+		"97193161": {"prod", down.URL},
+	} {
+		conf := config.New(config.Opts{Fs: env.Fs})
+		conf.Set("cluster.name", cluster[0])
+		conf.Set("core.dcos_url", cluster[1])
+		conf.SetPath(filepath.Join(".dcos", "clusters", id, "dcos.toml"))
+		require.NoError(t, conf.Persist())
+	}
+	ctx := mock.NewContext(env)
+
+	// The clusters are not reached when neither the status nor the version is displayed.
+	cmd := newCmdClusterList(ctx)
+	cmd.SetArgs([]string{"--columns", "name,url", "--no-header"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "  dev   "+ts.URL+"  \n  prod  "+down.URL+"  \n", out.String())
+	require.EqualValues(t, 0, atomic.LoadInt32(&versionRequests))
+
+	// Unreachable clusters are listed as unavailable.
+	out.Reset()
+	cmd = newCmdClusterList(ctx)
+	cmd.SetArgs([]string{"--columns", "name,status,version"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "NAME")
+	require.Regexp(t, `dev\s+AVAILABLE\s+1.13.0`, out.String())
+	require.Regexp(t, `prod\s+UNAVAILABLE\s+UNKNOWN`, out.String())
+	require.EqualValues(t, 1, atomic.LoadInt32(&versionRequests))
+
+	cmd = newCmdClusterList(ctx)
+	cmd.SetArgs([]string{"--columns", "name,region"})
+	require.EqualError(t, cmd.Execute(), "unknown column 'region', expected one of attached, name, id, status, version, url")
+}
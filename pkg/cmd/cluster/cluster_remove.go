@@ -30,7 +30,7 @@ func newCmdClusterRemove(ctx api.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Remove a single cluster.
 			if len(args) == 1 {
-				conf, err := ctx.ConfigManager().Find(args[0], false)
+				conf, err := ctx.ConfigManager().ResolveCluster(args[0])
 				if err != nil {
 					return err
 				}
@@ -39,7 +39,7 @@ func newCmdClusterRemove(ctx api.Context) *cobra.Command {
 					return err
 				}
 
-				ctx.Logger().Infof("Removed cluster: %s", args[0])
+				ctx.Logger().Infof("Removed cluster: %s", filepath.Base(filepath.Dir(conf.Path())))
 				return nil
 			}
 
@@ -48,7 +48,11 @@ func newCmdClusterRemove(ctx api.Context) *cobra.Command {
 				filters = append(filters, lister.Status(lister.StatusUnavailable))
 			}
 
-			items := lister.New(ctx.ConfigManager(), ctx.Logger()).List(filters...)
+			clusterLister := lister.New(ctx.ConfigManager(), ctx.Logger())
+			clusterLister.SetEnvLookup(ctx.EnvLookup)
+			clusterLister.SetACSTokenResolver(ctx.ACSToken)
+
+			items := clusterLister.List(filters...)
 
 			for _, item := range items {
 				if err := ctx.Fs().RemoveAll(item.Cluster().Dir()); err != nil {
@@ -12,12 +12,11 @@ func newCmdClusterRename(ctx api.Context) *cobra.Command {
 		Short: "Rename a configured cluster",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			conf, err := ctx.ConfigManager().Find(args[0], false)
+			conf, err := ctx.ConfigManager().ResolveCluster(args[0])
 			if err != nil {
 				return err
 			}
-			conf.Set("cluster.name", args[1])
-			err = conf.Persist()
+			err = ctx.ConfigManager().Rename(conf, args[1])
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,43 @@
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterRename(t *testing.T) {
+	env := mock.NewEnvironment()
+	env.Fs = afero.NewCopyOnWriteFs(
+		afero.NewReadOnlyFs(afero.NewOsFs()),
+		afero.NewMemMapFs(),
+	)
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "DCOS_DIR" {
+			return filepath.Join("testdata", "cluster_rename", ".dcos"), true
+		}
+		return "", false
+	}
+	ctx := mock.NewContext(env)
+
+	// Renaming a cluster to the name of another cluster is not allowed.
+	cmd := newCmdClusterRename(ctx)
+	cmd.SetArgs([]string{"cluster-01", "prod"})
+	require.Error(t, cmd.Execute())
+
+	cmd = newCmdClusterRename(ctx)
+	cmd.SetArgs([]string{"79893270-f9f1-4293-9225-e6e3900043a9", "dev"})
+	require.NoError(t, cmd.Execute())
+
+	conf, err := ctx.ConfigManager().Find("dev", true)
+	require.NoError(t, err)
+	require.Equal(t, "79893270-f9f1-4293-9225-e6e3900043a9", filepath.Base(filepath.Dir(conf.Path())))
+
+	// The attached cluster is left untouched.
+	cluster, err := ctx.Cluster()
+	require.NoError(t, err)
+	require.Equal(t, "prod", cluster.Name())
+}
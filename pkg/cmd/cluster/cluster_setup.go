@@ -13,9 +13,18 @@ func newCmdClusterSetup(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "setup <url>",
 		Short: "Set up the CLI to communicate with a cluster",
-		Args:  cobra.ExactArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// The URL is part of the bundle when setting up a cluster from it.
+			if setupFlags.FromBundle() {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clusterURL := args[0]
+			var clusterURL string
+			if len(args) > 0 {
+				clusterURL = args[0]
+			}
 			_, err := ctx.Setup(setupFlags, clusterURL, true)
 			return err
 		},
@@ -20,7 +20,7 @@ func newCmdClusterUnlink(ctx api.Context) *cobra.Command {
 			}
 
 			manager := ctx.ConfigManager()
-			linkedClusterConfig, err := manager.Find(args[0], false)
+			linkedClusterConfig, err := manager.ResolveCluster(args[0])
 			if err != nil {
 				return err
 			}
@@ -4,24 +4,52 @@ package completion
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dcos/dcos-cli/api"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// clustersArg is an internal argument used by completion scripts to get the configured cluster names.
+const clustersArg = "__clusters"
+
+// clusterCommands are the commands whose first argument is a configured cluster.
+var clusterCommands = map[string]bool{
+	"cluster attach": true,
+	"cluster remove": true,
+	"cluster rename": true,
+}
+
 // NewCommand creates the `dcos completion` subcommand
 func NewCommand(ctx api.Context) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:       "completion",
-		Short:     "Output completion script for the DC/OS CLI",
+		Use:   "completion <shell>",
+		Short: "Output completion script for the DC/OS CLI",
+		Long: `Output completion script for the DC/OS CLI.
+
+The script can be loaded in the current shell session with:
+
+    source <(dcos completion bash)
+    source <(dcos completion zsh)
+    dcos completion fish | source`,
 		Hidden:    true,
-		ValidArgs: []string{"bash"},
+		ValidArgs: []string{"bash", "zsh", "fish"},
 		Args:      cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			shell := args[0]
 			switch shell {
 			case "bash":
 				return genBashCompletion(ctx)
+			case "zsh":
+				return genZshCompletion(ctx, cmd.Root())
+			case "fish":
+				return genFishCompletion(ctx, cmd.Root())
+			case clustersArg:
+				for _, cluster := range ctx.Clusters() {
+					fmt.Fprintln(ctx.Out(), cluster.Name())
+				}
+				return nil
 			default:
 				return fmt.Errorf("invalid shell '%s' given", shell)
 			}
@@ -40,3 +68,36 @@ func genBashCompletion(ctx api.Context) error {
 	fmt.Fprint(ctx.Out(), string(data))
 	return nil
 }
+
+// commandPath returns the path of a command without the root command name, eg. "cluster attach".
+func commandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " ")
+}
+
+// subcommands returns the subcommands of a command which should be completed.
+func subcommands(cmd *cobra.Command) (cmds []*cobra.Command) {
+	for _, subcmd := range cmd.Commands() {
+		if subcmd.IsAvailableCommand() || subcmd.Name() == "help" {
+			cmds = append(cmds, subcmd)
+		}
+	}
+	return cmds
+}
+
+// flags returns the flags of a command which should be completed.
+func flags(cmd *cobra.Command) (flags []*pflag.Flag) {
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			flags = append(flags, flag)
+		}
+	})
+	return flags
+}
+
+// walk calls fn for a command and all its completable subcommands.
+func walk(cmd *cobra.Command, fn func(cmd *cobra.Command)) {
+	fn(cmd)
+	for _, subcmd := range subcommands(cmd) {
+		walk(subcmd, fn)
+	}
+}
@@ -39,6 +39,11 @@ __dcos_default_command_parse() {
     return 0
 }
 
+# Lists the names of the configured clusters.
+__dcos_clusters() {
+    dcos completion __clusters 2> /dev/null
+}
+
 __dcos_handle_compreply() {
     __dcos_debug "completions: $*"
 
@@ -98,7 +103,7 @@ _dcos_auth() {
         return
     fi
 
-    local commands=("list-providers" "login" "logout")
+    local commands=("list-providers" "login" "logout" "whoami")
     local flags=("--help")
 
     if [ -z "$command" ]; then
@@ -123,7 +128,8 @@ _dcos_auth_login() {
         return
     fi
 
-    local flags=( "--help"
+    local flags=( "--device"
+    "--help"
     "--password="
     "--password-file="
     "--private-key="
@@ -195,6 +201,30 @@ _dcos_auth_logout() {
     fi
 }
 
+_dcos_auth_whoami() {
+	local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=("--details" "--help")
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*=*)
+                # don't support flag argument completion yet
+                return
+                ;;
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *) ;;
+        esac
+        return
+    fi
+}
+
 _dcos_cluster() {
     local i command
 
@@ -202,7 +232,7 @@ _dcos_cluster() {
         return 
     fi
 
-    local commands=("attach" "help" "list" "remove" "rename" "setup")
+    local commands=("attach" "export" "help" "list" "remove" "rename" "setup")
     local flags=("--help")
 
     if [ -z "$command" ]; then
@@ -229,6 +259,32 @@ _dcos_cluster_attach() {
 
     local flags=("--help")
 
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*=*)
+                # don't support flag argument completion yet
+                return
+                ;;
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *)
+                __dcos_handle_compreply $(__dcos_clusters)
+                ;;
+        esac
+        return
+    fi
+}
+
+_dcos_cluster_export() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=("--help" "--include-token")
+
     if [ -z "$command" ]; then
         case "$cur" in
             --*=*)
@@ -251,7 +307,7 @@ _dcos_cluster_list() {
         return
     fi
 
-    local flags=("--help" "--attached" "--json")
+    local flags=("--help" "--attached" "--columns=" "--json" "--no-header" "--parallel=")
 
     if [ -z "$command" ]; then
         case "$cur" in
@@ -286,7 +342,9 @@ _dcos_cluster_remove() {
             --*)
                 __dcos_handle_compreply "${flags[@]}"
                 ;;
-            *) ;;
+            *)
+                __dcos_handle_compreply $(__dcos_clusters)
+                ;;
         esac
         return
     fi
@@ -306,7 +364,9 @@ _dcos_cluster_rename() {
             --*)
                 __dcos_handle_compreply "${flags[@]}"
                 ;;
-            *) ;;
+            *)
+                __dcos_handle_compreply $(__dcos_clusters)
+                ;;
         esac
         return
     fi
@@ -321,6 +381,10 @@ _dcos_cluster_setup() {
 
     local flags=("--help"
         "--ca-certs="
+        "--cert-fingerprint="
+        "--device"
+        "--force"
+        "--from-bundle="
         "--insecure"
         "--name="
         "--no-check"
@@ -354,7 +418,7 @@ _dcos_config() {
         return 
     fi
 
-    local commands=("set" "show" "unset")
+    local commands=("keys" "set" "show" "unset")
     local flags=("--help")
 
     if [ -z "$command" ]; then
@@ -372,6 +436,26 @@ _dcos_config() {
     __dcos_handle_subcommand
 }
 
+_dcos_config_keys() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=("--help" "--json")
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *) ;;
+        esac
+        return
+    fi
+}
+
 _dcos_config_set() {
     local i command
 
@@ -379,7 +463,7 @@ _dcos_config_set() {
         return 
     fi
 
-    local flags=("--help")
+    local flags=("--force" "--global" "--help")
 
     if [ -z "$command" ]; then
         case "$cur" in
@@ -419,7 +503,7 @@ _dcos_config_unset() {
         return 
     fi
 
-    local flags=("--help")
+    local flags=("--global" "--help")
 
     if [ -z "$command" ]; then
         case "$cur" in
@@ -439,7 +523,7 @@ _dcos_plugin() {
         return
     fi
 
-    local commands=("add" "list" "remove")
+    local commands=("add" "list" "remove" "update")
     local flags=("--help")
 
     if [ -z "$command" ]; then
@@ -464,7 +548,7 @@ _dcos_plugin_add() {
         return
     fi
 
-    local flags=("--help" "--update")
+    local flags=("--asset" "--help" "--pin-version" "--sha256" "--update")
 
     if [ -z "$command" ]; then
         case "$cur" in
@@ -517,6 +601,26 @@ _dcos_plugin_remove() {
     fi
 }
 
+_dcos_plugin_update() {
+    local i command
+
+    if ! __dcos_default_command_parse; then
+        return
+    fi
+
+    local flags=("--help")
+
+    if [ -z "$command" ]; then
+        case "$cur" in
+            --*)
+                __dcos_handle_compreply "${flags[@]}"
+                ;;
+            *) ;;
+        esac
+        return
+    fi
+}
+
 _dcos() {
 	local i c=1 command
 
@@ -525,7 +629,7 @@ _dcos() {
     fi
 
     local commands=("auth" "cluster" "config" "help" "plugin")
-    local flags=("--help" "--version")
+    local flags=("--help" "--version" "--yes")
 
     # TODO: add plugin commands relevant to currently attached cluster
 
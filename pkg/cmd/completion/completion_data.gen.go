@@ -66,7 +66,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _completionSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\x6d\x6f\x1b\x37\x12\xfe\x7c\xfa\x15\xd3\x95\x80\xd8\x8e\xb7\x49\xbe\xda\xa7\x20\xbd\xbc\x1c\x02\xb4\x4d\x71\xbd\x7e\x38\x18\x86\xc0\xe5\xce\x6a\x79\xa6\xc8\x05\x5f\xa4\xe8\x7c\xfe\xef\xc5\x90\xfb\x2e\xd9\x4d\x5a\x39\x4e\x51\x0b\x08\x2c\x73\xc9\x99\xe1\xcc\x33\xcf\x90\xeb\xc9\xf4\x9b\x67\x99\x50\xcf\x32\x66\xcb\xc9\x64\x0a\x8b\x45\xce\xb5\x5d\xfc\xbd\x44\x59\xa1\x81\xc2\x2b\xfe\x92\x86\xe3\x28\x97\x02\xac\xcf\xb8\x5e\xad\x98\xca\x5f\x4e\x26\xf5\xf4\x1c\x33\xbf\x3c\x3a\x86\xeb\x09\x00\x80\x28\xe0\xe2\x02\x52\x05\xb3\xeb\x37\xaf\x3f\xfc\xbc\x78\xfd\xe1\x87\x9f\x16\x6f\xde\xfe\xe3\x97\x7f\x2e\xde\xbd\xff\xfe\xed\x0d\x5c\x5e\x9e\x83\x2b\x51\x85\xd9\xf4\x41\x5e\x6a\x48\x66\xd7\xef\x7e\xf9\xf1\xf5\x8f\xdf\xfd\xf0\xf6\xe2\xc5\xe5\xcd\x19\xcc\x4e\x12\x78\xf9\x92\xc6\xf7\x8a\x49\xc2\xf2\x42\x4c\x6e\x60\x42\xa6\xbf\xc1\x82\x79\xe9\x20\xc3\x92\xad\x85\x36\xe0\x34\x2c\xd1\x91\x26\x50\xf8\xd1\x41\x6d\x36\x14\x46\xaf\xc2\x28\xf7\xc6\xa0\x6a\x1f\x7c\x0b\xef\x55\x1c\x67\x16\x41\x17\x90\xa6\xe4\x06\xa0\x35\x2b\xb6\xcd\x10\xb4\x2b\xd1\x80\x15\xce\x33\x27\xb4\xb2\x93\x29\x88\xb8\xa4\xf0\xce\x1b\x04\x57\x32\x07\xb6\xd4\x5e\xe6\x80\x2a\x27\xc9\x95\x44\x9a\x7b\x0a\xae\x14\x16\x0c\x3a\x6f\x94\x85\x17\xa7\x51\xd8\x46\x58\x84\xe7\x93\xe9\x64\x0a\xdf\x59\xeb\x57\x68\x81\xc1\xac\xb1\x74\xcd\x8c\x60\x99\x44\xc0\x8f\xc2\x3a\xdb\x28\xe3\x4c\x4a\xa1\x96\xc0\xb5\x72\xb4\xb1\xa0\x75\x23\xa4\x0c\x23\xac\x31\x49\x7b\x95\xf7\xc2\x35\x52\xf1\x59\xc2\x85\xed\x3b\x6c\x32\x85\x8d\x36\x39\x64\x48\x13\x71\xcd\xa4\x67\x0e\x7b\x8e\x15\xaa\xf2\x0e\xac\x33\xf4\xfc\x28\x6c\x5c\x58\xc8\xb1\x10\x0a\x73\x52\x54\xe3\x66\x45\xb6\x5a\x5d\x7b\x4c\x3d\xa1\xe0\x01\x83\xca\xe8\x4c\xe2\xea\xb8\x83\x57\x88\xec\xa2\xde\xc8\xa2\x62\xc6\x62\x0b\xb7\x4d\x29\x24\xc2\x05\x24\x33\x9e\x40\x2a\x1d\x7d\x21\xf3\x12\xb8\x3c\x87\x5c\xb7\x20\x13\xf3\x64\x76\x4d\x0f\xec\x05\xbf\xac\xd1\x43\x9f\x10\xed\x64\x26\x12\x10\x1d\x22\xe9\x13\xc3\xff\xff\xb4\x3c\x1e\x0c\xd3\x67\x4a\x20\x0f\xe0\x10\xe4\xcd\x42\xb2\xe5\x29\x70\x9d\x19\x06\xd6\xe9\xca\x02\x99\x48\x9b\x17\xab\x15\xe6\x82\x39\x94\x5b\xda\xe8\x06\x6b\x04\x40\x89\x06\x77\xc4\xd6\xcf\x5e\xec\x3c\x38\x3f\x1f\x5a\x76\x72\x3c\x1e\x3a\xd9\x35\xb2\x76\xd7\x9c\xf6\x76\xbe\x67\x0b\x33\x4e\xd6\x63\x51\x20\x77\x62\x4d\x16\x2e\xa5\xce\x98\x24\x43\x23\xa4\xd8\x16\x84\x03\xce\x14\x70\xc3\x36\x12\x5c\x69\xb4\x5f\x96\x21\xc6\xcc\x2c\xfd\x0a\x95\xb3\xc0\x22\x38\x2a\xa3\x97\x86\xad\xf6\x28\x52\x6c\x2d\x96\xcc\xa1\xad\x53\x45\x71\xca\x08\x70\x06\x31\x24\x97\xd5\x64\x4b\x00\x30\x93\x1b\xb6\xb5\x04\x83\x36\x6d\xbd\x32\xc8\xf2\x80\xb8\x3d\xb2\x59\xe1\x88\xa7\x84\xca\xc9\xdd\xe3\x5c\x3f\x25\x8f\x0b\xc5\x0d\x92\xa9\xa4\x25\xc3\x42\x1b\x84\xcc\x20\xbb\xa2\x15\xda\x3b\x4a\x75\x5a\x28\xb5\xae\x76\x34\x1c\x1d\xf1\xa7\x4f\x8f\x77\x9d\x1b\x04\xdc\x15\x27\xb4\x8c\x4f\xf6\x49\xc9\xb5\x8a\x91\xaf\xa3\xfd\x7c\x72\xd3\x12\x69\xc9\x54\x2e\x91\x80\x5e\x19\xac\xe4\xb6\x05\x79\x9f\x68\x21\xe9\x68\xc5\x06\x9a\x9c\x84\x39\x52\x73\x26\x41\xcc\x9f\xc7\x5f\x0b\x6d\x7a\xfc\x43\x59\x97\xcc\x5e\x25\x83\x94\x98\x42\x21\x24\xf9\x8f\xbc\xd0\x13\x4a\x39\xc5\x4b\xb2\xf4\x89\x83\x15\x73\xbc\x1c\xf0\xe5\x20\x14\x91\xed\x67\x3d\x4d\xf3\x39\xa5\xa0\x37\xc9\xc9\x2e\xd3\x47\xad\xbc\x44\x7e\x45\x2b\x83\xd4\x6e\xa5\x2e\x0a\x34\xc4\x10\x4d\x52\x45\x1c\x32\xce\xb1\x22\xa0\xa9\x16\x75\xb5\x85\xc2\xc2\x8a\x99\x2b\xcc\x21\xdb\xd2\xe3\xf9\x48\x91\x28\xc0\xea\x53\x60\x60\x2b\xc6\x91\xa6\x2b\x4d\x3b\x52\x9e\x49\xb9\x05\x96\xe7\x98\x83\x15\x8a\x47\xbc\x79\x8b\x26\xe4\xc4\xc7\x0a\x39\x31\x9a\xd3\x34\x07\x0a\x6f\x02\xf3\x07\x5e\x1b\xa8\xa8\xc9\xa3\xdb\xc3\x0e\x8b\x40\x60\x92\x93\xf9\x9e\x04\xa5\x0f\x55\xb5\x7f\xbd\xfd\xe9\xfb\xff\x5c\x88\xa7\x4f\x2f\xe7\x03\x51\x7b\x17\x9c\xef\xa6\xf2\xe7\x8b\x86\x4f\x92\x3d\x80\x70\x21\x3a\xf4\xde\x50\xbd\x7d\xcd\xa4\xb4\x31\xdb\xba\x22\x43\x71\xd0\xde\xf4\xab\xc8\x1a\x55\x00\xd5\xb7\x93\x29\xfc\xfb\xc3\x9b\x0f\x67\x5d\x10\x03\xdc\xc3\x34\xa6\x02\xeb\xb3\x4c\x6e\x29\xf9\x49\x0b\xac\x28\x51\xf1\x63\x25\x05\x17\x4e\x6e\x69\x39\x55\x12\x56\x17\xb1\x58\xe3\xa4\xd4\x1b\x92\xd0\x54\x33\x1b\xcb\xd9\xb8\x9a\x59\xae\xab\x48\x36\xcc\x10\xe4\x8c\x41\xee\xce\x26\xd3\x86\x28\x2c\x59\x65\xd8\x96\xb8\xa0\xdb\x8d\x8d\x45\xbb\x65\xac\x52\xcb\xdc\x76\x8b\xce\x1a\xfc\x86\x9d\x3b\x1d\xb4\x81\x50\x4e\x8f\xf2\xb9\x93\x18\x12\x7a\xd2\x82\x93\xb5\xab\x37\xcc\xc2\x52\xac\x51\x9d\xd6\xd9\x11\xeb\x6e\x28\xbd\x84\x7b\xee\x3c\x93\xed\x6c\xfa\x17\x94\x05\x26\xb6\x56\x73\x11\x2b\x70\x6d\x69\x47\x00\xab\x3c\x66\xfe\x75\xb3\xa5\x8b\x57\x97\x37\x43\x16\x68\x32\x78\x95\xd7\xa9\x1b\x67\x26\xfb\xb3\x77\xc8\x44\xe3\x43\xc6\x59\x90\x53\x13\x52\xf3\x89\xc4\xd4\x03\x09\x81\xa6\xa9\xe6\xa3\x8c\xb5\x1b\x56\x41\x4a\x36\xb7\x31\x50\x8c\xc2\x4d\xbb\x59\x0c\x26\x77\x02\xe7\xed\xf6\x9e\x3d\x4b\x9f\x2d\x6e\x26\x77\x58\xdc\x20\x82\xc2\xd4\x45\x76\x76\x2d\x99\x6d\x6d\xba\x59\xcc\xae\x3b\xe9\x37\xc3\x64\xe9\x1b\x3f\xbf\x73\xdd\xd0\x09\xbd\x79\xf3\xd9\xc0\x03\x83\x79\xb3\x5b\x9d\x13\xeb\xc5\x6d\x09\x19\x37\xc9\xbc\x2b\x03\xc6\xfe\x56\x57\x03\x18\x28\x11\x05\x7c\x03\x77\x9d\xaa\x46\x01\xef\xa9\x2c\x44\xbf\xca\x34\xc1\x99\x1f\x25\x52\x58\x97\x56\x46\xaf\x45\x8e\xc6\x26\x90\x48\xbd\x14\x2a\xfe\xd4\xde\x25\xc7\xbd\x65\xc4\xe9\xb4\x26\x1e\xad\x92\xe3\xd6\xaa\x0b\x48\xff\x37\x80\xde\xc8\x90\x86\x67\xbd\xd9\x73\x4c\xdb\xc3\x7f\xb7\xd4\x53\x4a\x84\x60\x43\xc8\x82\xdf\x3a\x66\x7d\x9e\xdc\x41\x82\x7d\xf2\xc9\x60\x8f\x87\x6f\xe3\x8e\x61\x94\x17\xc1\xcd\x5f\x22\xd6\x75\xd0\xa0\x89\x5a\x78\x94\xa4\x69\xc5\xac\xa5\xa3\xc0\x7c\x67\x24\x2d\x84\xc4\xde\xb0\x11\x6b\xe6\x30\xbd\xc2\x6d\x7f\x30\x22\xa6\x1b\xa1\xea\x4b\xb9\x5e\x8f\x1c\x0a\x1c\xfb\x2a\xef\xb4\x3e\xdb\x58\x5f\x55\xda\xb8\x78\xd6\x68\xcb\x52\xaf\x4e\x6e\xd1\xdd\x72\x4a\xff\xcd\x33\xfa\x3d\xc2\xf2\x53\xd1\x34\x02\x8c\xb0\x6e\xd1\x26\xea\x17\x44\x4e\x03\x1c\x0a\xf2\x7f\xad\x56\x07\xcb\xfb\xbf\x6e\x68\x77\xa8\x40\x7b\xf7\x00\x11\x7d\x0c\xe4\xc1\x02\xc9\xa5\xb7\x0e\x4d\x7b\xe3\x3b\x60\x1c\xe1\xee\x02\xce\x9c\x63\xbc\x4c\x20\xa9\x93\x94\x78\x22\x81\xc4\xe0\x4a\xaf\x31\x7c\x21\x56\x4e\x20\xb1\xe8\x7c\xf5\x58\xcf\xc7\xa2\xff\x78\x3d\xaf\x63\xbf\x88\x91\xb8\x0f\x08\x3c\xa6\xf2\x97\x4f\xe5\x50\x70\x1f\x26\x98\x54\x69\x23\x98\x30\x7f\x2c\xbb\xf7\x18\xe2\x48\x92\x0f\x18\x64\x29\xc3\x4f\xaf\xd8\x9a\x09\xc9\x32\x89\x8f\x61\xbe\x8f\x30\x53\x09\xfc\x73\x13\xf3\x57\xe7\xd4\x70\x9c\x78\x18\x9f\xb6\x0b\x92\x34\xe5\x2c\xe5\x68\x9c\x9d\x0f\x46\x85\xb2\xc8\xbd\xc1\xc1\x60\xef\x76\xda\x8c\xe8\x34\xbc\xae\x1b\x0f\x56\xd2\x2f\x85\x1a\x8c\x8e\xae\xcb\x70\xfb\x95\x19\x6e\xbb\x36\xc3\xde\xab\x33\x3c\x5e\x9f\xef\x19\xb0\x5a\x15\x62\xf9\x00\x27\x73\x8b\x74\x10\xb7\xa5\xde\x24\x90\x78\x45\xbf\x3e\x9e\xbe\xe1\xe0\xa7\xef\x10\x5e\xa2\xa3\x2f\x15\xe2\x3f\x5b\xe0\x7e\x5f\xc2\x2c\x08\xb8\x8f\x2e\x3d\xa8\x4b\x03\x09\x3c\xfa\xf4\x30\x3e\x8d\x45\xfa\x21\xde\xb8\xe4\xf9\xce\x7b\x96\x47\x62\x87\x43\x13\x7b\x8c\xef\x82\xe5\xf9\x03\x65\x4c\x38\x96\x55\x39\x73\x87\xbb\x94\x7d\x65\xc9\x73\x6f\xef\x38\x3e\xd1\xbb\x07\x7d\xaf\xf1\xb5\xf9\xf6\xfe\x5e\x2e\xfc\x05\xd9\x7e\xf4\xd7\x91\xf9\x8b\xfb\xff\xcb\x38\xf3\xae\x4c\x20\xa9\xef\xbb\xf4\x2d\x14\xf1\xee\x4d\x7b\x7d\x4b\xbc\x83\xfa\x09\xe3\x6b\x34\x56\x74\x30\x6f\xbb\x68\xf2\x1c\xa2\x80\xae\x55\xc2\xa0\xc4\x35\x53\x2e\x34\xa3\xc4\x6e\x2d\xb9\x85\xe6\x4d\x20\xd4\x96\x34\x72\x94\xee\x37\x65\x34\xdd\x27\xf1\xf6\x86\x80\x22\xf4\x3c\x05\x83\x40\x9b\x7e\x5b\xcc\x1f\x44\xc6\x9e\x5b\x65\xb3\xa9\x3d\xf7\xca\xde\x75\xd2\x88\x65\xe9\x40\xe9\xcd\x68\x6d\xe8\xd2\xc9\x35\x5a\x90\xc8\xd6\x18\xda\xd9\x42\x4b\xa2\x76\xa8\x9c\x20\xb7\x6a\x03\x39\x3a\xe4\x4e\xa8\x65\xbd\xa7\xd0\x61\xe3\xd8\x15\xc2\x9a\x49\x8f\x16\x32\xef\x42\x7b\x90\xc5\x8a\x99\xd0\x4f\x23\xc5\x15\x8e\x74\xa5\x69\xb0\x2a\x2c\x01\xa1\xac\x43\x96\xc7\x66\x61\x1a\x9f\x87\xf1\xd1\x92\x0d\x3e\x31\x18\x3a\xcf\x36\xda\x98\x2d\x59\xc0\x32\xb2\xb1\xb9\x37\x8f\xae\xcc\xe0\xca\xd0\x66\x69\x35\x08\xf7\xc4\x82\x65\x05\x52\x44\xc5\x52\xe9\xba\xc9\x78\xa0\x61\xcf\x8d\xba\x97\x1b\x84\xa3\xe3\xcf\x98\xbf\x93\xb3\x9f\x9f\xaf\x3d\x69\x3b\x71\x56\x7a\xb7\xe7\x89\x76\x4a\x85\x24\x04\xae\xd2\xd6\x8a\x4c\xe2\x0e\xe0\x9a\xcf\x48\xa0\x50\x60\x90\x49\xf0\x96\x2d\xf1\xb4\x6b\x9e\xae\xfb\x49\xad\x0e\x9d\xd8\xbe\xaa\xdb\x95\xfb\xbd\xab\xb5\x76\xa7\xfb\x8d\x5c\xa7\x21\x52\xd6\x1b\xdc\x79\x7d\x31\x85\x52\x6f\x60\x83\xb0\xa9\x73\x2c\xfa\x64\x37\x22\xbf\xeb\xfc\x73\xa8\xb3\x4f\xd7\x5b\x7d\x4b\xfb\xe8\xec\xd5\xa0\x63\x94\x7b\x03\x95\xc1\x75\xe8\xeb\xb4\x10\x9a\xa7\x07\xed\x4a\x35\x5d\x90\xb3\x06\xe3\xc1\x6b\x46\x6b\x47\xcb\x0b\xf1\x31\xca\xec\xf7\x39\x25\x41\x6f\xad\xad\xed\x47\x9c\x1f\xb5\x54\x36\xec\xaf\x1b\x76\xa2\x67\xcc\x96\x69\x2f\x33\x2a\xc6\xaf\xd8\x12\xeb\xd6\xcf\xf7\x90\xa1\x14\xb8\x46\x58\x79\xeb\x6a\x71\x59\x4c\x49\x26\x25\xe6\x6d\x1a\xcb\x6d\xec\x4c\x0f\xff\xc7\x21\x78\x63\x89\xc1\xc4\x6a\x11\x76\xbc\xc8\xb6\x0b\x83\x05\xa4\x0a\x92\xf9\x59\xb2\xd7\x1f\x93\x3d\x6e\xfc\xd9\x31\xe3\x62\x93\x63\xd7\xc1\xaa\x80\xe8\x2e\x2a\x6c\xda\xcd\x4f\x28\xd2\x51\x00\xad\xa7\x20\xb5\x2c\x9b\xea\x60\x57\x5d\x72\xe8\xd7\xde\x57\xa5\x63\xdf\x6a\xfa\x6e\xd0\x31\x1f\x84\xfc\x1a\x00\x00\xff\xff\x1e\x52\x1a\x9b\xb9\x31\x00\x00")
+var _completionSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\x6d\x6f\xdb\x46\xf2\x7f\xfd\xe7\xa7\x98\x52\x02\x6a\x3b\x66\xdd\x14\xf8\xdf\x0b\xe7\x14\xa4\x97\x87\x43\x80\xb4\x29\xae\xd7\x17\x07\xc3\x20\x56\xe4\x50\xdc\xf3\x6a\x97\xd8\x07\xc9\x3a\x9f\xbf\xfb\x61\x96\xcb\x47\x29\x6e\xdc\xc8\x76\x11\xa8\x56\x11\x6a\xb9\x33\x3b\x9c\xdf\x3c\xac\x86\x3b\x93\x6f\xce\xe6\x5c\x9e\xcd\x99\x29\xa3\x68\x02\x69\x9a\x67\xca\xa4\x7f\x2d\x51\x54\xa8\xa1\x70\x32\x7b\x49\xc3\xf5\x68\x26\x38\x18\x37\xcf\xd4\x72\xc9\x64\xfe\x32\x8a\xc2\xf4\x1c\xe7\x6e\x71\x74\x0c\x37\x11\x00\x00\x2f\xe0\xe2\x02\x12\x09\xd3\x9b\x37\xaf\x3f\xfe\x9a\xbe\xfe\xf8\xd3\x2f\xe9\x9b\xb7\x7f\xfb\xed\xef\xe9\xbb\xf7\x1f\xde\xde\xc2\xe5\xe5\x0b\xb0\x25\x4a\x3f\x9b\x3e\x98\x95\x0a\xe2\xe9\xcd\xbb\xdf\x7e\x7e\xfd\xf3\x8f\x3f\xbd\xbd\x78\x7e\x79\x7b\x0e\xd3\x93\x18\x5e\xbe\x84\xf8\x13\x6c\x62\x4f\x5e\xf0\xe8\x36\x22\xc9\xdf\x60\xc1\x9c\xb0\x30\xc7\x92\xad\xb8\xd2\x60\x15\x2c\xd0\xd2\x42\x20\xf1\xda\x42\x90\x1a\x0a\xad\x96\x7e\x34\x73\x5a\xa3\x6c\x6f\x7c\x07\xef\x65\x3d\xce\x0c\x82\x2a\x20\x49\x48\x0b\x40\x34\x4b\xb6\x99\x23\x28\x5b\xa2\x06\xc3\xad\x63\x96\x2b\x69\xa2\x09\xf0\x9a\xa4\x70\xd6\x69\x04\x5b\x32\x0b\xa6\x54\x4e\xe4\x80\x32\x27\xce\x95\x40\x9a\x7b\x0a\xb6\xe4\x06\x34\x5a\xa7\xa5\x81\xe7\xa7\x35\xb3\x35\x37\x08\xdf\x47\x93\x68\x02\x3f\x1a\xe3\x96\x68\x80\xc1\xb4\x91\x74\xc5\x34\x67\x73\x81\x80\xd7\xdc\x58\xd3\x2c\x96\x31\x21\xb8\x5c\x40\xa6\xa4\xa5\x07\xf3\xab\xae\xb9\x10\x7e\x84\x35\x22\x29\x27\xf3\x1e\x5a\xa3\x25\xee\xc5\x9c\x9b\xbe\xc2\xa2\x09\xac\x95\xce\x61\x8e\x34\x11\x57\x4c\x38\x66\xb1\xa7\x58\x2e\x2b\x67\xc1\x58\x4d\xf7\x8f\xfc\x83\x73\x03\x39\x16\x5c\x62\x4e\x0b\x05\xb3\x59\x92\xac\x46\x05\x8d\xc9\x6f\x09\x3c\x60\x50\x69\x35\x17\xb8\x3c\xee\xac\xcb\x23\x9b\x86\x07\x49\x2b\xa6\x0d\xb6\xd6\xb6\x2e\xb9\x40\xb8\x80\x78\x9a\xc5\x90\x08\x4b\x17\x24\x5e\x0c\x97\x2f\x20\x57\xad\x8d\xf1\x59\x3c\xbd\xa1\x1b\xe6\x22\xbb\xbc\x8d\xdb\x71\x8f\x76\x3c\xe5\x31\xf0\xce\x20\xe9\xaf\x86\xff\xbf\x49\x79\x3c\x18\xa6\xcf\x84\x6c\x9c\xee\x02\x27\xc0\x0a\xc1\x16\xa7\x90\xa9\xb9\x66\x60\xac\xaa\x0c\x90\x88\xf4\xf0\x7c\xb9\xc4\x9c\x33\x8b\x62\x03\x46\xc1\x1a\x83\x05\x40\x89\x1a\xb7\xd8\x86\x7b\xcf\xb7\x6e\xbc\x78\x31\x18\x4a\x4e\x8e\xc7\x43\x27\xdb\x42\x06\x75\xcd\xe8\xd9\x86\x93\x89\x60\x42\x36\xc0\x0d\x60\x51\x60\x66\xf9\x8a\x24\x5c\x08\x35\x67\x82\x04\xf5\xa8\xaf\xd9\x06\xb8\x85\x8c\x49\xc8\x34\x5b\x0b\xb0\xa5\x56\x6e\x51\x7a\x5b\x60\x7a\xe1\x96\x28\xad\x01\x56\x1b\x47\xa5\xd5\x42\xb3\xe5\x8e\x85\x24\x5b\xf1\x05\xb3\x68\x82\xab\xc8\x8c\x3c\x02\xac\x46\xf4\xce\x65\x14\xc9\xe2\x0d\x98\x89\x35\xdb\x18\x32\x83\xd6\x6d\x9d\xd4\xc8\x72\x6f\x71\x3b\x78\xb3\xc2\x52\x98\xe2\x32\x27\x75\x8f\x7d\xfd\x94\x34\xce\x65\xa6\x91\x44\xa5\x55\xe6\x58\x28\x8d\x30\xd7\xc8\xae\x88\x42\x39\x4b\xae\x4e\x84\x42\xa9\x6a\x6b\x85\xa3\xa3\xec\xd9\xb3\xe3\x6d\xe5\x7a\x06\x77\xe1\x84\x86\x65\xd1\x2e\x2e\xb9\x92\x18\xf5\xd0\xfe\x9e\xe2\xd7\x04\x3e\x78\x27\x24\x39\x24\xa3\x30\x10\x84\xca\x94\x2c\xf8\xc2\x69\xcc\x21\x13\xce\x58\xd4\xe6\xbb\xc6\x2f\x9a\x81\xd6\x15\xc8\xa9\x7a\x31\x07\xd2\x76\x0a\xfc\xf0\x12\xce\x72\x5c\x9d\x49\x27\x04\x2d\x18\x58\x94\x4c\xe6\x02\xc9\xb3\x2a\x8d\x95\xd8\xb4\xac\xc2\x7d\x1f\xd8\x21\xee\x78\x1a\x1f\x96\x23\x3f\x47\xa8\x8c\x09\xe0\xb3\xef\xeb\xaf\x85\xd2\xfd\xc5\xb9\x84\x78\xfa\x2a\x1e\xf8\xe0\x04\x0a\x2e\x08\x30\x52\x7b\x8f\x29\xac\x4b\x9e\x95\x90\x2b\x8a\x01\x4b\x66\xb3\x72\x10\xa0\x07\xd8\xd7\xd9\x65\xda\x5b\x69\x36\x23\x9f\x77\x3a\x3e\xd9\xce\x2c\xf4\x37\x81\xac\xc4\xec\x8a\x7c\xd6\x73\xed\x28\x55\x51\x20\xa9\xb6\xf5\xe2\x3a\x82\xb3\x2c\xc3\x8a\x2c\x5b\xb6\x66\x1e\x24\xe4\x06\x96\x4c\x5f\x61\x0e\xf3\x0d\xdd\x9e\x8d\x16\xe2\x05\x18\x75\x0a\x0c\x4c\xc5\x32\xa4\xe8\x20\x15\x3d\x91\x74\x4c\x88\x0d\xb0\x3c\xc7\x1c\x0c\x97\x59\x6d\xe0\xce\xa0\xa6\x49\x78\x5d\x61\x46\x21\xd4\x2a\x9a\x03\x85\xd3\x94\x1d\xea\x40\x3a\x58\x22\x44\xab\xee\xe9\xb7\xc2\x16\x7d\x92\xe4\x64\xb6\x23\x22\xd0\x87\x92\xf1\x3f\xde\xfe\xf2\xe1\x5f\x17\xfc\xd9\xb3\xcb\xd9\x80\xd5\x4e\x82\x17\xdb\xb1\xe3\xfe\xac\x21\x8e\x46\x93\x77\xf1\x1e\xf8\x4c\xc1\x3b\x77\xf1\x1e\xf2\x9a\x09\x61\xea\x54\xde\x65\x35\xc2\x41\x39\xdd\x4f\x5b\x2b\x94\xb4\xa4\xf9\x2e\x9a\xc0\x3f\x3f\xbe\xf9\x78\xde\x81\xe8\xcd\x9d\xdc\x9e\x62\x1a\xa5\x19\x36\x17\x1b\x8a\x36\xb9\x92\x08\x4b\x8a\x0c\x78\x5d\x09\x9e\x71\x2b\x36\x44\x4e\xa9\x8b\x85\xac\x49\x80\x15\x4a\x08\xb5\x26\x0e\x4d\xfa\x24\xf0\xb8\xb1\xe3\xf4\x69\x32\x55\xd5\xd1\x8d\x69\x32\x39\xad\x31\xb3\xe7\xd1\xa4\x89\x4c\x86\xa4\xd2\x6c\x43\xc1\xa7\x7b\x1a\x8a\x90\xdc\x74\x21\xb2\x54\x22\x37\x1d\xd1\x79\x63\xbf\x34\x97\xf6\x37\xb4\x1a\x70\x69\xd5\xc8\x9f\x3b\x8e\xde\xa1\xa3\xd6\x38\x59\x4b\xbd\x66\x06\x16\x7c\x85\xf2\x34\x78\x87\xb7\x7c\x6e\xc9\x1c\x99\x04\x96\x59\xc7\x44\x3b\x9b\xfe\xf7\x8b\x91\x00\xcc\x18\x95\x51\x52\xcb\x5b\x49\xbb\x00\xb0\xf4\x09\x3e\x9e\xde\x04\x52\x73\xf1\xea\xf2\x76\x18\x05\x1a\x0f\x5e\xe6\xc1\x75\xeb\x99\xf1\x6e\xef\x1d\x46\xa2\xf1\xae\xe6\xdc\xf3\x89\xa3\x01\x49\x1d\x98\xba\x49\xde\x68\x9a\xed\xc3\x60\xe6\x04\xcc\x9a\x55\x90\x90\xcc\xe1\xbe\x09\x01\x98\xc2\x59\x3a\x98\xdc\x31\x9c\xb5\x8f\x77\x76\x96\x9c\xa5\xb7\xd1\x1d\x12\x37\x16\x41\x30\x75\xc8\x4e\x6f\x04\x33\xad\x4c\xb7\xe9\xf4\xa6\xe3\xde\xdb\x9c\xd0\xa7\x2f\xfc\xec\x4e\xba\x01\x59\x7f\xde\x6c\x3a\xd0\xc0\x60\x1e\x2f\x20\xc7\x4c\x90\x99\x26\x05\x0c\x26\x42\x2f\x69\xec\x40\x86\x3e\x43\xc6\xcd\x28\xfd\xa1\x30\xdb\x3b\x9c\xa1\x6a\xa6\x37\x7d\xea\x5b\xc8\x15\xd6\x01\xd3\xbb\xd4\x50\x0b\x05\x1f\x7c\xad\x93\x67\x34\xba\xdb\x04\x8b\x7a\x15\xe6\x6c\xe9\xed\xff\xff\x42\xa6\x6a\x20\x8e\x9a\x9f\x29\xdf\xc0\x5d\x5b\xcc\xd1\x23\xf7\x96\x2c\x78\x3f\x03\x06\x22\x33\x3b\x8a\x05\x37\x36\xa9\xb4\x5a\xf1\x1c\xb5\x89\x21\x16\x6a\xc1\x65\xfd\xaf\x72\x36\x86\x78\x5d\x2a\xb6\xe4\xf1\x71\x8f\x9e\xb6\x8f\x44\x5c\x6f\x38\xe3\xe3\x56\xbc\x0b\x48\xfe\x33\xf0\x8f\x91\x44\x4d\x32\x70\x7a\xc7\xe6\xf5\xe4\xf8\x53\xda\x1f\x27\x7d\x02\xc2\xcb\xe0\x5d\x35\xfa\x9d\x20\x7d\x3f\xbe\x8d\x6e\x7e\x97\xf5\x20\xf6\xef\x50\xf5\x70\x8d\xce\xe8\x87\x70\xa7\x5e\xdf\x8f\x01\x7a\x00\x0d\xe2\x24\xc9\x71\xc5\x33\xac\x9f\xae\x01\xb1\xf9\x52\x31\x63\x68\xfb\x32\xdb\x1a\x49\x0a\x2e\xb0\x37\xac\xf9\x8a\x59\x4c\xae\x70\xd3\x1f\xac\x2d\xa9\x1b\xa1\x1d\x03\xc5\xa7\x30\xb2\x2f\x5b\xd9\xb5\x5b\x98\x84\xfd\x98\x71\x55\xa5\xb4\xad\xf7\x47\x6d\x2a\xed\xe5\xf6\x0d\xda\x2d\xe2\x9e\xde\x3e\x01\xf8\x43\x5b\xe9\xe7\x1a\xd7\xc8\x7e\xb8\xb1\x69\xeb\xc0\x8f\x68\x48\x8d\xe1\x10\xc8\xff\x36\x4a\xc6\x07\x68\xbf\x14\xda\xad\xc8\xa0\x9c\x7d\x02\x44\x0f\x40\xee\x17\xc8\x3a\x83\x3e\x2e\x90\x39\x5a\xc6\x05\x25\xf4\x03\xa8\xfb\x06\x35\xd4\x28\xda\xd2\xc3\x1e\x31\x85\x1d\xa0\x06\x2a\xc2\x95\x59\xcb\xb2\x32\x86\x18\xaf\x49\xd1\x31\xc4\x21\x04\xd3\x36\x2e\x86\x58\xe3\x52\xad\xd0\x5f\x50\xce\x8d\x21\x36\x68\x5d\x75\xd8\xbc\xed\x7f\xf3\x16\x8c\x20\xad\x21\x79\x08\x5b\xb8\xd3\xbf\x0f\x3e\x7d\xb7\x4f\x7f\x36\xdf\xe9\x51\x3a\x00\xd4\x1c\xdf\xc5\xfc\x1e\xe1\x21\xad\x7d\xf4\x69\x2c\x83\xc2\x3e\x97\x99\x70\x39\x26\x56\x5d\xe1\x61\x77\xb6\xc7\xdd\x59\x03\x30\x05\xdd\xa7\x83\xb7\x0e\x3c\x98\xfb\x14\x9f\x29\xe1\x96\xd2\xcc\xba\xdd\x38\x5d\x48\x95\x94\xc8\x72\xd4\x7e\xb8\x62\x9a\x09\x81\x62\x76\xb0\x85\xfd\xdb\x42\x9d\x79\x9f\xd0\x1a\x84\xf0\x20\x3b\xc9\x56\x8c\x0b\x2a\xf4\x1e\x60\xfe\x34\xcc\x9f\xcd\xf7\xe1\xd2\x43\xbd\x45\x7b\x1a\x8b\xd9\x97\x65\x7c\xcd\xf8\xf8\x9d\xf3\xd3\xc0\xd3\x12\x50\x64\x67\x49\x86\xda\x9a\xd9\x70\x14\xb5\x4d\x0a\x2e\x17\xa8\x2b\xcd\xa5\x1d\xde\xed\xd7\xf7\xc2\x50\xa1\xf4\x78\x44\xab\x65\x32\x77\x64\xef\x43\x6a\x2e\x0d\x66\x4e\x0f\x67\xf7\x4a\x78\xcd\x88\x4a\xfc\x7b\x98\xf1\x60\x25\x1c\x55\x90\xfb\xa3\xa3\x9a\xe2\x68\xb4\x5f\x57\xfc\x64\x6d\x71\x77\x7d\xf1\x50\x63\x7c\x98\x1a\x63\xe7\x0c\xfe\x6d\xfe\x13\xfc\xd2\xbd\xc2\x0d\x15\x2f\x0c\xd2\x2b\x08\x53\xaa\x75\x0c\xb1\x93\xf4\xf5\xf0\x5b\x76\xff\xbf\x65\x3d\xca\x29\xe9\xfc\x69\x02\xde\xde\x6b\xc8\x0f\x86\xe0\x1f\x73\xa0\xd4\xa0\x7d\x2c\x27\xea\x54\x5b\xc7\x7c\xd2\x6d\x7d\x4e\x6a\xff\xc5\xc0\x3f\x9d\x9a\x4b\xb5\x7e\x7c\x3d\x7f\xd5\x2a\x75\xf2\x49\x6c\xf7\xab\xb7\xd8\x7a\x9b\xf4\x10\x8a\xdd\xa1\xd7\x40\x44\xe6\xca\xf2\x7c\x47\xbd\xd8\x55\x39\xb3\x78\xc8\xad\xfb\xcf\xad\x35\xce\x29\xcb\xf3\x47\xc2\xba\xc3\x8d\x19\xda\x2f\xb5\x2e\x44\x17\x15\x97\xc9\x0a\xb5\xe1\xa1\x42\x64\x4a\xf6\xc3\xff\xff\xc5\x5f\xb6\x26\xf0\x27\xc7\xf9\x8f\x39\xda\x13\x17\xeb\xbe\xce\xcd\x4d\x50\xed\x53\xd6\xbe\xbe\x56\x9d\xd6\xde\x78\xd0\xe9\x3e\x74\x3a\x7a\xf3\x3e\x7b\xfe\x40\x6a\x0c\x44\xe4\xfa\x74\xb4\x2b\x86\x38\xd4\x92\xe8\xca\x6f\x50\xbb\x17\xb6\xa1\x4a\x72\x47\xc2\xa5\x98\xdc\x0f\xd5\x1b\x34\x0d\x32\xed\x31\xe1\x3c\x87\x9a\x51\xf3\x44\xd4\xb0\x23\x70\xc5\xa4\xf5\xa7\x6d\xeb\xf6\x17\x3a\xc7\x1d\x5e\x16\x34\x67\xf2\x1b\x3e\x52\xf5\x4f\x9d\x36\xc7\x6b\xeb\xea\x2d\x02\x72\x7f\xa8\xdb\x0b\x06\x4a\xf7\xa6\x9a\x2f\xb4\x90\x1d\xd5\x95\xe6\xa1\x76\xd4\x57\x7a\x65\x15\xcd\x17\xa5\x05\xa9\xd6\x23\x5a\x7f\x0c\xd9\x1f\xc7\x14\xc8\x56\xe8\xcf\xeb\xd3\xd1\xdf\x4a\x59\x3a\x5d\x4d\x46\xae\x34\xe4\x68\xa9\x77\x44\x2e\x6a\x93\xaf\x0f\xcf\x5b\x76\x85\x40\x3d\x41\x68\x60\xee\x2c\xd0\xc1\x52\x83\xf4\xb6\x84\x0e\x0c\x0b\x7e\x35\x3c\x15\x3a\x81\x24\x21\xea\x9a\x04\xb8\x34\x96\xda\x3d\x7c\xfb\x15\x8d\xcf\xfc\xf8\x88\x64\x8d\xdf\x6a\xf4\x27\x45\xd7\x4a\xeb\x0d\x1d\xaf\x65\x73\xe5\xba\xfa\xd1\xa8\x74\x04\xb6\xf4\x8d\x2b\x46\x01\xb7\xdf\x1a\x30\xac\x40\x42\x94\x2f\xa4\x0a\x6d\x5b\x83\x15\x7a\x36\xb9\xc3\x6b\xc8\x9e\x8e\xef\x31\x7f\xcb\x77\xef\xef\xb7\x3d\x6e\x5b\x38\x4b\xd5\x98\x6b\x77\xa8\x9b\x7a\x78\x28\x45\x7b\xe0\x2a\x65\x0c\xa7\x4e\xaf\xb1\xc1\x35\xff\x8d\x18\x72\x09\x1a\x99\x00\x67\xd8\x02\x4f\xbb\x76\xb4\xd0\xa1\x63\x94\xef\x6d\x73\x55\x68\x00\xeb\x77\x03\x85\xd5\xad\xea\x9f\x54\x3f\xf5\x48\x19\x6a\x90\x1b\x97\xf1\x26\x50\xaa\x35\x35\xe9\xac\x83\x8f\xd5\x39\x77\x1b\x91\x3b\x54\x16\x56\xb9\x5b\x6b\x5f\xb4\xf3\xec\xba\xd5\xda\x0c\x32\x3a\xc8\xfc\x2a\x1e\x44\x2e\xa7\xa1\xd2\xb8\xf2\x4d\x4b\x06\x32\xfa\x67\x70\x1e\x3b\x84\x0b\x52\xd6\x60\xdc\x6b\x4d\x2b\x65\x89\xbc\xe0\xd7\xd1\xd6\x41\xee\xd8\xaf\x1b\x56\x6b\x1b\x2e\x66\x47\x6d\x28\x1b\x36\x10\x0c\x7b\xfb\xa8\xc9\x33\xe9\x79\x46\xc5\xb2\x2b\xb6\xc0\xd0\xdb\xf2\x1e\xe6\x28\x38\xae\x10\x96\xce\xd8\xc0\x6e\x4e\xfd\x53\xc6\xd2\x9b\xce\xbc\x75\x63\xb1\xa9\x7b\xfd\x88\x9f\x9f\x97\x2e\xd0\x8b\x58\xa5\xf4\xa8\x26\x9d\x6f\x52\x8d\x05\xb5\x80\xc6\xb3\xf3\x78\xa7\x3e\xa2\x1d\x6a\xfc\xd5\x32\xed\xc3\x49\x4f\x46\x25\x81\xc2\x5d\xbd\x60\xd3\xc0\x77\x42\xfe\x51\x33\x20\x7a\x02\x29\x90\x20\x24\xca\xcb\x15\x52\x0f\x7d\xed\x5d\x4a\x55\x37\xe6\x24\xef\x9a\xa5\x97\x8c\x4b\xc8\x33\x65\xa2\xff\x0d\x00\x33\xa6\x7e\x80\x0a\x3b\x00\x00")
 
 func completionShBytes() ([]byte, error) {
 	return bindataRead(
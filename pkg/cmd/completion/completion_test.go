@@ -0,0 +1,84 @@
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommand(ctx *mock.Context) *cobra.Command {
+	root := &cobra.Command{Use: "dcos"}
+	clusterCmd := &cobra.Command{Use: "cluster", Short: "Manage your DC/OS clusters"}
+	attachCmd := &cobra.Command{Use: "attach", Short: "Attach the CLI to a cluster", Run: func(*cobra.Command, []string) {}}
+	listCmd := &cobra.Command{Use: "list", Short: "List the clusters", Run: func(*cobra.Command, []string) {}}
+	listCmd.Flags().Bool("json", false, "returns clusters in json format")
+	clusterCmd.AddCommand(attachCmd, listCmd)
+	root.AddCommand(clusterCmd, NewCommand(ctx))
+	return root
+}
+
+func TestCompletionClusters(t *testing.T) {
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	ctx := mock.NewContext(env)
+
+	var clusters []*config.Cluster
+	for _, name := range []string{"dev", "prod"} {
+		cluster := config.NewCluster(nil)
+		cluster.SetName(name)
+		clusters = append(clusters, cluster)
+	}
+	ctx.SetClusters(clusters)
+
+	cmd := newTestCommand(ctx)
+	cmd.SetArgs([]string{"completion", clustersArg})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "dev\nprod\n", out.String())
+}
+
+func TestZshCompletion(t *testing.T) {
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	ctx := mock.NewContext(env)
+
+	cmd := newTestCommand(ctx)
+	cmd.SetArgs([]string{"completion", "zsh"})
+	require.NoError(t, cmd.Execute())
+
+	script := out.String()
+	require.Contains(t, script, "#compdef dcos\n")
+	require.Contains(t, script, "    'cluster')\n        commands=('attach:Attach the CLI to a cluster' 'list:List the clusters')\n")
+	require.Contains(t, script, "    'cluster attach')\n        flags=(--help)\n        complete_clusters=1\n")
+	require.Contains(t, script, "    'cluster list')\n        flags=(--help --json)\n        ;;\n")
+	require.NotContains(t, script, "'completion")
+}
+
+func TestFishCompletion(t *testing.T) {
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	ctx := mock.NewContext(env)
+
+	cmd := newTestCommand(ctx)
+	cmd.SetArgs([]string{"completion", "fish"})
+	require.NoError(t, cmd.Execute())
+
+	script := out.String()
+	require.Contains(t, script, "complete -c dcos -n '__dcos_using_command' -a 'cluster' -d 'Manage your DC/OS clusters'\n")
+	require.Contains(t, script, "complete -c dcos -n '__dcos_using_command cluster list' -l 'json' -d 'returns clusters in json format'\n")
+	require.Contains(t, script, "complete -c dcos -n '__dcos_using_command cluster attach' -a '(dcos completion __clusters 2> /dev/null)'\n")
+}
+
+func TestInvalidShell(t *testing.T) {
+	cmd := newTestCommand(mock.NewContext(nil))
+	cmd.SetArgs([]string{"completion", "powershell"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	require.Error(t, cmd.Execute())
+}
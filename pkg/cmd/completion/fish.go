@@ -0,0 +1,85 @@
+package completion
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// fishHeader is the beginning of the fish completion script. __dcos_using_command checks
+// whether the command path built from the words before the cursor, ignoring flags, is
+// the one given as argument.
+const fishHeader = `function __dcos_using_command
+    set -l cmdpath
+    for word in (commandline -opc)[2..-1]
+        switch $word
+            case '-*'
+            case '*'
+                set cmdpath $cmdpath $word
+        end
+    end
+    test "$cmdpath" = "$argv"
+end
+
+complete -c dcos -f
+`
+
+// genFishCompletion writes a fish completion script for the given root command.
+func genFishCompletion(ctx api.Context, root *cobra.Command) error {
+	var buf bytes.Buffer
+	buf.WriteString(fishHeader)
+
+	walk(root, func(cmd *cobra.Command) {
+		path := commandPath(cmd)
+		condition := fishQuote(strings.TrimSpace("__dcos_using_command " + path))
+
+		for _, subcmd := range subcommands(cmd) {
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -a %s -d %s\n",
+				condition, fishQuote(subcmd.Name()), fishQuote(subcmd.Short))
+		}
+
+		fmt.Fprintf(&buf, "complete -c dcos -n %s -l help -d 'Show usage help'\n", condition)
+		if !cmd.HasParent() {
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l version -d 'Print version information'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l json -d 'Print output in JSON format'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l timeout -r -d 'Abort the command after a duration'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l token -r -d 'Authenticate with the given ACS token'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l config-dir -r -d 'Use a directory instead of ~/.dcos'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -s y -l yes -d 'Answer yes to confirmation prompts'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l refresh -d 'Fetch cluster metadata instead of using the cache'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l no-version-check -d 'Do not warn about incompatible cluster versions'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l log-level -x -a 'error info debug' -d 'Set the log level'\n", condition)
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -s v -d 'Output verbosity'\n", condition)
+		}
+		for _, flag := range flags(cmd) {
+			if flag.Name == "help" {
+				continue
+			}
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -l %s", condition, fishQuote(flag.Name))
+			if flag.Shorthand != "" {
+				fmt.Fprintf(&buf, " -s %s", fishQuote(flag.Shorthand))
+			}
+			if flag.Value.Type() != "bool" {
+				buf.WriteString(" -r")
+			}
+			fmt.Fprintf(&buf, " -d %s\n", fishQuote(flag.Usage))
+		}
+
+		if clusterCommands[path] {
+			fmt.Fprintf(&buf, "complete -c dcos -n %s -a '(dcos completion __clusters 2> /dev/null)'\n", condition)
+		}
+	})
+
+	_, err := io.Copy(ctx.Out(), &buf)
+	return err
+}
+
+// fishQuote quotes a string for fish using single quotes.
+func fishQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return "'" + strings.Replace(s, "'", `\'`, -1) + "'"
+}
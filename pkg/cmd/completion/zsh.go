@@ -0,0 +1,98 @@
+package completion
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// zshHeader is the beginning of the zsh completion script, the command path is
+// built from the words before the cursor, ignoring flags.
+const zshHeader = `#compdef dcos
+
+__dcos_clusters() {
+    local -a clusters
+    clusters=(${(f)"$(dcos completion __clusters 2> /dev/null)"})
+    compadd -a clusters
+}
+
+_dcos() {
+    local -a commands flags
+    local word cmdpath complete_clusters
+
+    for word in ${words[2,CURRENT-1]}; do
+        [[ $word == -* ]] || cmdpath="${cmdpath:+$cmdpath }$word"
+    done
+
+    case "$cmdpath" in
+`
+
+// zshFooter is the end of the zsh completion script.
+const zshFooter = `    esac
+
+    if [[ $words[CURRENT] == -* ]]; then
+        compadd -a flags
+    elif [[ -n $complete_clusters ]]; then
+        __dcos_clusters
+    elif (( ${#commands} )); then
+        _describe -t commands 'dcos command' commands
+    fi
+}
+
+compdef _dcos dcos
+`
+
+// rootFlags are the global flags of the DC/OS CLI, they are parsed before cobra and thus not registered.
+var rootFlags = []string{"--version", "--json", "--timeout", "--token", "--config-dir", "--yes", "--refresh", "--no-version-check", "--log-level", "-v", "-vv"}
+
+// genZshCompletion writes a zsh completion script for the given root command.
+func genZshCompletion(ctx api.Context, root *cobra.Command) error {
+	var buf bytes.Buffer
+	buf.WriteString(zshHeader)
+
+	walk(root, func(cmd *cobra.Command) {
+		path := commandPath(cmd)
+		fmt.Fprintf(&buf, "    %s)\n", zshQuote(path))
+
+		var commands []string
+		for _, subcmd := range subcommands(cmd) {
+			commands = append(commands, zshQuote(strings.Replace(subcmd.Name(), ":", `\:`, -1)+":"+subcmd.Short))
+		}
+		if len(commands) > 0 {
+			fmt.Fprintf(&buf, "        commands=(%s)\n", strings.Join(commands, " "))
+		}
+
+		flagNames := []string{"--help"}
+		if !cmd.HasParent() {
+			flagNames = append(flagNames, rootFlags...)
+		}
+		for _, flag := range flags(cmd) {
+			if flag.Name == "help" {
+				continue
+			}
+			flagNames = append(flagNames, "--"+flag.Name)
+			if flag.Shorthand != "" {
+				flagNames = append(flagNames, "-"+flag.Shorthand)
+			}
+		}
+		fmt.Fprintf(&buf, "        flags=(%s)\n", strings.Join(flagNames, " "))
+
+		if clusterCommands[path] {
+			buf.WriteString("        complete_clusters=1\n")
+		}
+		buf.WriteString("        ;;\n")
+	})
+
+	buf.WriteString(zshFooter)
+	_, err := io.Copy(ctx.Out(), &buf)
+	return err
+}
+
+// zshQuote quotes a string for zsh using single quotes.
+func zshQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -12,6 +12,7 @@ func NewCommand(ctx api.Context) *cobra.Command {
 		Short: "Manage the DC/OS configuration file",
 	}
 	cmd.AddCommand(
+		newCmdConfigKeys(ctx),
 		newCmdConfigSet(ctx),
 		newCmdConfigShow(ctx),
 		newCmdConfigUnset(ctx),
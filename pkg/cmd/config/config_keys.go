@@ -0,0 +1,34 @@
+package config
+
+import (
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// newCmdConfigKeys creates the `dcos config keys` subcommand.
+func newCmdConfigKeys(ctx api.Context) *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "List the properties known by the CLI",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := config.KnownKeys()
+
+			if jsonOutput || ctx.JSONOutput() {
+				return cli.PrintJSON(ctx.Out(), keys)
+			}
+
+			table := cli.NewTable(ctx.Out(), []string{"NAME", "TYPE", "DESCRIPTION"})
+			for _, key := range keys {
+				table.Append([]string{key.Name, string(key.Type), key.Description})
+			}
+			table.Render()
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print properties in JSON format.")
+	return cmd
+}
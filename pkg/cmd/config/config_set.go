@@ -2,24 +2,36 @@ package config
 
 import (
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 // newCmdConfigSet creates the `dcos config set` subcommand.
 func newCmdConfigSet(ctx api.Context) *cobra.Command {
-	return &cobra.Command{
+	var force, global bool
+	cmd := &cobra.Command{
 		Use:   "set <name> <value>",
 		Short: "Add or set a property in the configuration file used for the current cluster",
-		Long:  "The properties that can be set are: core.dcos_url, core.dcos_acs_token, core.ssl_verify, core.timeout, core.ssh_user, core_ssh_proxy_ip, core.pagination, core.reporting, core.mesos_master_url, core_prompt_login",
+		Long:  "Add or set a property in the configuration file used for the current cluster.\n\nRun `dcos config keys` to list the properties known by the CLI, other properties (eg. defined by plugins) can be set with --force.\n\nWith --global, the property is set in the global configuration file, it then applies to all clusters which don't set it.",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cluster, err := ctx.Cluster()
+			if !force {
+				if err := config.Validate(args[0], args[1]); err != nil {
+					return err
+				}
+			}
+			if global {
+				if err := config.ValidateGlobal(args[0]); err != nil {
+					return err
+				}
+			}
+			conf, err := targetConfig(ctx, global)
 			if err != nil {
 				return err
 			}
-			conf := cluster.Config()
-			conf.Set(args[0], args[1])
-			err = conf.Persist()
+			err = conf.Update(func(conf *config.Config) {
+				conf.Set(args[0], args[1])
+			})
 			if err != nil {
 				return err
 			}
@@ -27,4 +39,19 @@ func newCmdConfigSet(ctx api.Context) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Set the property without validating it.")
+	cmd.Flags().BoolVar(&global, "global", false, "Set the property in the global configuration file.")
+	return cmd
+}
+
+// targetConfig returns the global config or the config of the current cluster.
+func targetConfig(ctx api.Context, global bool) (*config.Config, error) {
+	if global {
+		return ctx.ConfigManager().Global()
+	}
+	cluster, err := ctx.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	return cluster.Config(), nil
 }
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSet(t *testing.T) {
+	ctx, conf := configSetContext()
+
+	cmd := newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"core.timeout", "15"})
+	require.NoError(t, cmd.Execute())
+	require.EqualValues(t, 15, conf.Get("core.timeout"))
+}
+
+func TestConfigSetInvalid(t *testing.T) {
+	ctx, conf := configSetContext()
+
+	cmd := newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"core.dcos_url", "not a URL"})
+	require.EqualError(t, cmd.Execute(), "invalid value 'not a URL' for core.dcos_url, expected a URL such as https://example.com")
+	require.Nil(t, conf.Get("core.dcos_url"))
+
+	cmd = newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"core.dcos_ur", "https://dcos.example.com"})
+	require.EqualError(t, cmd.Execute(), "unknown config key 'core.dcos_ur', did you mean 'core.dcos_url'?")
+	require.Nil(t, conf.Get("core.dcos_ur"))
+}
+
+func TestConfigSetForce(t *testing.T) {
+	ctx, conf := configSetContext()
+
+	cmd := newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"--force", "marathon.url", "https://marathon.example.com"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "https://marathon.example.com", conf.Get("marathon.url"))
+}
+
+// configSetContext returns a context whose current cluster has an empty config.
+func configSetContext() (*mock.Context, *config.Config) {
+	env := mock.NewEnvironment()
+	env.Fs = afero.NewMemMapFs()
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "DCOS_DIR" {
+			return "/dcos", true
+		}
+		return "", false
+	}
+
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.SetPath("/dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763/dcos.toml")
+
+	ctx := mock.NewContext(env)
+	ctx.SetCluster(config.NewCluster(conf))
+	return ctx, conf
+}
+
+func TestConfigSetGlobal(t *testing.T) {
+	ctx, conf := configSetContext()
+
+	cmd := newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"--global", "core.timeout", "30"})
+	require.NoError(t, cmd.Execute())
+	require.Nil(t, conf.Get("core.timeout"))
+
+	global, err := ctx.ConfigManager().Global()
+	require.NoError(t, err)
+	require.EqualValues(t, 30, global.Get("core.timeout"))
+
+	cmd = newCmdConfigSet(ctx)
+	cmd.SetArgs([]string{"--global", "core.dcos_url", "https://dcos.example.com"})
+	require.EqualError(t, cmd.Execute(), "core.dcos_url is specific to a cluster and can't be set globally")
+}
+
+func TestConfigUnset(t *testing.T) {
+	ctx, conf := configSetContext()
+	conf.Set("core.timeout", 15)
+
+	cmd := newCmdConfigUnset(ctx)
+	cmd.SetArgs([]string{"core.timeout"})
+	require.NoError(t, cmd.Execute())
+	require.Nil(t, conf.Get("core.timeout"))
+
+	// Unsetting a key which isn't set is a no-op.
+	cmd = newCmdConfigUnset(ctx)
+	cmd.SetArgs([]string{"core.timeout"})
+	require.NoError(t, cmd.Execute())
+}
@@ -38,6 +38,11 @@ func newCmdConfigShow(ctx api.Context) *cobra.Command {
 					if key == "core.dcos_acs_token" {
 						val = "********"
 					}
+					// Values overridden by the environment are suffixed with the env var they come from.
+					if envVar, ok := conf.EnvSource(key); ok {
+						fmt.Fprintf(ctx.Out(), "%s %v (%s)\n", key, val, envVar)
+						continue
+					}
 					fmt.Fprintf(ctx.Out(), "%s %v\n", key, val)
 				}
 			}
@@ -33,3 +33,30 @@ func TestConfigShowEnvVar(t *testing.T) {
 
 	require.Equal(t, "https://dcos.example.org\n", out.String())
 }
+
+func TestConfigShowEnvSource(t *testing.T) {
+	var out bytes.Buffer
+
+	env := mock.NewEnvironment()
+	env.Out = &out
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "DCOS_CORE_TIMEOUT" {
+			return "30", true
+		}
+		return "", false
+	}
+
+	conf := config.New(config.Opts{EnvLookup: env.EnvLookup})
+	conf.Set("core.dcos_url", "https://dcos.example.org")
+	conf.Set("core.timeout", 15)
+
+	ctx := mock.NewContext(env)
+	ctx.SetCluster(config.NewCluster(conf))
+	cmd := newCmdConfigShow(ctx)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "core.dcos_url https://dcos.example.org\ncore.timeout 30 (DCOS_CORE_TIMEOUT)\n", out.String())
+}
@@ -2,28 +2,41 @@ package config
 
 import (
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 // newCmdConfigUnset creates the `dcos config unset` subcommand.
 func newCmdConfigUnset(ctx api.Context) *cobra.Command {
-	return &cobra.Command{
+	var global bool
+	cmd := &cobra.Command{
 		Use:   "unset <name>",
 		Short: "Remove a property from the configuration file used for the current cluster",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cluster, err := ctx.Cluster()
+			conf, err := targetConfig(ctx, global)
 			if err != nil {
 				return err
 			}
-			conf := cluster.Config()
-			conf.Unset(args[0])
-			err = conf.Persist()
+			var found bool
+			err = conf.Update(func(conf *config.Config) {
+				found = conf.Contains(args[0])
+				conf.Unset(args[0])
+			})
 			if err != nil {
 				return err
 			}
+			if !found {
+				ctx.Logger().Infof("Config value %s is not set", args[0])
+				return nil
+			}
 			ctx.Logger().Infof("Config value %s was removed", args[0])
+			if !global && config.IsRequired(args[0]) {
+				ctx.Logger().Warnf("%s is required, subsequent commands for this cluster may fail", args[0])
+			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&global, "global", false, "Remove the property from the global configuration file.")
+	return cmd
 }
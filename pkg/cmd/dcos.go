@@ -2,12 +2,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -75,6 +79,22 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 		annotationUsageOptions: `
   --version
       Print version information
+  --json
+      Print output in JSON format
+  --timeout <duration>
+      Abort the command when it takes longer than the duration (eg. 30s)
+  --token <token>
+      Authenticate requests to the cluster with the given ACS token
+  --config-dir <dir>
+      Use the given directory instead of ~/.dcos, DCOS_DIR can also be set
+  -y, --yes
+      Answer yes to confirmation prompts
+  --refresh
+      Fetch cluster metadata instead of using the cache
+  --no-version-check
+      Do not warn when the CLI version is incompatible with the cluster version
+  --log-level <level>
+      Set the log level (error, info or debug), HTTP requests are logged at debug level
   -v, -vv
       Output verbosity (verbose or very verbose)
   -h, --help
@@ -112,6 +132,12 @@ func newPluginCommand(ctx api.Context, cmd plugin.Command) *cobra.Command {
 				return updateCorePlugin(ctx)
 			}
 
+			// Plugin commands talk to the cluster, mismatched versions cause subtle failures.
+			cluster, clusterErr := ctx.Cluster()
+			if clusterErr == nil {
+				ctx.CheckClusterVersion(cluster)
+			}
+
 			executablePath, err := os.Executable()
 			if err != nil {
 				return err
@@ -123,6 +149,33 @@ func newPluginCommand(ctx api.Context, cmd plugin.Command) *cobra.Command {
 
 			execCmd.Env = append(os.Environ(), "DCOS_CLI_EXECUTABLE_PATH="+executablePath)
 
+			// Plugins use the same directory as the CLI, it might have been set through --config-dir.
+			if dcosDir := ctx.DCOSDir(); dcosDir != "" {
+				execCmd.Env = append(execCmd.Env, "DCOS_DIR="+dcosDir)
+			}
+
+			// Plugins authenticate with the token resolved by the CLI, eg. from --token. A token stored
+			// in the cluster config isn't passed, plugins read it themselves and might refresh it.
+			if clusterErr == nil {
+				acsToken, err := ctx.ACSToken(cluster)
+				if err != nil {
+					return err
+				}
+				if acsToken != "" && acsToken != cluster.ACSToken() {
+					execCmd.Env = append(execCmd.Env, "DCOS_CLUSTER_TOKEN="+acsToken)
+				}
+			}
+
+			// Plugins are given the time left before the --timeout is exceeded, they're terminated after that.
+			if deadline, ok := ctx.BaseContext().Deadline(); ok {
+				execCmd.Env = append(execCmd.Env, "DCOS_TIMEOUT="+time.Until(deadline).Round(time.Millisecond).String())
+			}
+
+			// Plugins print their output in JSON format when the --json global flag is set.
+			if ctx.JSONOutput() {
+				execCmd.Env = append(execCmd.Env, "DCOS_OUTPUT_JSON=1")
+			}
+
 			switch ctx.Logger().Level {
 			case logrus.DebugLevel:
 				execCmd.Env = append(execCmd.Env, "DCOS_VERBOSITY=2", "DCOS_LOG_LEVEL=debug")
@@ -130,7 +183,7 @@ func newPluginCommand(ctx api.Context, cmd plugin.Command) *cobra.Command {
 				execCmd.Env = append(execCmd.Env, "DCOS_VERBOSITY=1", "DCOS_LOG_LEVEL=info")
 			}
 
-			err = execCmd.Run()
+			err = runPlugin(ctx.BaseContext(), execCmd)
 			if err != nil {
 				// Because we're silencing errors through Cobra, we need to print this separately.
 				ctx.Logger().Debug(err)
@@ -140,6 +193,47 @@ func newPluginCommand(ctx api.Context, cmd plugin.Command) *cobra.Command {
 	}
 }
 
+// pluginTerminationTimeout is the time given to a plugin to exit after a SIGTERM, it is then killed.
+var pluginTerminationTimeout = 3 * time.Second
+
+// runPlugin runs a plugin command and waits for it to complete.
+//
+// The base context is done when the CLI receives SIGINT or SIGTERM or once the --timeout is exceeded.
+// The plugin is then sent a SIGTERM so that it can exit gracefully rather than being orphaned, it is
+// killed when it doesn't exit within pluginTerminationTimeout.
+func runPlugin(ctx context.Context, execCmd *exec.Cmd) error {
+	if err := execCmd.Start(); err != nil {
+		return err
+	}
+
+	terminationTimeout := pluginTerminationTimeout
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		// Signals other than Kill are not supported on Windows.
+		if err := execCmd.Process.Signal(syscall.SIGTERM); err != nil {
+			execCmd.Process.Kill()
+			return
+		}
+		select {
+		case <-time.After(terminationTimeout):
+			execCmd.Process.Kill()
+		case <-done:
+		}
+	}()
+
+	err := execCmd.Wait()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New("the command timed out")
+	}
+	return err
+}
+
 // updateCorePlugin updates the core CLI plugin.
 func updateCorePlugin(ctx api.Context) error {
 	cluster, err := ctx.Cluster()
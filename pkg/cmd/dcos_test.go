@@ -2,9 +2,24 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/dcos/dcos-cli/pkg/cli"
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/interrupt"
 	"github.com/dcos/dcos-cli/pkg/mock"
+	"github.com/dcos/dcos-cli/pkg/plugin"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +54,22 @@ Commands:
 Options:
   --version
       Print version information
+  --json
+      Print output in JSON format
+  --timeout <duration>
+      Abort the command when it takes longer than the duration (eg. 30s)
+  --token <token>
+      Authenticate requests to the cluster with the given ACS token
+  --config-dir <dir>
+      Use the given directory instead of ~/.dcos, DCOS_DIR can also be set
+  -y, --yes
+      Answer yes to confirmation prompts
+  --refresh
+      Fetch cluster metadata instead of using the cache
+  --no-version-check
+      Do not warn when the CLI version is incompatible with the cluster version
+  --log-level <level>
+      Set the log level (error, info or debug), HTTP requests are logged at debug level
   -v, -vv
       Output verbosity (verbose or very verbose)
   -h, --help
@@ -49,3 +80,224 @@ Use "dcos [command] --help" for more information about a command.
 
 	require.Equal(t, expectedHelp, out.String())
 }
+
+func TestConfigDirIsolation(t *testing.T) {
+	clusterID := "79893270-3ccd-4c17-a2ce-32d8eb1b1763"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"CLUSTER_ID": "` + clusterID + `"}`))
+	})
+	mux.HandleFunc("/mesos/state-summary", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"cluster": "ci"}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	env := mock.NewEnvironment()
+	env.EnvLookup = func(key string) (string, bool) {
+		if key == "DCOS_CLUSTER_SETUP_ACS_TOKEN" {
+			return "token_zj8Tb0vhQw", true
+		}
+		return "", false
+	}
+
+	configDir := filepath.Join(os.TempDir(), "dcos-ci")
+	ctx := mock.NewContext(env)
+	ctx.SetGlobalFlags(&cli.GlobalFlags{ConfigDir: configDir})
+	require.Equal(t, configDir, ctx.DCOSDir())
+
+	for _, args := range [][]string{
+		{"cluster", "setup", ts.URL, "--no-plugin"},
+		{"cluster", "attach", clusterID},
+		{"config", "set", "core.timeout", "30"},
+	} {
+		cmd := NewDCOSCommand(ctx)
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute(), "%v", args)
+	}
+
+	cluster, err := ctx.Cluster()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(configDir, "clusters", clusterID), cluster.Dir())
+	require.EqualValues(t, 30, cluster.Config().Get("core.timeout"))
+
+	// Nothing should be written outside of the config dir, eg. to ~/.dcos.
+	var files []string
+	err = afero.Walk(env.Fs, "/", func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		require.True(t, strings.HasPrefix(file, configDir+string(filepath.Separator)), file)
+	}
+}
+
+func TestPluginCommandToken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "dcos-cli")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binPath := filepath.Join(dir, "dcos-hello")
+	script := "#!/bin/sh\necho \"$DCOS_CLUSTER_TOKEN\"\n"
+	require.NoError(t, ioutil.WriteFile(binPath, []byte(script), 0755))
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	env.Args = []string{"dcos", "hello"}
+
+	ctx := mock.NewContext(env)
+	conf := config.New(config.Opts{Fs: env.Fs})
+	conf.SetPath("/dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763/dcos.toml")
+	cluster := config.NewCluster(conf)
+	cluster.SetACSToken("stored-token")
+	ctx.SetCluster(cluster)
+
+	pluginCmd := plugin.Command{Name: "hello", Path: binPath}
+
+	// The token given through --token is passed to plugins.
+	ctx.SetGlobalFlags(&cli.GlobalFlags{Token: "flag-token", NoVersionCheck: true})
+	require.NoError(t, newPluginCommand(ctx, pluginCmd).Execute())
+	require.Equal(t, "flag-token\n", out.String())
+
+	// The stored token is left to plugins.
+	out.Reset()
+	ctx.SetGlobalFlags(&cli.GlobalFlags{NoVersionCheck: true})
+	require.NoError(t, newPluginCommand(ctx, pluginCmd).Execute())
+	require.Equal(t, os.Getenv("DCOS_CLUSTER_TOKEN")+"\n", out.String())
+}
+
+func TestPluginCommandJSONOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "dcos-cli")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binPath := filepath.Join(dir, "dcos-hello")
+	script := "#!/bin/sh\necho \"$DCOS_OUTPUT_JSON $*\"\n"
+	require.NoError(t, ioutil.WriteFile(binPath, []byte(script), 0755))
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	env.Args = []string{"dcos", "--json", "hello", "list"}
+
+	ctx := mock.NewContext(env)
+	pluginCmd := plugin.Command{Name: "hello", Path: binPath}
+
+	ctx.SetGlobalFlags(&cli.GlobalFlags{JSON: true, NoVersionCheck: true})
+	require.NoError(t, newPluginCommand(ctx, pluginCmd).Execute())
+	require.Equal(t, "1 hello list\n", out.String())
+
+	out.Reset()
+	env.Args = []string{"dcos", "hello", "list"}
+	ctx.SetGlobalFlags(&cli.GlobalFlags{NoVersionCheck: true})
+	require.NoError(t, newPluginCommand(ctx, pluginCmd).Execute())
+	require.Equal(t, os.Getenv("DCOS_OUTPUT_JSON")+" hello list\n", out.String())
+}
+
+func TestPluginCommandForwardsSIGTERM(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "dcos-cli")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The plugin signals that it is ready then waits for up to 5 seconds.
+	readyPath := filepath.Join(dir, "ready")
+	binPath := filepath.Join(dir, "dcos-hello")
+	script := "#!/bin/sh\n" +
+		"trap 'echo terminated; exit 0' TERM\n" +
+		"touch " + readyPath + "\n" +
+		"i=0; while [ $i -lt 50 ]; do sleep 0.1; i=$((i+1)); done\n" +
+		"echo timeout\n"
+	require.NoError(t, ioutil.WriteFile(binPath, []byte(script), 0755))
+
+	var out bytes.Buffer
+	env := mock.NewEnvironment()
+	env.Out = &out
+	env.Args = []string{"dcos", "hello"}
+
+	ctx := mock.NewContext(env)
+	ctx.SetGlobalFlags(&cli.GlobalFlags{NoVersionCheck: true})
+
+	interruptHandler := interrupt.New(context.Background(), env.ErrOut)
+	interruptHandler.Start()
+	defer interruptHandler.Stop()
+	ctx.SetBaseContext(interruptHandler.Context())
+
+	go func() {
+		for {
+			if _, err := os.Stat(readyPath); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		process, _ := os.FindProcess(os.Getpid())
+		process.Signal(syscall.SIGTERM)
+	}()
+
+	require.NoError(t, newPluginCommand(ctx, plugin.Command{Name: "hello", Path: binPath}).Execute())
+	require.Equal(t, "terminated\n", out.String())
+}
+
+func TestPluginCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "dcos-cli")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	defaultTerminationTimeout := pluginTerminationTimeout
+	pluginTerminationTimeout = 100 * time.Millisecond
+	defer func() { pluginTerminationTimeout = defaultTerminationTimeout }()
+
+	// The first plugin exits on SIGTERM while the second one ignores it and must be killed.
+	for _, trap := range []string{"echo terminated; exit 0", ""} {
+		binPath := filepath.Join(dir, "dcos-hello")
+		script := "#!/bin/sh\n" +
+			"trap '" + trap + "' TERM\n" +
+			"echo \"$DCOS_TIMEOUT\"\n" +
+			"i=0; while [ $i -lt 50 ]; do sleep 0.1; i=$((i+1)); done\n" +
+			"echo timeout\n"
+		require.NoError(t, ioutil.WriteFile(binPath, []byte(script), 0755))
+
+		var out bytes.Buffer
+		env := mock.NewEnvironment()
+		env.Out = &out
+		env.Args = []string{"dcos", "hello"}
+
+		ctx := mock.NewContext(env)
+		ctx.SetGlobalFlags(&cli.GlobalFlags{NoVersionCheck: true})
+		baseCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		ctx.SetBaseContext(baseCtx)
+
+		start := time.Now()
+		err := newPluginCommand(ctx, plugin.Command{Name: "hello", Path: binPath}).Execute()
+		require.EqualError(t, err, "the command timed out")
+		require.True(t, time.Since(start) < 3*time.Second, trap)
+
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		timeout, err := time.ParseDuration(lines[0])
+		require.NoError(t, err)
+		require.True(t, timeout > 0 && timeout <= 500*time.Millisecond, lines[0])
+		if trap != "" {
+			require.Equal(t, []string{lines[0], "terminated"}, lines)
+		} else {
+			require.Len(t, lines, 1)
+		}
+	}
+}
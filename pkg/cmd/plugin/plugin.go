@@ -15,6 +15,7 @@ func NewCommand(ctx api.Context) *cobra.Command {
 		newCmdPluginAdd(ctx),
 		newCmdPluginRemove(ctx),
 		newCmdPluginList(ctx),
+		newCmdPluginUpdate(ctx),
 	)
 	return cmd
 }
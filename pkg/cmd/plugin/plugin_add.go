@@ -8,6 +8,7 @@ import (
 
 // newCmdPluginAdd creates the `dcos plugin add` subcommand.
 func newCmdPluginAdd(ctx api.Context) *cobra.Command {
+	var sha256 string
 	installOpts := &plugin.InstallOpts{}
 	cmd := &cobra.Command{
 		Use:   "add <resource>",
@@ -18,9 +19,18 @@ func newCmdPluginAdd(ctx api.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if sha256 != "" {
+				installOpts.Checksum, err = plugin.SHA256Checksum(sha256)
+				if err != nil {
+					return err
+				}
+			}
 			return ctx.PluginManager(cluster).Install(args[0], installOpts)
 		},
 	}
 	cmd.Flags().BoolVarP(&installOpts.Update, "update", "u", false, "")
+	cmd.Flags().BoolVar(&installOpts.PinVersion, "pin-version", false, "Pin the plugin to the installed version, it is then skipped when updating all plugins.")
+	cmd.Flags().StringVar(&sha256, "sha256", "", "Verify the downloaded plugin against a SHA-256 checksum.")
+	cmd.Flags().StringVar(&installOpts.Asset, "asset", "", "Name of the asset to install from a GitHub release, instead of the one matching the current platform.")
 	return cmd
 }
@@ -1,7 +1,6 @@
 package plugin
 
 import (
-	"encoding/json"
 	"strings"
 
 	"github.com/dcos/dcos-cli/api"
@@ -24,19 +23,24 @@ func newCmdPluginList(ctx api.Context) *cobra.Command {
 
 			plugins := ctx.PluginManager(cluster).Plugins()
 
-			if jsonOutput {
-				enc := json.NewEncoder(ctx.Out())
-				enc.SetIndent("", "    ")
-				return enc.Encode(plugins)
+			if jsonOutput || ctx.JSONOutput() {
+				return cli.PrintJSON(ctx.Out(), plugins)
 			}
 
-			table := cli.NewTable(ctx.Out(), []string{"NAME", "COMMANDS"})
+			table := cli.NewTable(ctx.Out(), []string{"NAME", "VERSION", "COMMANDS"})
 			for _, plugin := range plugins {
 				var commands []string
 				for _, command := range plugin.Commands {
 					commands = append(commands, command.Name)
 				}
-				table.Append([]string{plugin.Name, strings.Join(commands, " ")})
+				version := plugin.Version
+				if version == "" {
+					version = "N/A"
+				}
+				if plugin.Pinned {
+					version += " (pinned)"
+				}
+				table.Append([]string{plugin.Name, version, strings.Join(commands, " ")})
 			}
 			table.Render()
 			return nil
@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newCmdPluginUpdate creates the `dcos plugin update` subcommand.
+func newCmdPluginUpdate(ctx api.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "update [<plugin>]",
+		Short: "Update CLI plugins",
+		Long:  "Update a CLI plugin from the resource it was installed from, all plugins are updated when none is specified.\n\nPlugins added with --pin-version are only updated when they are specified explicitly.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cluster, err := ctx.Cluster()
+			if err != nil {
+				return err
+			}
+			pluginManager := ctx.PluginManager(cluster)
+
+			names := args
+			if len(names) == 0 {
+				for _, p := range pluginManager.Plugins() {
+					if p.Pinned {
+						fmt.Fprintf(ctx.Out(), "%s is pinned, skipping.\n", p.Name)
+						continue
+					}
+					names = append(names, p.Name)
+				}
+			}
+
+			var failed bool
+			for _, name := range names {
+				updated, err := pluginManager.Update(name)
+				switch {
+				case err != nil && len(args) == 1:
+					return err
+				case err != nil:
+					fmt.Fprintf(ctx.ErrOut(), "Couldn't update %s: %s\n", name, err)
+					failed = true
+				case updated:
+					fmt.Fprintf(ctx.Out(), "Updated %s.\n", name)
+				default:
+					fmt.Fprintf(ctx.Out(), "%s is already up-to-date.\n", name)
+				}
+			}
+			if failed {
+				return errors.New("some plugins couldn't be updated")
+			}
+			return nil
+		},
+	}
+}
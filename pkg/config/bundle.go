@@ -0,0 +1,247 @@
+package config
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+)
+
+// Bundle holds the connection details of a cluster. It is a portable file which allows
+// to set up the CLI for a cluster without going through the interactive setup.
+//
+// The ACS token is per-user, it is only part of a bundle when explicitly requested.
+type Bundle struct {
+	ID                 string `json:"cluster_id"`
+	Name               string `json:"name"`
+	URL                string `json:"url"`
+	Insecure           bool   `json:"insecure,omitempty"`
+	CACerts            string `json:"ca_certs,omitempty"`
+	TLSCertFingerprint string `json:"cert_fingerprint,omitempty"`
+	ACSToken           string `json:"acs_token,omitempty"`
+}
+
+// ErrClusterExists means that a cluster with the same ID as the one being imported is already configured.
+var ErrClusterExists = errors.New("the cluster is already configured")
+
+// NewBundle creates the bundle of a cluster, the CA bundle it trusts is read from the filesystem.
+func NewBundle(cluster *Cluster, includeToken bool) (*Bundle, error) {
+	bundle := &Bundle{
+		ID:                 cluster.ID(),
+		Name:               cluster.Name(),
+		URL:                cluster.URL(),
+		TLSCertFingerprint: cluster.TLSCertFingerprint(),
+	}
+
+	// The TLS value is either a bool or the path to a CA bundle. When it is not set,
+	// certificates are verified against the system CAs.
+	tlsVal := cast.ToString(cluster.Config().Get(keyTLS))
+	if verify, err := strconv.ParseBool(tlsVal); err == nil {
+		bundle.Insecure = !verify
+	} else if tlsVal != "" {
+		caCerts, err := afero.ReadFile(cluster.Config().Fs(), tlsVal)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the CA bundle of the cluster: %s", err)
+		}
+		bundle.CACerts = string(caCerts)
+	}
+	if includeToken {
+		bundle.ACSToken = cluster.ACSToken()
+	}
+	return bundle, nil
+}
+
+// ReadBundle reads a bundle file.
+func ReadBundle(fs afero.Fs, path string) (*Bundle, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid cluster bundle '%s': %s", path, err)
+	}
+	if bundle.ID == "" || bundle.URL == "" {
+		return nil, fmt.Errorf("invalid cluster bundle '%s': the cluster ID and URL are required", path)
+	}
+	return &bundle, nil
+}
+
+// Write writes the bundle to a file. Bundles holding an ACS token are only readable by their owner.
+func (b *Bundle) Write(fs afero.Fs, path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if b.ACSToken != "" {
+		perm = 0600
+	}
+	return afero.WriteFile(fs, path, append(data, '\n'), perm)
+}
+
+// ImportOpts are options to import a cluster from a bundle.
+type ImportOpts struct {
+	// Force replaces the config of the cluster when it is already configured.
+	Force bool
+
+	// Setup is called with the staged cluster before its config is moved into place (eg. to log in).
+	// When it fails, the import is aborted and an existing config of the cluster is left untouched.
+	Setup func(cluster *Cluster) error
+}
+
+// Import creates the config of the cluster described by a bundle. It fails with ErrClusterExists
+// when the cluster is already configured, unless opts.Force is true.
+//
+// The bundle is validated and the config is staged before an existing config of the cluster is
+// replaced. Only the config and CA bundle files are replaced, other files of the cluster directory
+// (eg. its `attached` file or installed plugins) are kept.
+func (m *Manager) Import(bundle *Bundle, opts ImportOpts) (*Cluster, error) {
+	if err := bundle.validate(); err != nil {
+		return nil, err
+	}
+
+	clustersDir := filepath.Join(m.dir, "clusters")
+	clusterDir := filepath.Join(clustersDir, bundle.ID)
+	clusterExists, err := afero.DirExists(m.fs, clusterDir)
+	if err != nil {
+		return nil, err
+	}
+	if clusterExists && !opts.Force {
+		return nil, ErrClusterExists
+	}
+
+	// The config is staged next to its final location, renames within the clusters dir are atomic.
+	if err := m.fs.MkdirAll(clustersDir, 0755); err != nil {
+		return nil, err
+	}
+	tmpDir, err := afero.TempDir(m.fs, clustersDir, "."+bundle.ID)
+	if err != nil {
+		return nil, err
+	}
+	newClusterDir := filepath.Join(tmpDir, "new")
+	oldClusterDir := filepath.Join(tmpDir, "old")
+
+	cluster := NewCluster(m.newConfig())
+	cluster.SetID(bundle.ID)
+	cluster.SetURL(bundle.URL)
+	cluster.SetName(bundle.Name)
+	if bundle.Name == "" {
+		cluster.SetName(bundle.ID)
+	}
+	cluster.SetTLS(TLS{Insecure: bundle.Insecure})
+	if bundle.TLSCertFingerprint != "" {
+		cluster.SetTLSCertFingerprint(bundle.TLSCertFingerprint)
+	}
+	if bundle.ACSToken != "" {
+		cluster.SetACSToken(bundle.ACSToken)
+	}
+
+	if err := m.save(cluster.Config(), newClusterDir, []byte(bundle.CACerts)); err != nil {
+		m.fs.RemoveAll(tmpDir)
+		return nil, err
+	}
+	if opts.Setup != nil {
+		if err := opts.Setup(cluster); err != nil {
+			m.fs.RemoveAll(tmpDir)
+			return nil, err
+		}
+	}
+
+	// The CA bundle is referenced by its final path before moving the config into place.
+	if bundle.CACerts != "" {
+		cluster.Config().Set(keyTLS, filepath.Join(clusterDir, "dcos_ca.crt"))
+		if err := cluster.Config().Persist(); err != nil {
+			m.fs.RemoveAll(tmpDir)
+			return nil, err
+		}
+	}
+	if err := m.swapClusterFiles(clusterDir, newClusterDir, oldClusterDir); err != nil {
+		return nil, err
+	}
+	m.fs.RemoveAll(tmpDir)
+
+	cluster.Config().SetPath(filepath.Join(clusterDir, "dcos.toml"))
+	return cluster, nil
+}
+
+// clusterFiles are the files of a cluster directory which are replaced when importing a bundle.
+var clusterFiles = []string{"dcos.toml", "dcos_ca.crt"}
+
+// swapClusterFiles moves the staged files of a cluster into its directory, the files they
+// replace are moved to the old dir. On failure, the replaced files are put back in place.
+// The staging dir is kept when they can't, in order not to lose the existing config.
+func (m *Manager) swapClusterFiles(clusterDir, newClusterDir, oldClusterDir string) error {
+	clusterExists, err := afero.DirExists(m.fs, clusterDir)
+	if err != nil {
+		return err
+	}
+	if err := m.fs.MkdirAll(clusterDir, 0755); err != nil {
+		return err
+	}
+	if err := m.fs.MkdirAll(oldClusterDir, 0755); err != nil {
+		return err
+	}
+
+	var moved []string
+	restore := func(err error) error {
+		for _, name := range clusterFiles {
+			m.fs.Remove(filepath.Join(clusterDir, name))
+		}
+		if !clusterExists {
+			m.fs.Remove(clusterDir)
+		}
+		for _, name := range moved {
+			if restoreErr := m.fs.Rename(filepath.Join(oldClusterDir, name), filepath.Join(clusterDir, name)); restoreErr != nil {
+				return fmt.Errorf("%s (the previous config is in %s)", err, oldClusterDir)
+			}
+		}
+		m.fs.RemoveAll(filepath.Dir(newClusterDir))
+		return err
+	}
+
+	for _, name := range clusterFiles {
+		path := filepath.Join(clusterDir, name)
+		if !m.fileExists(path) {
+			continue
+		}
+		if err := m.fs.Rename(path, filepath.Join(oldClusterDir, name)); err != nil {
+			return restore(err)
+		}
+		moved = append(moved, name)
+	}
+	for _, name := range clusterFiles {
+		path := filepath.Join(newClusterDir, name)
+		if !m.fileExists(path) {
+			continue
+		}
+		if err := m.fs.Rename(path, filepath.Join(clusterDir, name)); err != nil {
+			return restore(err)
+		}
+	}
+	return nil
+}
+
+// validate checks that a bundle can be imported.
+func (b *Bundle) validate() error {
+	// The ID is used as a directory name, make sure it can't point outside of the clusters directory.
+	if b.ID != filepath.Base(b.ID) || b.ID == "." || b.ID == ".." || strings.HasPrefix(b.ID, ".") {
+		return fmt.Errorf("invalid cluster ID '%s'", b.ID)
+	}
+	if b.TLSCertFingerprint != "" {
+		if _, err := ParseCertFingerprint(b.TLSCertFingerprint); err != nil {
+			return err
+		}
+	}
+	if b.CACerts != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(b.CACerts)) {
+		return errors.New("no certificate found in the CA bundle of the cluster")
+	}
+	return nil
+}
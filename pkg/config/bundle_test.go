@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleExportImport(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	conf := New(Opts{Fs: fs})
+	cluster := NewCluster(conf)
+	cluster.SetURL("https://dcos.example.com")
+	cluster.SetName("prod")
+	cluster.SetACSToken("secret-token")
+	caCerts, _ := generateCertificate(t)
+	require.NoError(t, manager.Save(conf, "79893270-3ccd-4c17-a2ce-32d8eb1b1763", caCerts))
+
+	bundle, err := NewBundle(cluster, false)
+	require.NoError(t, err)
+	require.Equal(t, "79893270-3ccd-4c17-a2ce-32d8eb1b1763", bundle.ID)
+	require.Equal(t, "prod", bundle.Name)
+	require.Equal(t, "https://dcos.example.com", bundle.URL)
+	require.Equal(t, string(caCerts), bundle.CACerts)
+	require.Empty(t, bundle.ACSToken)
+
+	require.NoError(t, bundle.Write(fs, "/bundle.json"))
+
+	// Import the bundle on another machine.
+	otherFs := afero.NewMemMapFs()
+	data, err := afero.ReadFile(fs, "/bundle.json")
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(otherFs, "/bundle.json", data, 0644))
+	otherManager := NewManager(ManagerOpts{Fs: otherFs, Dir: "/home/user/.dcos"})
+
+	importedBundle, err := ReadBundle(otherFs, "/bundle.json")
+	require.NoError(t, err)
+	require.Equal(t, bundle, importedBundle)
+
+	imported, err := otherManager.Import(importedBundle, ImportOpts{})
+	require.NoError(t, err)
+	require.Equal(t, "79893270-3ccd-4c17-a2ce-32d8eb1b1763", imported.ID())
+	require.Equal(t, "prod", imported.Name())
+	require.Equal(t, "https://dcos.example.com", imported.URL())
+	require.Empty(t, imported.ACSToken())
+	require.Equal(t, "/home/user/.dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763/dcos_ca.crt", imported.TLS().RootCAsPath)
+
+	_, err = otherManager.Import(importedBundle, ImportOpts{})
+	require.Equal(t, ErrClusterExists, err)
+
+	importedBundle.Name = "production"
+	imported, err = otherManager.Import(importedBundle, ImportOpts{Force: true})
+	require.NoError(t, err)
+	require.Equal(t, "production", imported.Name())
+	require.Len(t, otherManager.All(), 1)
+
+	// The staging directory is removed once the config is in place.
+	entries, err := afero.ReadDir(otherFs, "/home/user/.dcos/clusters")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestForceImportKeepsClusterFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	bundle := &Bundle{ID: "79893270-3ccd-4c17-a2ce-32d8eb1b1763", Name: "prod", URL: "https://dcos.example.com"}
+	cluster, err := manager.Import(bundle, ImportOpts{})
+	require.NoError(t, err)
+	require.NoError(t, manager.Attach(cluster.Config()))
+	pluginPath := filepath.Join(cluster.Dir(), "subcommands", "dcos-core-cli", "plugin.toml")
+	require.NoError(t, afero.WriteFile(fs, pluginPath, []byte("name = \"dcos-core-cli\"\n"), 0644))
+
+	bundle.Name = "production"
+	cluster, err = manager.Import(bundle, ImportOpts{
+		Force: true,
+		Setup: func(cluster *Cluster) error {
+			require.Equal(t, "79893270-3ccd-4c17-a2ce-32d8eb1b1763", cluster.ID())
+			return cluster.Config().Update(func(conf *Config) {
+				cluster.SetACSToken("token")
+			})
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763", cluster.Dir())
+
+	current, err := manager.Current()
+	require.NoError(t, err)
+	require.Equal(t, "production", NewCluster(current).Name())
+	require.Equal(t, "token", NewCluster(current).ACSToken())
+	require.True(t, manager.fileExists(pluginPath))
+}
+
+func TestFailedForceImportKeepsExistingConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	caCerts, _ := generateCertificate(t)
+	bundle := &Bundle{
+		ID:      "79893270-3ccd-4c17-a2ce-32d8eb1b1763",
+		Name:    "prod",
+		URL:     "https://dcos.example.com",
+		CACerts: string(caCerts),
+	}
+	cluster, err := manager.Import(bundle, ImportOpts{})
+	require.NoError(t, err)
+	require.NoError(t, manager.Attach(cluster.Config()))
+
+	// Invalid bundles are rejected before touching the existing config.
+	_, err = manager.Import(&Bundle{ID: bundle.ID, URL: bundle.URL, TLSCertFingerprint: "invalid"}, ImportOpts{Force: true})
+	require.Error(t, err)
+
+	_, err = manager.Import(&Bundle{ID: bundle.ID, URL: bundle.URL, CACerts: "invalid"}, ImportOpts{Force: true})
+	require.EqualError(t, err, "no certificate found in the CA bundle of the cluster")
+
+	// A failed setup aborts the import.
+	_, err = manager.Import(&Bundle{ID: bundle.ID, Name: "production", URL: bundle.URL}, ImportOpts{
+		Force: true,
+		Setup: func(cluster *Cluster) error {
+			return errors.New("login failed")
+		},
+	})
+	require.EqualError(t, err, "login failed")
+
+	current, err := manager.Current()
+	require.NoError(t, err)
+	require.Equal(t, "prod", NewCluster(current).Name())
+	require.Equal(t, "/dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763/dcos_ca.crt", NewCluster(current).TLS().RootCAsPath)
+	require.NotNil(t, NewCluster(current).TLS().RootCAs)
+
+	entries, err := afero.ReadDir(fs, "/dcos/clusters")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestBundleIncludeToken(t *testing.T) {
+	cluster := NewCluster(nil)
+	cluster.SetURL("https://dcos.example.com")
+	cluster.SetACSToken("secret-token")
+
+	bundle, err := NewBundle(cluster, true)
+	require.NoError(t, err)
+	require.Equal(t, "secret-token", bundle.ACSToken)
+}
+
+func TestImportInvalidBundle(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	_, err := manager.Import(&Bundle{ID: "../../etc", URL: "https://dcos.example.com"}, ImportOpts{})
+	require.Error(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, "/bundle.json", []byte(`{"name": "prod"}`), 0644))
+	_, err = ReadBundle(fs, "/bundle.json")
+	require.Error(t, err)
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -51,6 +53,38 @@ func (c *Cluster) SetACSToken(acsToken string) {
 	c.config.Set("core.dcos_acs_token", acsToken)
 }
 
+// ACSTokenFile returns the path to a file containing the ACS token.
+func (c *Cluster) ACSTokenFile() string {
+	return cast.ToString(c.config.Get(keyACSTokenFile))
+}
+
+// SetACSTokenFile sets the path to a file containing the ACS token.
+func (c *Cluster) SetACSTokenFile(path string) {
+	c.config.Set(keyACSTokenFile, path)
+}
+
+// ResolveACSToken returns the ACS token used to authenticate requests to the cluster. The
+// DCOS_CLUSTER_TOKEN env var takes precedence, followed by the file referenced by
+// "core.dcos_acs_token_file" and "core.dcos_acs_token". The file is read on each call,
+// this allows the token to be rotated without rewriting the config.
+func (c *Cluster) ResolveACSToken() (string, error) {
+	if token, ok := c.config.envLookup(envToken); ok && token != "" {
+		return token, nil
+	}
+	if path := c.ACSTokenFile(); path != "" {
+		rawToken, err := afero.ReadFile(c.config.Fs(), path)
+		if err != nil {
+			return "", fmt.Errorf("couldn't read the ACS token file referenced by %s: %s", keyACSTokenFile, err)
+		}
+		token := strings.TrimSpace(string(rawToken))
+		if token == "" {
+			return "", fmt.Errorf("the ACS token file '%s' is empty", path)
+		}
+		return token, nil
+	}
+	return c.ACSToken(), nil
+}
+
 // TLS returns the configuration for TLS clients.
 func (c *Cluster) TLS() TLS {
 	tlsVal := cast.ToString(c.config.Get("core.ssl_verify"))
@@ -91,6 +125,102 @@ func (c *Cluster) SetTLS(tls TLS) {
 	c.config.Set("core.ssl_verify", tls.String())
 }
 
+// ClientCertificate returns the certificate used for TLS client authentication, it is loaded
+// from the "core.ssl_client_cert" and "core.ssl_client_key" paths. It returns nil when the
+// cluster isn't configured for TLS client authentication.
+func (c *Cluster) ClientCertificate() (*tls.Certificate, error) {
+	certPath := cast.ToString(c.config.Get(keyTLSClientCert))
+	keyPath := cast.ToString(c.config.Get(keyTLSClientKey))
+	if certPath == "" && keyPath == "" {
+		return nil, nil
+	}
+	if certPath == "" || keyPath == "" {
+		return nil, ErrIncompleteClientCertificate
+	}
+	return LoadClientCertificate(c.config.Fs(), certPath, keyPath)
+}
+
+// SetClientCertificate sets the paths to the certificate and private key used for TLS client authentication.
+func (c *Cluster) SetClientCertificate(certPath, keyPath string) {
+	c.config.Set(keyTLSClientCert, certPath)
+	c.config.Set(keyTLSClientKey, keyPath)
+}
+
+// TLSMinVersion returns the minimum TLS version for connections to the cluster.
+// It defaults to TLS 1.2, which is also used when the configured version is invalid.
+func (c *Cluster) TLSMinVersion() uint16 {
+	val := cast.ToString(c.config.Get(keyTLSMinVersion))
+	if val == "" {
+		return tls.VersionTLS12
+	}
+	version, err := ParseTLSVersion(val)
+	if err != nil {
+		return tls.VersionTLS12
+	}
+	return version
+}
+
+// SetTLSMinVersion sets the minimum TLS version for connections to the cluster (eg. "1.2").
+func (c *Cluster) SetTLSMinVersion(version string) {
+	c.config.Set(keyTLSMinVersion, version)
+}
+
+// TLSCipherSuites returns the allowed TLS cipher suites for connections to the cluster.
+// It returns nil when no cipher suite is configured, Go defaults are then used.
+func (c *Cluster) TLSCipherSuites() ([]uint16, error) {
+	suites, err := ParseCipherSuites(cast.ToString(c.config.Get(keyTLSCiphers)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %s", keyTLSCiphers, err)
+	}
+	return suites, nil
+}
+
+// TLSCertFingerprint returns the pinned SHA-256 fingerprint of the server certificate, if any.
+func (c *Cluster) TLSCertFingerprint() string {
+	return cast.ToString(c.config.Get(keyTLSFingerprint))
+}
+
+// SetTLSCertFingerprint pins the SHA-256 fingerprint of the server certificate.
+func (c *Cluster) SetTLSCertFingerprint(fingerprint string) {
+	c.config.Set(keyTLSFingerprint, fingerprint)
+}
+
+// TLSConfig returns the configuration for TLS clients communicating with the cluster.
+//
+// When a client certificate is configured but can't be loaded, the error is
+// returned during the TLS handshake if the cluster requests a client certificate.
+// When a certificate fingerprint is pinned, connections to a server whose leaf
+// certificate doesn't match it fail with a CertFingerprintMismatchError.
+// When the configured cipher suites are invalid, TLS handshakes fail with the
+// parsing error rather than using cipher suites the user didn't allow.
+func (c *Cluster) TLSConfig() *tls.Config {
+	tlsConf := c.TLS()
+	cipherSuites, cipherSuitesErr := c.TLSCipherSuites()
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: tlsConf.Insecure,
+		RootCAs:            tlsConf.RootCAs,
+		MinVersion:         c.TLSMinVersion(),
+		CipherSuites:       cipherSuites,
+	}
+
+	if fingerprint := c.TLSCertFingerprint(); fingerprint != "" {
+		tlsConfig.VerifyPeerCertificate = VerifyCertFingerprint(fingerprint)
+	}
+	if cipherSuitesErr != nil {
+		tlsConfig.VerifyPeerCertificate = func([][]byte, [][]*x509.Certificate) error {
+			return cipherSuitesErr
+		}
+	}
+
+	clientCert, err := c.ClientCertificate()
+	if clientCert != nil || err != nil {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return clientCert, err
+		}
+	}
+	return tlsConfig
+}
+
 // Timeout returns the HTTP request timeout once the connection is established.
 func (c *Cluster) Timeout() time.Duration {
 	timeout := c.config.Get("core.timeout")
@@ -102,6 +232,56 @@ func (c *Cluster) SetTimeout(timeout time.Duration) {
 	c.config.Set("core.timeout", timeout.Seconds())
 }
 
+// TokenRefreshWindow returns the duration before the expiry of the ACS token during
+// which the CLI attempts to refresh it. It returns 0 when the window is not configured.
+func (c *Cluster) TokenRefreshWindow() time.Duration {
+	window := c.config.Get("core.token_refresh_window")
+	return time.Duration(cast.ToInt64(window)) * time.Second
+}
+
+// SetTokenRefreshWindow sets the duration before the expiry of the ACS token
+// during which the CLI attempts to refresh it.
+func (c *Cluster) SetTokenRefreshWindow(window time.Duration) {
+	c.config.Set("core.token_refresh_window", window.Seconds())
+}
+
+// Proxy returns the proxy URL for HTTP requests to the cluster.
+// When empty, the HTTP_PROXY and HTTPS_PROXY env vars are used.
+func (c *Cluster) Proxy() string {
+	return cast.ToString(c.config.Get(keyProxy))
+}
+
+// SetProxy sets the proxy URL for HTTP requests to the cluster.
+func (c *Cluster) SetProxy(proxy string) {
+	c.config.Set(keyProxy, proxy)
+}
+
+// MaxIdleConns returns the maximum number of idle HTTP connections to the cluster, 0 means the default.
+func (c *Cluster) MaxIdleConns() int {
+	return cast.ToInt(c.config.Get(keyMaxIdleConns))
+}
+
+// MaxIdleConnsPerHost returns the maximum number of idle HTTP connections per host, 0 means the default.
+func (c *Cluster) MaxIdleConnsPerHost() int {
+	return cast.ToInt(c.config.Get(keyMaxIdlePerHost))
+}
+
+// IdleConnTimeout returns the time after which idle HTTP connections are closed, 0 means the default.
+func (c *Cluster) IdleConnTimeout() time.Duration {
+	timeout := c.config.Get(keyIdleTimeout)
+	return time.Duration(cast.ToInt64(timeout)) * time.Second
+}
+
+// VersionCheck returns whether or not the CLI should warn when its version is known
+// to be incompatible with the cluster version. It defaults to true.
+func (c *Cluster) VersionCheck() bool {
+	versionCheck := c.config.Get(keyVersionCheck)
+	if versionCheck == nil {
+		return true
+	}
+	return cast.ToBool(versionCheck)
+}
+
 // ID returns the ID of the cluster.
 func (c *Cluster) ID() string {
 	if c.id != "" {
@@ -136,6 +316,23 @@ func (c *Cluster) Dir() string {
 	return filepath.Dir(c.Config().Path())
 }
 
+// LoadClientCertificate loads a TLS client certificate from a PEM encoded certificate and private key file.
+func LoadClientCertificate(fs afero.Fs, certPath, keyPath string) (*tls.Certificate, error) {
+	certPEM, err := afero.ReadFile(fs, certPath)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := afero.ReadFile(fs, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS client certificate '%s': %s", certPath, err)
+	}
+	return &cert, nil
+}
+
 // TLS holds the configuration for TLS clients.
 type TLS struct {
 	// Insecure specifies if server certificates should be accepted without verification.
@@ -1,7 +1,18 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -16,6 +27,9 @@ func TestGetters(t *testing.T) {
 	conf.Set("core.dcos_acs_token", "token_zj8Tb0vhQw")
 	conf.Set("core.ssl_verify", "/path/to/dcos_ca.crt")
 	conf.Set("core.timeout", 15)
+	conf.Set("core.token_refresh_window", 300)
+	conf.Set("core.proxy", "http://proxy.example.com:3128")
+	conf.Set("core.version_check", false)
 	conf.Set("cluster.name", "mr-cluster")
 
 	cluster := NewCluster(conf)
@@ -24,7 +38,13 @@ func TestGetters(t *testing.T) {
 	require.Equal(t, "token_zj8Tb0vhQw", cluster.ACSToken())
 	require.Equal(t, true, cluster.TLS().Insecure)
 	require.Equal(t, 15*time.Second, cluster.Timeout())
+	require.Equal(t, 5*time.Minute, cluster.TokenRefreshWindow())
+	require.Equal(t, "http://proxy.example.com:3128", cluster.Proxy())
+	require.False(t, cluster.VersionCheck())
 	require.Equal(t, "mr-cluster", cluster.Name())
+
+	// The version check is enabled by default.
+	require.True(t, NewCluster(Empty()).VersionCheck())
 }
 
 func TestSetters(t *testing.T) {
@@ -35,12 +55,16 @@ func TestSetters(t *testing.T) {
 	cluster.SetACSToken("token_XYZ")
 	cluster.SetTLS(TLS{})
 	cluster.SetTimeout(15 * time.Second)
+	cluster.SetTokenRefreshWindow(5 * time.Minute)
+	cluster.SetProxy("http://proxy.example.com:3128")
 	cluster.SetName("custom-cluster-name")
 
 	require.Equal(t, "https://dcos.example.com", conf.Get("core.dcos_url"))
 	require.Equal(t, "token_XYZ", conf.Get("core.dcos_acs_token"))
 	require.Equal(t, "true", conf.Get("core.ssl_verify"))
 	require.EqualValues(t, 15, conf.Get("core.timeout"))
+	require.EqualValues(t, 300, conf.Get("core.token_refresh_window"))
+	require.Equal(t, "http://proxy.example.com:3128", conf.Get("core.proxy"))
 	require.Equal(t, "custom-cluster-name", conf.Get("cluster.name"))
 }
 
@@ -149,3 +173,169 @@ NT4Sf75bbjkawxsKnddRgK2dILw//sQdOXmSJboaStNrHS5joczy
 	require.True(t, certPool.AppendCertsFromPEM(ca))
 	require.Equal(t, certPool, tlsConfig.RootCAs)
 }
+
+func TestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/client.crt", certPEM, 0644))
+	require.NoError(t, afero.WriteFile(fs, "/client.key", keyPEM, 0600))
+
+	cluster := NewCluster(New(Opts{Fs: fs}))
+
+	// No client certificate is configured.
+	cert, err := cluster.ClientCertificate()
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	require.Nil(t, cluster.TLSConfig().GetClientCertificate)
+
+	// Only the certificate is configured.
+	cluster.Config().Set("core.ssl_client_cert", "/client.crt")
+	_, err = cluster.ClientCertificate()
+	require.Equal(t, ErrIncompleteClientCertificate, err)
+
+	cluster.SetClientCertificate("/client.crt", "/client.key")
+	cert, err = cluster.ClientCertificate()
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	require.NotNil(t, cluster.TLSConfig().GetClientCertificate)
+
+	// The key doesn't match the certificate.
+	_, otherKeyPEM := generateCertificate(t)
+	require.NoError(t, afero.WriteFile(fs, "/client.key", otherKeyPEM, 0600))
+	_, err = cluster.ClientCertificate()
+	require.Error(t, err)
+}
+
+func TestTLSConfigWithClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	ts.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	ts.StartTLS()
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/client.crt", certPEM, 0644))
+	require.NoError(t, afero.WriteFile(fs, "/client.key", keyPEM, 0600))
+
+	cluster := NewCluster(New(Opts{Fs: fs}))
+	cluster.SetTLS(TLS{Insecure: true})
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cluster.TLSConfig()}}
+	_, err := client.Get(ts.URL)
+	require.Error(t, err)
+
+	cluster.SetClientCertificate("/client.crt", "/client.key")
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: cluster.TLSConfig()}}
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTLSVersionAndCipherSuites(t *testing.T) {
+	cluster := NewCluster(nil)
+
+	tlsConfig := cluster.TLSConfig()
+	require.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	require.Nil(t, tlsConfig.CipherSuites)
+
+	cluster.SetTLSMinVersion("1.1")
+	cluster.Config().Set("core.ssl_ciphers", "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+
+	tlsConfig = cluster.TLSConfig()
+	require.Equal(t, uint16(tls.VersionTLS11), tlsConfig.MinVersion)
+	require.Equal(t, []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	}, tlsConfig.CipherSuites)
+
+	// An invalid version falls back to the default.
+	cluster.SetTLSMinVersion("1.4")
+	tlsConfig = cluster.TLSConfig()
+	require.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+
+	// Invalid cipher suites make TLS handshakes fail.
+	cluster.Config().Set("core.ssl_ciphers", "TLS_NULL")
+	_, err := cluster.TLSCipherSuites()
+	require.EqualError(t, err, "invalid value for core.ssl_ciphers: unknown cipher suite 'TLS_NULL'")
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	ts.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	defer ts.Close()
+
+	tlsConfig = cluster.TLSConfig()
+	tlsConfig.InsecureSkipVerify = true
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	_, err = client.Get(ts.URL)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid value for core.ssl_ciphers: unknown cipher suite 'TLS_NULL'")
+}
+
+// generateCertificate generates a self-signed PEM encoded certificate and its private key.
+func generateCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dcos-cli"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
+
+func TestResolveACSToken(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	env := map[string]string{}
+	conf := New(Opts{
+		Fs: fs,
+		EnvLookup: func(key string) (string, bool) {
+			val, ok := env[key]
+			return val, ok
+		},
+	})
+	cluster := NewCluster(conf)
+	cluster.SetACSToken("stored-token")
+
+	token, err := cluster.ResolveACSToken()
+	require.NoError(t, err)
+	require.Equal(t, "stored-token", token)
+
+	// A missing token file is an error rather than silently falling back.
+	cluster.SetACSTokenFile("/token")
+	_, err = cluster.ResolveACSToken()
+	require.Error(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, "/token", []byte("file-token\n"), 0600))
+	token, err = cluster.ResolveACSToken()
+	require.NoError(t, err)
+	require.Equal(t, "file-token", token)
+
+	// The file is read again on each call so that the token can be rotated.
+	require.NoError(t, afero.WriteFile(fs, "/token", []byte("rotated-token"), 0600))
+	token, err = cluster.ResolveACSToken()
+	require.NoError(t, err)
+	require.Equal(t, "rotated-token", token)
+
+	env["DCOS_CLUSTER_TOKEN"] = "env-token"
+	token, err = cluster.ResolveACSToken()
+	require.NoError(t, err)
+	require.Equal(t, "env-token", token)
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dcos/dcos-cli/pkg/fsutil"
 	toml "github.com/pelletier/go-toml"
 	"github.com/spf13/afero"
 	"github.com/spf13/cast"
@@ -17,7 +18,13 @@ import (
 const (
 	keyURL            = "core.dcos_url"
 	keyACSToken       = "core.dcos_acs_token"
+	keyACSTokenFile   = "core.dcos_acs_token_file"
 	keyTLS            = "core.ssl_verify"
+	keyTLSClientCert  = "core.ssl_client_cert"
+	keyTLSClientKey   = "core.ssl_client_key"
+	keyTLSMinVersion  = "core.ssl_min_version"
+	keyTLSCiphers     = "core.ssl_ciphers"
+	keyTLSFingerprint = "core.ssl_cert_fingerprint"
 	keyTimeout        = "core.timeout"
 	keySSHUser        = "core.ssh_user"
 	keySSHProxyHost   = "core.ssh_proxy_ip"
@@ -25,6 +32,12 @@ const (
 	keyReporting      = "core.reporting"
 	keyMesosMasterURL = "core.mesos_master_url"
 	keyPrompLogin     = "core.prompt_login"
+	keyTokenRefresh   = "core.token_refresh_window"
+	keyProxy          = "core.proxy"
+	keyMaxIdleConns   = "core.http_max_idle_conns"
+	keyMaxIdlePerHost = "core.http_max_idle_conns_per_host"
+	keyIdleTimeout    = "core.http_idle_conn_timeout"
+	keyVersionCheck   = "core.version_check"
 	keyClusterName    = "cluster.name"
 )
 
@@ -32,6 +45,7 @@ const (
 const (
 	envURL      = "DCOS_URL"
 	envACSToken = "DCOS_ACS_TOKEN"
+	envToken    = "DCOS_CLUSTER_TOKEN"
 	envTLS      = "DCOS_SSL_VERIFY"
 	envTimeout  = "DCOS_TIMEOUT"
 )
@@ -39,6 +53,10 @@ const (
 // Errors related to the Config.
 var (
 	ErrNoConfigPath = errors.New("no path specified for the config")
+
+	ErrIncompleteClientCertificate = errors.New(
+		"both core.ssl_client_cert and core.ssl_client_key must be set for TLS client authentication",
+	)
 )
 
 // Opts are functional options for a Config.
@@ -64,6 +82,7 @@ type Config struct {
 	envWhitelist map[string]string
 	envLookup    func(key string) (string, bool)
 	fs           afero.Fs
+	defaults     *Config
 }
 
 // New creates a Config based on functional options.
@@ -146,32 +165,73 @@ func (c *Config) Fs() afero.Fs {
 	return c.fs
 }
 
-// Get returns a value from the Config using a key.
-func (c *Config) Get(key string) interface{} {
-	// Check if the given key is whitelisted as an env var.
+// SetDefaults sets the config to fallback to for keys which are not set in this config.
+// Cluster configs use the global config as defaults.
+func (c *Config) SetDefaults(defaults *Config) {
+	c.defaults = defaults
+}
+
+// EnvVar returns the environment variable overriding a config key, it is the key prefixed
+// with "DCOS_", in uppercase and with dots replaced by underscores (eg. DCOS_CORE_DCOS_URL).
+// Keys of the "cluster" section can't be overridden, as they identify the cluster.
+func EnvVar(key string) (string, bool) {
+	if !strings.Contains(key, ".") || strings.HasPrefix(key, "cluster.") {
+		return "", false
+	}
+	return "DCOS_" + strings.ToUpper(strings.Replace(key, ".", "_", -1)), true
+}
+
+// EnvSource returns the environment variable a key is read from, if it is set.
+// Whitelisted env vars (eg. DCOS_URL) take precedence over the generic ones.
+func (c *Config) EnvSource(key string) (string, bool) {
 	if envVar, ok := c.envWhitelist[key]; ok {
-		// If so, look-it up.
-		if envVal, ok := c.envLookup(envVar); ok {
-			return envVal
+		if _, ok := c.envLookup(envVar); ok {
+			return envVar, true
 		}
 	}
+	if envVar, ok := EnvVar(key); ok {
+		if _, ok := c.envLookup(envVar); ok {
+			return envVar, true
+		}
+	}
+	return "", false
+}
+
+// Get returns a value from the Config using a key.
+func (c *Config) Get(key string) interface{} {
+	// Env vars take precedence over the TOML tree, they are never persisted.
+	if envVar, ok := c.EnvSource(key); ok {
+		envVal, _ := c.envLookup(envVar)
+		return envVal
+	}
 
 	// Fallback to the TOML tree if present.
 	switch node := c.tree.Get(key).(type) {
 	case *toml.Tree, []*toml.Tree:
 		return nil
+	case nil:
+		// Fallback to the defaults if any.
+		if c.defaults != nil {
+			return c.defaults.Get(key)
+		}
+		return nil
 	default:
 		return node
 	}
 }
 
+// Contains indicates whether or not a key is set in the TOML document of the Config.
+func (c *Config) Contains(key string) bool {
+	return c.tree.Has(key)
+}
+
 // Set sets a key in the store.
 func (c *Config) Set(key string, val interface{}) {
-	switch key {
-	case keyTimeout:
+	switch knownKeys[key].Type {
+	case TypeDuration, TypeInt:
 		// go-toml requires int64
 		val = cast.ToInt64(val)
-	case keyPagination, keyReporting, keyPrompLogin:
+	case TypeBool:
 		val = cast.ToBool(val)
 	default:
 		val = cast.ToString(val)
@@ -215,37 +275,68 @@ func (c *Config) Persist() error {
 	if _, err := c.tree.WriteTo(&buf); err != nil {
 		return err
 	}
-	return afero.WriteFile(c.fs, c.path, buf.Bytes(), 0600)
+
+	// Write the config to a temporary file first and then rename it, this
+	// prevents concurrent CLI invocations from reading a partially written file.
+	return fsutil.WriteFileAtomic(c.fs, c.path, buf.Bytes(), 0600)
 }
 
-// Keys returns all the keys in the Config.
+// Update performs a read-modify-write of the config on disk. It acquires an exclusive lock
+// for the config path, reloads the config from disk, applies the given function to it and
+// persists it. This prevents concurrent CLI invocations from overwriting each other's changes.
+func (c *Config) Update(fn func(conf *Config)) error {
+	if c.path == "" {
+		return ErrNoConfigPath
+	}
+	unlock, err := fsutil.Lock(c.fs, c.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := c.LoadPath(c.path); err != nil {
+		return err
+	}
+	fn(c)
+	return c.Persist()
+}
+
+// Keys returns all the keys in the Config. As env vars can't be enumerated, keys only set
+// through the environment are listed when they are known keys.
 func (c *Config) Keys() []string {
 	var keys []string
-	for key, envVar := range c.envWhitelist {
-		if _, ok := c.envLookup(envVar); ok {
+	for key := range c.envWhitelist {
+		if _, ok := c.EnvSource(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	for key := range knownKeys {
+		if _, ok := c.EnvSource(key); ok {
 			keys = append(keys, key)
 		}
 	}
 
 	searchKeys(c.tree, &keys, []string{})
-	keysLen := len(keys)
-	if keysLen < 2 {
+	if c.defaults != nil {
+		keys = append(keys, c.defaults.Keys()...)
+	}
+	if len(keys) < 2 {
 		// Less than 2 keys, no need to sort or remove duplicates.
 		return keys
 	}
 
 	sort.Strings(keys)
 
-	// Remove duplicates, this happens when there is both an env var and a TOML key.
-	// Keys are already sorted so this is done by comparing each key with the next one
-	// and removing the latter if it's the same.
-	for i := 1; i < keysLen; i++ {
-		if keys[i] == keys[i-1] {
-			keys = append(keys[:i], keys[i+1:]...)
-			keysLen--
+	// Remove duplicates, this happens when a key is set in several sources (env var, TOML
+	// document, defaults). Keys are already sorted so this is done by comparing each key
+	// with the previous one and only keeping it if it's different.
+	uniqueKeys := keys[:1]
+	for _, key := range keys[1:] {
+		if key != uniqueKeys[len(uniqueKeys)-1] {
+			uniqueKeys = append(uniqueKeys, key)
 		}
 	}
-	return keys
+	return uniqueKeys
 }
 
 // searchKeys walks recursively through a toml.Tree and appends the full path to each leaf into keys.
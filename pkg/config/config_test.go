@@ -1,13 +1,18 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/pelletier/go-toml"
 	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -155,6 +160,65 @@ func TestGet(t *testing.T) {
 	require.Equal(t, "mr-cluster", val)
 }
 
+func TestGetGenericEnvVar(t *testing.T) {
+	store := New(Opts{
+		EnvLookup: func(key string) (string, bool) {
+			switch key {
+			case "DCOS_CORE_DCOS_URL":
+				return "https://dcos-generic.example.com", true
+			case "DCOS_CORE_SSL_VERIFY":
+				return "/path/to/ca.crt", true
+			case "DCOS_SSL_VERIFY":
+				return "false", true
+			case "DCOS_MARATHON_URL":
+				return "https://marathon.example.com", true
+			case "DCOS_CLUSTER_NAME":
+				return "dummy", true
+			}
+			return "", false
+		},
+	})
+	store.Set(keyURL, "https://dcos.example.com")
+	store.Set(keyClusterName, "mr-cluster")
+
+	require.Equal(t, "https://dcos-generic.example.com", store.Get(keyURL))
+	envVar, ok := store.EnvSource(keyURL)
+	require.True(t, ok)
+	require.Equal(t, "DCOS_CORE_DCOS_URL", envVar)
+
+	// Whitelisted env vars take precedence over generic ones.
+	require.Equal(t, "false", store.Get(keyTLS))
+	envVar, _ = store.EnvSource(keyTLS)
+	require.Equal(t, "DCOS_SSL_VERIFY", envVar)
+
+	// Keys unknown to the CLI can also be overridden.
+	require.Equal(t, "https://marathon.example.com", store.Get("marathon.url"))
+
+	// The cluster section can't be overridden.
+	require.Equal(t, "mr-cluster", store.Get(keyClusterName))
+	_, ok = store.EnvSource(keyClusterName)
+	require.False(t, ok)
+
+	// The override is never persisted.
+	require.Equal(t, "https://dcos.example.com", store.tree.Get(keyURL))
+}
+
+func TestEnvVar(t *testing.T) {
+	envVar, ok := EnvVar("core.dcos_url")
+	require.True(t, ok)
+	require.Equal(t, "DCOS_CORE_DCOS_URL", envVar)
+
+	envVar, ok = EnvVar("package.cosmos.url")
+	require.True(t, ok)
+	require.Equal(t, "DCOS_PACKAGE_COSMOS_URL", envVar)
+
+	_, ok = EnvVar("core")
+	require.False(t, ok)
+
+	_, ok = EnvVar("cluster.name")
+	require.False(t, ok)
+}
+
 func TestSetAndUnset(t *testing.T) {
 	store := New(Opts{})
 
@@ -231,7 +295,8 @@ func TestPersist(t *testing.T) {
 
 	require.NoError(t, store.Persist())
 
-	contents, err := ioutil.ReadAll(f)
+	// The config file is atomically replaced, it must be re-opened to read the new contents.
+	contents, err := afero.ReadFile(fs, f.Name())
 	require.NoError(t, err)
 
 	expectedTOML := []byte(`
@@ -240,3 +305,66 @@ func TestPersist(t *testing.T) {
 `)
 	require.Equal(t, expectedTOML, contents)
 }
+
+func TestPersistInt(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	store := New(Opts{
+		Fs: fs,
+	})
+	store.SetPath("/config.toml")
+	store.Set(keyMaxIdleConns, "20")
+	require.NoError(t, store.Persist())
+
+	contents, err := afero.ReadFile(fs, "/config.toml")
+	require.NoError(t, err)
+
+	expectedTOML := []byte(`
+[core]
+  http_max_idle_conns = 20
+`)
+	require.Equal(t, expectedTOML, contents)
+
+	store = New(Opts{
+		Fs: fs,
+	})
+	require.NoError(t, store.LoadPath("/config.toml"))
+	require.Equal(t, 20, NewCluster(store).MaxIdleConns())
+}
+
+func TestUpdateWithoutPath(t *testing.T) {
+	store := New(Opts{})
+	require.Equal(t, ErrNoConfigPath, store.Update(func(conf *Config) {}))
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dcos-cli-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "dcos.toml")
+	fs := afero.NewOsFs()
+
+	// Each goroutine uses its own config, as separate CLI invocations would.
+	const count = 50
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store := New(Opts{Fs: fs})
+			if !assert.NoError(t, store.LoadPath(path)) {
+				return
+			}
+			assert.NoError(t, store.Update(func(conf *Config) {
+				conf.Set(fmt.Sprintf("test.key_%d", i), i)
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	store := New(Opts{Fs: fs})
+	require.NoError(t, store.LoadPath(path))
+	for i := 0; i < count; i++ {
+		require.Equal(t, strconv.Itoa(i), store.Get(fmt.Sprintf("test.key_%d", i)))
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// EnvDir is the env var for the root directory of the DC/OS CLI.
+const EnvDir = "DCOS_DIR"
+
+// Dir returns the root directory of the DC/OS CLI, where cluster configs, plugins and caches are stored.
+// In order of precedence, it is the given dir (eg. from the --config-dir flag), the DCOS_DIR env var,
+// or `~/.dcos`.
+func Dir(dir string, envLookup func(key string) (string, bool)) (string, error) {
+	if dir == "" && envLookup != nil {
+		dir, _ = envLookup(EnvDir)
+	}
+	if dir != "" {
+		return dir, nil
+	}
+
+	// We use github.com/mitchellh/go-homedir as os/user doesn't work well with cross-compilation.
+	// In the future this could instead be done through the "osusergo" build tag (added in Go 1.11).
+	// See https://tip.golang.org/doc/go1.11#os/user.
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".dcos"), nil
+}
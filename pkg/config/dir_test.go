@@ -0,0 +1,37 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir(t *testing.T) {
+	envLookup := func(key string) (string, bool) {
+		if key == "DCOS_DIR" {
+			return "/tmp/dcos-staging", true
+		}
+		return "", false
+	}
+	noEnv := func(key string) (string, bool) {
+		return "", false
+	}
+
+	// The given dir (eg. --config-dir) takes precedence over DCOS_DIR.
+	dir, err := Dir("/tmp/dcos-prod", envLookup)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/dcos-prod", dir)
+
+	dir, err = Dir("", envLookup)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/dcos-staging", dir)
+
+	homeDir, err := homedir.Dir()
+	require.NoError(t, err)
+
+	dir, err = Dir("", noEnv)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(homeDir, ".dcos"), dir)
+}
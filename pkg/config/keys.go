@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// KeyType is the type of the value of a config key.
+type KeyType string
+
+// Types of config values.
+const (
+	// TypeString is for free-form string values.
+	TypeString KeyType = "string"
+
+	// TypeBool is for boolean values (eg. "true", "false", "1", "0").
+	TypeBool KeyType = "bool"
+
+	// TypeURL is for absolute URLs with a scheme and a host (eg. "https://dcos.example.com").
+	TypeURL KeyType = "url"
+
+	// TypeDuration is for durations, expressed as a number of seconds.
+	TypeDuration KeyType = "duration"
+
+	// TypeInt is for non-negative integers.
+	TypeInt KeyType = "int"
+
+	// TypeTLSVersion is for TLS versions (eg. "1.2", "1.3").
+	TypeTLSVersion KeyType = "tls-version"
+
+	// TypeCipherSuites is for comma-separated lists of TLS cipher suite names.
+	TypeCipherSuites KeyType = "cipher-suites"
+
+	// TypeFingerprint is for SHA-256 fingerprints, as hex bytes optionally separated by colons.
+	TypeFingerprint KeyType = "sha256-fingerprint"
+)
+
+// Key is a config key known by the DC/OS CLI.
+type Key struct {
+	Name        string  `json:"name"`
+	Type        KeyType `json:"type"`
+	Description string  `json:"description"`
+}
+
+// knownKeys is the registry of config keys known by the DC/OS CLI.
+var knownKeys = map[string]Key{
+	keyURL: {
+		Type:        TypeURL,
+		Description: "The public master URL of the DC/OS cluster.",
+	},
+	keyACSToken: {
+		Type:        TypeString,
+		Description: "The authentication token for the current user.",
+	},
+	keyACSTokenFile: {
+		Type:        TypeString,
+		Description: "The path to a file containing the authentication token, it takes precedence over core.dcos_acs_token.",
+	},
+	keyTLS: {
+		Type:        TypeString,
+		Description: "Whether to verify TLS certificates (true or false), or the path to a CA bundle.",
+	},
+	keyTLSClientCert: {
+		Type:        TypeString,
+		Description: "The path to the certificate used for TLS client authentication.",
+	},
+	keyTLSClientKey: {
+		Type:        TypeString,
+		Description: "The path to the private key used for TLS client authentication.",
+	},
+	keyTLSMinVersion: {
+		Type:        TypeTLSVersion,
+		Description: "The minimum TLS version for connections to the cluster (1.0 to 1.3), defaults to 1.2.",
+	},
+	keyTLSCiphers: {
+		Type:        TypeCipherSuites,
+		Description: "The comma-separated list of allowed TLS cipher suites, Go defaults are used when empty.",
+	},
+	keyTLSFingerprint: {
+		Type:        TypeFingerprint,
+		Description: "The SHA-256 fingerprint of the server certificate, connections to other certificates are rejected.",
+	},
+	keyTimeout: {
+		Type:        TypeDuration,
+		Description: "The timeout of HTTP requests, in seconds.",
+	},
+	keySSHUser: {
+		Type:        TypeString,
+		Description: "The user used when connecting to cluster nodes through SSH.",
+	},
+	keySSHProxyHost: {
+		Type:        TypeString,
+		Description: "The host used as a proxy when connecting to cluster nodes through SSH.",
+	},
+	keyPagination: {
+		Type:        TypeBool,
+		Description: "Whether to paginate long outputs.",
+	},
+	keyReporting: {
+		Type:        TypeBool,
+		Description: "Whether to report usage and errors.",
+	},
+	keyMesosMasterURL: {
+		Type:        TypeURL,
+		Description: "The URL of the Mesos master, when it is not reachable through the DC/OS URL.",
+	},
+	keyPrompLogin: {
+		Type:        TypeBool,
+		Description: "Whether to prompt for a login when the authentication token is expired.",
+	},
+	keyTokenRefresh: {
+		Type:        TypeDuration,
+		Description: "The time before the expiry of the authentication token when it gets refreshed, in seconds.",
+	},
+	keyProxy: {
+		Type:        TypeURL,
+		Description: "The URL of the proxy for HTTP requests to the cluster.",
+	},
+	keyMaxIdleConns: {
+		Type:        TypeInt,
+		Description: "The maximum number of idle HTTP connections kept open to the cluster.",
+	},
+	keyMaxIdlePerHost: {
+		Type:        TypeInt,
+		Description: "The maximum number of idle HTTP connections kept open per host of the cluster.",
+	},
+	keyIdleTimeout: {
+		Type:        TypeDuration,
+		Description: "The time after which an idle HTTP connection is closed, in seconds.",
+	},
+	keyVersionCheck: {
+		Type:        TypeBool,
+		Description: "Whether to warn when the CLI version is known to be incompatible with the cluster version.",
+	},
+	keyClusterName: {
+		Type:        TypeString,
+		Description: "The name of the cluster.",
+	},
+}
+
+// requiredKeys are the config keys without which commands targeting a cluster fail.
+var requiredKeys = map[string]bool{
+	keyURL: true,
+}
+
+// clusterKeys are the config keys specific to a cluster, they can't be set in the global config.
+var clusterKeys = map[string]bool{
+	keyURL:            true,
+	keyACSToken:       true,
+	keyACSTokenFile:   true,
+	keyTLSFingerprint: true,
+	keyClusterName:    true,
+}
+
+// KnownKeys returns the config keys known by the DC/OS CLI, sorted by name.
+func KnownKeys() []Key {
+	keys := make([]Key, 0, len(knownKeys))
+	for name, key := range knownKeys {
+		key.Name = name
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// LookupKey returns the known config key for a given name.
+func LookupKey(name string) (Key, bool) {
+	key, ok := knownKeys[name]
+	key.Name = name
+	return key, ok
+}
+
+// IsRequired indicates whether or not a config key is required for commands targeting a cluster.
+func IsRequired(name string) bool {
+	return requiredKeys[name]
+}
+
+// ValidateGlobal checks that a config key can be set in the global config.
+func ValidateGlobal(name string) error {
+	if clusterKeys[name] {
+		return fmt.Errorf("%s is specific to a cluster and can't be set globally", name)
+	}
+	return nil
+}
+
+// Validate checks that a value is valid for a given config key.
+// Unknown keys are rejected, with a suggestion when the name is close to a known key.
+func Validate(name, val string) error {
+	key, ok := LookupKey(name)
+	if !ok {
+		if suggestion := suggestKey(name); suggestion != "" {
+			return fmt.Errorf("unknown config key '%s', did you mean '%s'?", name, suggestion)
+		}
+		return fmt.Errorf("unknown config key '%s', run `dcos config keys` to list known keys", name)
+	}
+	return key.Validate(val)
+}
+
+// Validate checks that a value is valid for the key type.
+func (k Key) Validate(val string) error {
+	switch k.Type {
+	case TypeBool:
+		if _, err := strconv.ParseBool(val); err != nil {
+			return fmt.Errorf("invalid value '%s' for %s, expected true or false", val, k.Name)
+		}
+	case TypeDuration:
+		if seconds, err := strconv.ParseInt(val, 10, 64); err != nil || seconds < 0 {
+			return fmt.Errorf("invalid value '%s' for %s, expected a number of seconds", val, k.Name)
+		}
+	case TypeInt:
+		if n, err := strconv.ParseInt(val, 10, 64); err != nil || n < 0 {
+			return fmt.Errorf("invalid value '%s' for %s, expected a non-negative integer", val, k.Name)
+		}
+	case TypeURL:
+		if u, err := url.Parse(val); err != nil || u.Scheme == "" || u.Host == "" {
+			if bracketIPv6(val) != val {
+				return fmt.Errorf("invalid value '%s' for %s, IPv6 addresses must be enclosed in brackets (eg. https://[2001:db8::1])", val, k.Name)
+			}
+			return fmt.Errorf("invalid value '%s' for %s, expected a URL such as https://example.com", val, k.Name)
+		}
+	case TypeTLSVersion:
+		if _, err := ParseTLSVersion(val); err != nil {
+			return fmt.Errorf("invalid value for %s: %s", k.Name, err)
+		}
+	case TypeCipherSuites:
+		if _, err := ParseCipherSuites(val); err != nil {
+			return fmt.Errorf("invalid value for %s: %s", k.Name, err)
+		}
+	case TypeFingerprint:
+		if _, err := ParseCertFingerprint(val); err != nil {
+			return fmt.Errorf("invalid value for %s: %s", k.Name, err)
+		}
+	}
+	return nil
+}
+
+// suggestKey returns the known key which is the closest to a given name, if it is only a few typos away.
+func suggestKey(name string) (suggestion string) {
+	const maxDistance = 2
+
+	minDistance := maxDistance + 1
+	for _, key := range KnownKeys() {
+		if distance := levenshtein(name, key.Name); distance < minDistance {
+			minDistance = distance
+			suggestion = key.Name
+		}
+	}
+	if minDistance > maxDistance {
+		return ""
+	}
+	return suggestion
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	prevRow := make([]int, len(b)+1)
+	for j := range prevRow {
+		prevRow[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		row := make([]int, len(b)+1)
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = minInt(prevRow[j]+1, minInt(row[j-1]+1, prevRow[j-1]+cost))
+		}
+		prevRow = row
+	}
+	return prevRow[len(b)]
+}
+
+// minInt returns the smallest of two integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
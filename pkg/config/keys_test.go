@@ -0,0 +1,93 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownKeys(t *testing.T) {
+	keys := KnownKeys()
+	require.Len(t, keys, len(knownKeys))
+
+	for i, key := range keys {
+		require.NotEmpty(t, key.Name)
+		require.NotEmpty(t, key.Description, key.Name)
+		if i > 0 {
+			require.True(t, keys[i-1].Name < key.Name)
+		}
+	}
+
+	key, ok := LookupKey("core.dcos_url")
+	require.True(t, ok)
+	require.Equal(t, Key{Name: "core.dcos_url", Type: TypeURL, Description: knownKeys["core.dcos_url"].Description}, key)
+
+	_, ok = LookupKey("core.unknown")
+	require.False(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		key string
+		val string
+		err string
+	}{
+		{"core.dcos_url", "https://dcos.example.com", ""},
+		{"core.dcos_url", "dcos.example.com", "invalid value 'dcos.example.com' for core.dcos_url, expected a URL such as https://example.com"},
+		{"core.dcos_url", "https://[2001:db8::1]:8443", ""},
+		{"core.dcos_url", "https://2001:db8::1", "invalid value 'https://2001:db8::1' for core.dcos_url, IPv6 addresses must be enclosed in brackets (eg. https://[2001:db8::1])"},
+		{"core.timeout", "15", ""},
+		{"core.timeout", "15s", "invalid value '15s' for core.timeout, expected a number of seconds"},
+		{"core.timeout", "-1", "invalid value '-1' for core.timeout, expected a number of seconds"},
+		{"core.http_max_idle_conns", "64", ""},
+		{"core.http_max_idle_conns", "-1", "invalid value '-1' for core.http_max_idle_conns, expected a non-negative integer"},
+		{"core.reporting", "false", ""},
+		{"core.reporting", "nope", "invalid value 'nope' for core.reporting, expected true or false"},
+		{"core.ssl_verify", "/path/to/ca.crt", ""},
+		{"cluster.name", "prod", ""},
+		{"core.ssl_min_version", "1.3", ""},
+		{"core.ssl_min_version", "1.4", "invalid value for core.ssl_min_version: unknown TLS version '1.4', expected one of 1.0, 1.1, 1.2, 1.3"},
+		{"core.ssl_ciphers", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", ""},
+		{"core.ssl_ciphers", "TLS_NULL", "invalid value for core.ssl_ciphers: unknown cipher suite 'TLS_NULL'"},
+		{"core.dcos_ur", "https://dcos.example.com", "unknown config key 'core.dcos_ur', did you mean 'core.dcos_url'?"},
+		{"marathon.url", "https://dcos.example.com", "unknown config key 'marathon.url', run `dcos config keys` to list known keys"},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.key, tc.val)
+		if tc.err == "" {
+			require.NoError(t, err, tc.key)
+		} else {
+			require.EqualError(t, err, tc.err)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	require.Equal(t, 0, levenshtein("core.timeout", "core.timeout"))
+	require.Equal(t, 1, levenshtein("core.dcos_ur", "core.dcos_url"))
+	require.Equal(t, 2, levenshtein("core.timoeut", "core.timeout"))
+	require.Equal(t, 3, levenshtein("", "abc"))
+}
+
+func TestValidateGlobal(t *testing.T) {
+	require.NoError(t, ValidateGlobal("core.timeout"))
+	require.EqualError(t, ValidateGlobal("core.dcos_url"), "core.dcos_url is specific to a cluster and can't be set globally")
+	require.True(t, IsRequired("core.dcos_url"))
+	require.False(t, IsRequired("core.timeout"))
+}
+
+func TestParseCertFingerprint(t *testing.T) {
+	fingerprint := CertFingerprint([]byte("cert"))
+	parsed, err := ParseCertFingerprint(fingerprint)
+	require.NoError(t, err)
+
+	// Colons are optional and the hex encoding is case-insensitive.
+	parsedLower, err := ParseCertFingerprint(strings.ToLower(strings.Replace(fingerprint, ":", "", -1)))
+	require.NoError(t, err)
+	require.Equal(t, parsed, parsedLower)
+
+	require.Error(t, Validate("core.ssl_cert_fingerprint", "AB:CD"))
+	require.NoError(t, Validate("core.ssl_cert_fingerprint", fingerprint))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,6 +37,12 @@ var ErrConfigNotFound = errors.New("no match found")
 // ErrTooManyConfigs means that more than one config has been found for a given search.
 var ErrTooManyConfigs = errors.New("multiple matches found")
 
+// ErrNoClusterAttached means that there is no current config as none of the configured clusters is attached.
+var ErrNoClusterAttached = errors.New("no cluster is attached")
+
+// ErrTooManyClustersAttached means that there is no current config as several clusters are attached.
+var ErrTooManyClustersAttached = errors.New("multiple clusters are attached")
+
 // NewManager creates a new config manager.
 func NewManager(opts ManagerOpts) *Manager {
 	if opts.Fs == nil {
@@ -73,13 +80,13 @@ func (m *Manager) Current() (*Config, error) {
 		attachedFile := m.attachedFilePath(config)
 		if m.fileExists(attachedFile) {
 			if currentConfig != nil {
-				return nil, errors.New("multiple clusters are attached")
+				return nil, ErrTooManyClustersAttached
 			}
 			currentConfig = config
 		}
 	}
 	if currentConfig == nil {
-		return nil, errors.New("no cluster is attached")
+		return nil, ErrNoClusterAttached
 	}
 	return currentConfig, nil
 }
@@ -125,9 +132,13 @@ func (m *Manager) All() (configs []*Config) {
 		return
 	}
 
+	// An invalid global config is ignored rather than making all cluster configs unusable.
+	global, _ := m.loadGlobal()
+
 	for _, configDirInfo := range configsDirInfo {
 		if configDirInfo.IsDir() {
 			config := m.newConfig()
+			config.SetDefaults(global)
 			configPath := filepath.Join(configsDir.Name(), configDirInfo.Name(), "dcos.toml")
 			if err := config.LoadPath(configPath); err == nil {
 				configs = append(configs, config)
@@ -138,9 +149,35 @@ func (m *Manager) All() (configs []*Config) {
 	return
 }
 
+// Global returns the global config, located at `global.toml` in the root directory.
+// Its values apply to all clusters, unless they are set in the config of a cluster.
+func (m *Manager) Global() (*Config, error) {
+	if err := m.fs.MkdirAll(m.dir, 0755); err != nil {
+		return nil, err
+	}
+	return m.loadGlobal()
+}
+
+// loadGlobal loads the global config, it is empty when the file doesn't exist.
+func (m *Manager) loadGlobal() (*Config, error) {
+	global := m.newConfig()
+	globalPath := filepath.Join(m.dir, "global.toml")
+	if m.fileExists(globalPath) {
+		if err := global.LoadPath(globalPath); err != nil {
+			return nil, err
+		}
+	}
+	global.SetPath(globalPath)
+	return global, nil
+}
+
 // Save saves a config to the disk under the given cluster ID folder.
 func (m *Manager) Save(config *Config, id string, caBundle []byte) error {
-	configDir := filepath.Join(m.dir, "clusters", id)
+	return m.save(config, filepath.Join(m.dir, "clusters", id), caBundle)
+}
+
+// save persists a config and its CA bundle, if any, in a given directory.
+func (m *Manager) save(config *Config, configDir string, caBundle []byte) error {
 	if err := m.fs.MkdirAll(configDir, 0755); err != nil {
 		return err
 	}
@@ -152,10 +189,35 @@ func (m *Manager) Save(config *Config, id string, caBundle []byte) error {
 		}
 		config.Set(keyTLS, caBundlePath)
 	}
+	// The config might have been created with the default OS filesystem (eg. during a setup),
+	// it is persisted alongside the CA bundle, on the filesystem of the manager.
+	config.fs = m.fs
 	config.SetPath(filepath.Join(configDir, "dcos.toml"))
 	return config.Persist()
 }
 
+// Rename sets a new name for the cluster of a given config and persists it. It fails when the
+// name is already used as the name or ID of another configured cluster, as lookups by name would
+// then become ambiguous.
+func (m *Manager) Rename(conf *Config, name string) error {
+	if name == "" {
+		return errors.New("the cluster name cannot be empty")
+	}
+	for _, c := range m.All() {
+		if c.Path() == conf.Path() {
+			continue
+		}
+		clusterID := filepath.Base(filepath.Dir(c.Path()))
+		clusterName, _ := c.Get(keyClusterName).(string)
+		if name == clusterName || name == clusterID {
+			return fmt.Errorf("the name '%s' is already used by cluster %s", name, clusterID)
+		}
+	}
+	return conf.Update(func(conf *Config) {
+		conf.Set(keyClusterName, name)
+	})
+}
+
 // Attach sets a given config as the current one. This is done by adding an `attached`
 // file next to it. If another config is already attached, the file gets moved.
 func (m *Manager) Attach(config *Config) error {
@@ -121,3 +121,67 @@ func TestAttach(t *testing.T) {
 	require.NoError(t, manager.Attach(conf))
 	require.True(t, manager.fileExists(attachedFilePath))
 }
+
+func TestRename(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for id, name := range map[string]string{"79893270": "dev", "97193161": "prod"} {
+		afero.WriteFile(fs, filepath.Join(".dcos", "clusters", id, "dcos.toml"), []byte(`
+[cluster]
+name = "`+name+`"
+`), 0600)
+	}
+
+	manager := NewManager(ManagerOpts{
+		Dir: ".dcos",
+		Fs:  fs,
+	})
+
+	conf, err := manager.Find("dev", true)
+	require.NoError(t, err)
+
+	require.Error(t, manager.Rename(conf, ""))
+	require.Error(t, manager.Rename(conf, "prod"))
+	require.Error(t, manager.Rename(conf, "97193161"))
+
+	// Renaming a cluster to its own name is allowed.
+	require.NoError(t, manager.Rename(conf, "dev"))
+
+	require.NoError(t, manager.Rename(conf, "staging"))
+	conf, err = manager.Find("staging", true)
+	require.NoError(t, err)
+	require.Equal(t, "79893270", filepath.Base(filepath.Dir(conf.Path())))
+}
+
+func TestGlobal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, filepath.Join(".dcos", "clusters", "79893270", "dcos.toml"), []byte(`
+[core]
+dcos_url = "https://dcos.example.com"
+timeout = 10
+`), 0600)
+
+	manager := NewManager(ManagerOpts{
+		Dir: ".dcos",
+		Fs:  fs,
+	})
+
+	global, err := manager.Global()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(".dcos", "global.toml"), global.Path())
+	require.NoError(t, global.Update(func(conf *Config) {
+		conf.Set("core.timeout", 30)
+		conf.Set("core.ssh_user", "centos")
+	}))
+
+	conf, err := manager.Find("79893270", true)
+	require.NoError(t, err)
+
+	// Values of the cluster config take precedence over the global config.
+	require.EqualValues(t, 10, conf.Get("core.timeout"))
+	require.Equal(t, "centos", conf.Get("core.ssh_user"))
+	require.Equal(t, []string{"core.dcos_url", "core.ssh_user", "core.timeout"}, conf.Keys())
+
+	// Global values are not persisted to the cluster config.
+	require.True(t, conf.Contains("core.timeout"))
+	require.False(t, conf.Contains("core.ssh_user"))
+}
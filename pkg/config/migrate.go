@@ -0,0 +1,66 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"path/filepath"
+)
+
+// legacyConfigFile is the config file of CLIs which only supported a single cluster,
+// it is located at the root of the DC/OS directory.
+const legacyConfigFile = "dcos.toml"
+
+// LegacyConfigPath returns the path to the legacy single-cluster config file.
+func (m *Manager) LegacyConfigPath() string {
+	return filepath.Join(m.dir, legacyConfigFile)
+}
+
+// MigrateLegacy converts the legacy single-cluster config file into the config of an attached
+// cluster, the legacy file is then moved aside with a ".bak" extension. It returns the migrated
+// cluster, or nil when there is nothing to migrate. Nothing is done when there is no legacy file,
+// when it has no cluster URL, or when clusters are already configured.
+//
+// The cluster ID is resolved through clusterID, usually from the cluster metadata. When it is
+// nil or fails, the ID is derived from the cluster URL.
+func (m *Manager) MigrateLegacy(clusterID func(cluster *Cluster) (string, error)) (*Cluster, error) {
+	legacyPath := m.LegacyConfigPath()
+	if !m.fileExists(legacyPath) || len(m.All()) > 0 {
+		return nil, nil
+	}
+
+	conf := m.newConfig()
+	if err := conf.LoadPath(legacyPath); err != nil {
+		return nil, err
+	}
+	cluster := NewCluster(conf)
+	if cluster.URL() == "" {
+		return nil, nil
+	}
+
+	var id string
+	if clusterID != nil {
+		id, _ = clusterID(cluster)
+	}
+	if id == "" || id != filepath.Base(id) {
+		id = fmt.Sprintf("%x", sha256.Sum256([]byte(cluster.URL())))[:32]
+	}
+	if cluster.Name() == "" {
+		name := cluster.URL()
+		if u, err := url.Parse(cluster.URL()); err == nil && u.Hostname() != "" {
+			name = u.Hostname()
+		}
+		cluster.SetName(name)
+	}
+
+	if err := m.Save(conf, id, nil); err != nil {
+		return nil, err
+	}
+	if err := m.Attach(conf); err != nil {
+		return nil, err
+	}
+	if err := m.fs.Rename(legacyPath, legacyPath+".bak"); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
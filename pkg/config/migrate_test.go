@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyConfig = `
+[core]
+dcos_url = "https://dcos.example.com"
+dcos_acs_token = "legacy-token"
+ssl_verify = "false"
+`
+
+func TestMigrateLegacy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/dcos/dcos.toml", []byte(legacyConfig), 0600))
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	cluster, err := manager.MigrateLegacy(func(c *Cluster) (string, error) {
+		require.Equal(t, "https://dcos.example.com", c.URL())
+		return "79893270-3ccd-4c17-a2ce-32d8eb1b1763", nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cluster)
+	require.Equal(t, "79893270-3ccd-4c17-a2ce-32d8eb1b1763", cluster.ID())
+	require.Equal(t, "dcos.example.com", cluster.Name())
+
+	exists, err := afero.Exists(fs, "/dcos/dcos.toml")
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = afero.Exists(fs, "/dcos/dcos.toml.bak")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	current, err := manager.Current()
+	require.NoError(t, err)
+	currentCluster := NewCluster(current)
+	require.Equal(t, "/dcos/clusters/79893270-3ccd-4c17-a2ce-32d8eb1b1763/dcos.toml", current.Path())
+	require.Equal(t, "legacy-token", currentCluster.ACSToken())
+	require.True(t, currentCluster.TLS().Insecure)
+
+	// Running the migration again is a no-op.
+	cluster, err = manager.MigrateLegacy(nil)
+	require.NoError(t, err)
+	require.Nil(t, cluster)
+	require.Len(t, manager.All(), 1)
+}
+
+func TestMigrateLegacyWithoutMetadata(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/dcos/dcos.toml", []byte(legacyConfig), 0600))
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	cluster, err := manager.MigrateLegacy(func(c *Cluster) (string, error) {
+		return "", errors.New("unreachable")
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cluster)
+	require.Len(t, cluster.ID(), 32)
+
+	// The ID is derived from the URL, it is the same for every migration of a given cluster.
+	otherFs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(otherFs, "/dcos/dcos.toml", []byte(legacyConfig), 0600))
+	otherCluster, err := NewManager(ManagerOpts{Fs: otherFs, Dir: "/dcos"}).MigrateLegacy(nil)
+	require.NoError(t, err)
+	require.Equal(t, cluster.ID(), otherCluster.ID())
+}
+
+func TestMigrateLegacySkipped(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	manager := NewManager(ManagerOpts{Fs: fs, Dir: "/dcos"})
+
+	// There is no legacy config.
+	cluster, err := manager.MigrateLegacy(nil)
+	require.NoError(t, err)
+	require.Nil(t, cluster)
+
+	// Clusters are already configured with the new layout, the legacy file is left untouched.
+	require.NoError(t, afero.WriteFile(fs, "/dcos/dcos.toml", []byte(legacyConfig), 0600))
+	require.NoError(t, manager.Save(New(Opts{Fs: fs}), "cluster-1", nil))
+
+	cluster, err = manager.MigrateLegacy(nil)
+	require.NoError(t, err)
+	require.Nil(t, cluster)
+
+	exists, err := afero.Exists(fs, "/dcos/dcos.toml")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
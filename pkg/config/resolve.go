@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClusterNotFoundError is returned when no configured cluster matches a query.
+type ClusterNotFoundError struct {
+	Query string
+}
+
+// Error implements the error interface.
+func (e *ClusterNotFoundError) Error() string {
+	return fmt.Sprintf("no cluster matches '%s', run `dcos cluster list` to list configured clusters", e.Query)
+}
+
+// AmbiguousClusterError is returned when several configured clusters match a query.
+type AmbiguousClusterError struct {
+	Query      string
+	Candidates []*Cluster
+}
+
+// Error implements the error interface, it lists the clusters matching the query.
+func (e *AmbiguousClusterError) Error() string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "'%s' matches multiple clusters, please be more specific:", e.Query)
+	for _, cluster := range e.Candidates {
+		fmt.Fprintf(&msg, "\n  %s (%s)", cluster.ID(), cluster.Name())
+	}
+	return msg.String()
+}
+
+// ResolveCluster resolves a configured cluster from a query. The query can be the ID or name
+// of a cluster, a unique prefix of a cluster ID, or a case-insensitive substring of a cluster name.
+// Exact matches take precedence over partial ones.
+//
+// It returns a *ClusterNotFoundError when no cluster matches and an *AmbiguousClusterError
+// when the query matches several clusters.
+func (m *Manager) ResolveCluster(query string) (*Config, error) {
+	var exactMatches, partialMatches []*Config
+	for _, conf := range m.All() {
+		cluster := NewCluster(conf)
+		switch MatchCluster(query, cluster.ID(), cluster.Name()) {
+		case MatchID:
+			return conf, nil
+		case MatchName:
+			exactMatches = append(exactMatches, conf)
+		case MatchPartial:
+			partialMatches = append(partialMatches, conf)
+		}
+	}
+
+	matches := exactMatches
+	if len(matches) == 0 {
+		matches = partialMatches
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &ClusterNotFoundError{Query: query}
+	case 1:
+		return matches[0], nil
+	default:
+		candidates := make([]*Cluster, len(matches))
+		for i, conf := range matches {
+			candidates[i] = NewCluster(conf)
+		}
+		return nil, &AmbiguousClusterError{Query: query, Candidates: candidates}
+	}
+}
+
+// Match describes how a query matches a cluster.
+type Match int
+
+// These are the different ways a query can match a cluster, from the weakest to the strongest.
+const (
+	MatchNone Match = iota
+	MatchPartial
+	MatchName
+	MatchID
+)
+
+// MatchCluster indicates how a query matches a cluster with the given ID and name,
+// using the same rules as ResolveCluster. It can be used for clusters which aren't
+// configured locally, such as linked clusters.
+func MatchCluster(query, id, name string) Match {
+	switch {
+	case query == "":
+		return MatchNone
+	case query == id:
+		return MatchID
+	case query == name:
+		return MatchName
+	case strings.HasPrefix(id, query):
+		return MatchPartial
+	case strings.Contains(strings.ToLower(name), strings.ToLower(query)):
+		return MatchPartial
+	default:
+		return MatchNone
+	}
+}
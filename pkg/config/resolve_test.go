@@ -0,0 +1,70 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCluster(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	clusters := map[string]string{
+		"79893270-f9f1-4293-9225-e6e3900043a9": "dev-east",
+		"97193161-f7f1-2295-2514-a6b3918043b6": "prod-east",
+		"97193162-a0c4-4d6d-9b1e-1b7a3e2c5f10": "Prod-West",
+		"a1b2c3d4-0000-4000-8000-000000000000": "prod",
+	}
+	for id, name := range clusters {
+		afero.WriteFile(fs, filepath.Join(".dcos", "clusters", id, "dcos.toml"), []byte(`
+[cluster]
+name = "`+name+`"
+`), 0600)
+	}
+
+	manager := NewManager(ManagerOpts{
+		Dir: ".dcos",
+		Fs:  fs,
+	})
+
+	fixtures := []struct {
+		query      string
+		expectedID string
+		candidates int
+	}{
+		{"79893270-f9f1-4293-9225-e6e3900043a9", "79893270-f9f1-4293-9225-e6e3900043a9", 0},
+		{"7989", "79893270-f9f1-4293-9225-e6e3900043a9", 0},
+		{"dev", "79893270-f9f1-4293-9225-e6e3900043a9", 0},
+		{"WEST", "97193162-a0c4-4d6d-9b1e-1b7a3e2c5f10", 0},
+
+		// An exact name takes precedence over partial matches.
+		{"prod", "a1b2c3d4-0000-4000-8000-000000000000", 0},
+
+		{"9719316", "", 2},
+		{"east", "", 2},
+		{"rod", "", 3},
+		{"staging", "", 0},
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.query, func(t *testing.T) {
+			conf, err := manager.ResolveCluster(fixture.query)
+			if fixture.expectedID != "" {
+				require.NoError(t, err)
+				require.Equal(t, fixture.expectedID, NewCluster(conf).ID())
+				return
+			}
+			require.Nil(t, conf)
+			if fixture.candidates == 0 {
+				require.IsType(t, &ClusterNotFoundError{}, err)
+				return
+			}
+			require.IsType(t, &AmbiguousClusterError{}, err)
+			require.Len(t, err.(*AmbiguousClusterError).Candidates, fixture.candidates)
+			for _, cluster := range err.(*AmbiguousClusterError).Candidates {
+				require.Contains(t, err.Error(), cluster.ID())
+			}
+		})
+	}
+}
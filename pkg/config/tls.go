@@ -0,0 +1,119 @@
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tlsVersions maps the values accepted by "core.ssl_min_version" to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion parses a TLS version such as "1.2" or "1.3".
+func ParseTLSVersion(val string) (uint16, error) {
+	version, ok := tlsVersions[strings.TrimSpace(val)]
+	if !ok {
+		var versions []string
+		for v := range tlsVersions {
+			versions = append(versions, v)
+		}
+		sort.Strings(versions)
+		return 0, fmt.Errorf("unknown TLS version '%s', expected one of %s", val, strings.Join(versions, ", "))
+	}
+	return version, nil
+}
+
+// ParseCipherSuites parses a comma-separated list of cipher suite names
+// (eg. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384").
+func ParseCipherSuites(val string) ([]uint16, error) {
+	suites := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		suites[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(val, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := suites[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite '%s'", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// CertFingerprintMismatchError is returned during the TLS handshake when the
+// server certificate doesn't match the pinned "core.ssl_cert_fingerprint".
+type CertFingerprintMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+// Error implements the error interface.
+func (e *CertFingerprintMismatchError) Error() string {
+	return fmt.Sprintf("certificate fingerprint mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// CertFingerprint returns the SHA-256 fingerprint of a DER encoded certificate,
+// formatted as colon-separated uppercase hex bytes (eg. "3A:0F:...").
+func CertFingerprint(rawCert []byte) string {
+	return formatFingerprint(sha256.Sum256(rawCert))
+}
+
+// ParseCertFingerprint parses a SHA-256 fingerprint, the hex bytes can be separated by colons.
+func ParseCertFingerprint(val string) ([sha256.Size]byte, error) {
+	var fingerprint [sha256.Size]byte
+	digest, err := hex.DecodeString(strings.Replace(strings.TrimSpace(val), ":", "", -1))
+	if err != nil || len(digest) != sha256.Size {
+		return fingerprint, fmt.Errorf("invalid SHA-256 fingerprint '%s'", val)
+	}
+	copy(fingerprint[:], digest)
+	return fingerprint, nil
+}
+
+// VerifyCertFingerprint returns a tls.Config.VerifyPeerCertificate callback which rejects
+// connections whose server leaf certificate doesn't have the given SHA-256 fingerprint.
+func VerifyCertFingerprint(val string) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		expected, err := ParseCertFingerprint(val)
+		if err != nil {
+			return err
+		}
+		if len(rawCerts) == 0 {
+			return errors.New("no server certificate to verify the fingerprint of")
+		}
+		if actual := sha256.Sum256(rawCerts[0]); actual != expected {
+			return &CertFingerprintMismatchError{
+				Expected: formatFingerprint(expected),
+				Actual:   formatFingerprint(actual),
+			}
+		}
+		return nil
+	}
+}
+
+// formatFingerprint formats a SHA-256 fingerprint as colon-separated uppercase hex bytes.
+func formatFingerprint(fingerprint [sha256.Size]byte) string {
+	hexBytes := make([]string, len(fingerprint))
+	for i, b := range fingerprint {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexBytes, ":")
+}
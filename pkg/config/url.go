@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// NormalizeURL returns the canonical form of a cluster URL. IPv6 literals which are not
+// enclosed in brackets are bracketed (eg. "https://2001:db8::1" becomes "https://[2001:db8::1]"),
+// and trailing slashes are removed.
+func NormalizeURL(rawURL string) (string, error) {
+	u, err := url.Parse(bracketIPv6(rawURL))
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid URL '%s', expected a URL such as https://example.com", rawURL)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// bracketIPv6 encloses the host of a URL in brackets when it is a bare IPv6 literal.
+// Without brackets, the colons of the address would be mistaken for a port separator.
+func bracketIPv6(rawURL string) string {
+	i := strings.Index(rawURL, "://")
+	if i == -1 {
+		return rawURL
+	}
+	scheme, rest := rawURL[:i+3], rawURL[i+3:]
+
+	host, path := rest, ""
+	if j := strings.IndexAny(rest, "/?#"); j != -1 {
+		host, path = rest[:j], rest[j:]
+	}
+	if isIPv6(host) {
+		return scheme + "[" + host + "]" + path
+	}
+	return rawURL
+}
+
+// isIPv6 indicates whether or not a host is an IPv6 literal without brackets.
+func isIPv6(host string) bool {
+	return strings.Contains(host, ":") && net.ParseIP(host) != nil
+}
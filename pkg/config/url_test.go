@@ -0,0 +1,41 @@
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	testCases := []struct {
+		rawURL string
+		url    string
+	}{
+		{"https://dcos.example.com", "https://dcos.example.com"},
+		{"https://dcos.example.com/", "https://dcos.example.com"},
+		{"https://[2001:db8::1]", "https://[2001:db8::1]"},
+		{"https://[2001:db8::1]:8443/", "https://[2001:db8::1]:8443"},
+		{"https://2001:db8::1", "https://[2001:db8::1]"},
+		{"http://2001:db8::1/dcos", "http://[2001:db8::1]/dcos"},
+		{"https://10.0.0.1:8443", "https://10.0.0.1:8443"},
+	}
+
+	for _, tc := range testCases {
+		normalized, err := NormalizeURL(tc.rawURL)
+		require.NoError(t, err, tc.rawURL)
+		require.Equal(t, tc.url, normalized)
+
+		// The host must survive a round-trip through the config.
+		cluster := NewCluster(nil)
+		cluster.SetURL(normalized)
+		u, err := url.Parse(cluster.URL())
+		require.NoError(t, err)
+		require.Equal(t, tc.url, u.String())
+	}
+}
+
+func TestNormalizeInvalidURL(t *testing.T) {
+	_, err := NormalizeURL("dcos.example.com")
+	require.EqualError(t, err, "invalid URL 'dcos.example.com', expected a URL such as https://example.com")
+}
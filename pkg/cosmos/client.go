@@ -25,14 +25,15 @@ func NewClient(baseClient *httpclient.Client) *Client {
 // endpoint for unmarshalling convenience.
 type PackageInfo struct {
 	Package struct {
-		PackagingVersion      string `json:"packagingVersion"`
-		Name                  string `json:"name"`
-		Description           string `json:"description"`
-		Version               string `json:"version"`
-		ReleaseVersion        int    `json:"releaseVersion"`
-		MinDCOSReleaseVersion string `json:"minDcosReleaseVersion"`
-		Framework             bool   `json:"framework"`
-		Maintainer            string `json:"maintainer"`
+		PackagingVersion      string          `json:"packagingVersion"`
+		Name                  string          `json:"name"`
+		Description           string          `json:"description"`
+		Version               string          `json:"version"`
+		ReleaseVersion        int             `json:"releaseVersion"`
+		MinDCOSReleaseVersion string          `json:"minDcosReleaseVersion"`
+		Framework             bool            `json:"framework"`
+		Maintainer            string          `json:"maintainer"`
+		Config                json.RawMessage `json:"config,omitempty"`
 		Resource              struct {
 			CLI struct {
 				Plugins map[string]map[string]*Plugin `json:"binaries"`
@@ -54,9 +55,18 @@ type ContentHash struct {
 	Value string `json:"value"`
 }
 
-// DescribePackage returns information about the named package.
+// DescribePackage returns information about the latest version of the named package.
 func (c *Client) DescribePackage(name string) (*PackageInfo, error) {
+	return c.DescribePackageVersion(name, "")
+}
+
+// DescribePackageVersion returns information about a given version of the named package.
+// When the version is empty, the latest version is described.
+func (c *Client) DescribePackageVersion(name, version string) (*PackageInfo, error) {
 	reqBodyPayload := map[string]string{"packageName": name}
+	if version != "" {
+		reqBodyPayload["packageVersion"] = version
+	}
 
 	var reqBody bytes.Buffer
 	if err := json.NewEncoder(&reqBody).Encode(reqBodyPayload); err != nil {
@@ -83,3 +83,24 @@ func TestPackageDescribe(t *testing.T) {
 	require.Equal(t, "zip", windowsPlugin.Kind)
 	require.Equal(t, ts.URL+"/dcos-test-cli.zip", windowsPlugin.URL)
 }
+
+func TestPackageDescribeVersion(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/package/describe", func(w http.ResponseWriter, req *http.Request) {
+		payload := map[string]string{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		assert.Equal(t, "kafka", payload["packageName"])
+		assert.Equal(t, "2.4.0", payload["packageVersion"])
+
+		w.Write([]byte(`{"package": {"name": "kafka", "version": "2.4.0", "config": {"type": "object"}}}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	pkgInfo, err := NewClient(httpclient.New(ts.URL)).DescribePackageVersion("kafka", "2.4.0")
+	require.NoError(t, err)
+	require.Equal(t, "2.4.0", pkgInfo.Package.Version)
+	require.JSONEq(t, `{"type": "object"}`, string(pkgInfo.Package.Config))
+}
@@ -0,0 +1,315 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/spf13/afero"
+)
+
+// schema is the subset of a JSON schema used by package config schemas.
+type schema struct {
+	Type       interface{}        `json:"type"`
+	Default    interface{}        `json:"default"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+
+	AdditionalProperties interface{} `json:"additionalProperties"`
+}
+
+// DefaultOptions returns the default options document of a package config schema. It holds
+// the default value of each property, nested objects only appear when they hold defaults.
+// The document can be passed as is to a package install.
+func DefaultOptions(configSchema json.RawMessage) (map[string]interface{}, error) {
+	defaults := map[string]interface{}{}
+	if len(configSchema) == 0 {
+		return defaults, nil
+	}
+	var s schema
+	if err := json.Unmarshal(configSchema, &s); err != nil {
+		return nil, err
+	}
+	if obj, ok := s.defaults().(map[string]interface{}); ok {
+		defaults = obj
+	}
+	return defaults, nil
+}
+
+// defaults returns the default value of a schema, or nil when it has none. The default of an
+// object is made of the defaults of its properties, when it doesn't have an explicit default.
+func (s *schema) defaults() interface{} {
+	if s.Default != nil || len(s.Properties) == 0 {
+		return s.Default
+	}
+	obj := map[string]interface{}{}
+	for name, prop := range s.Properties {
+		if prop == nil {
+			continue
+		}
+		if val := prop.defaults(); val != nil {
+			obj[name] = val
+		}
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	return obj
+}
+
+// RenderPackage renders the Marathon app definition of a package with the given options.
+// When options is nil, the app is rendered with the default options of the package.
+// When the version is empty, the latest version of the package is rendered.
+func (c *Client) RenderPackage(name, version string, options map[string]interface{}) (json.RawMessage, error) {
+	reqBodyPayload := map[string]interface{}{"packageName": name}
+	if version != "" {
+		reqBodyPayload["packageVersion"] = version
+	}
+	if options != nil {
+		reqBodyPayload["options"] = options
+	}
+
+	var reqBody bytes.Buffer
+	if err := json.NewEncoder(&reqBody).Encode(reqBodyPayload); err != nil {
+		return nil, err
+	}
+
+	req, err := c.http.NewRequest("POST", "/package/render", &reqBody, httpclient.FailOnErrStatus(true))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(
+		"Content-Type",
+		"application/vnd.dcos.package.render-request+json;charset=utf-8;version=v1",
+	)
+	req.Header.Set(
+		"Accept",
+		"application/vnd.dcos.package.render-response+json;charset=utf-8;version=v1",
+	)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var renderResp struct {
+		MarathonJSON json.RawMessage `json:"marathonJson"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&renderResp); err != nil {
+		return nil, err
+	}
+	return renderResp.MarathonJSON, nil
+}
+
+// ReadOptions reads an options file for a package install, it must contain a JSON object.
+// When the path is "-", options are read from stdin. Syntax errors report their position.
+func ReadOptions(fs afero.Fs, stdin io.Reader, path string) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(stdin)
+	} else {
+		data, err = afero.ReadFile(fs, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var options map[string]interface{}
+	if err := json.Unmarshal(data, &options); err != nil {
+		switch jsonErr := err.(type) {
+		case *json.SyntaxError:
+			line, col := position(data, jsonErr.Offset)
+			return nil, fmt.Errorf("invalid options '%s': %s (line %d, column %d)", path, jsonErr, line, col)
+		case *json.UnmarshalTypeError:
+			return nil, fmt.Errorf("invalid options '%s': expected a JSON object, got %s", path, jsonErr.Value)
+		default:
+			return nil, fmt.Errorf("invalid options '%s': %s", path, err)
+		}
+	}
+	if options == nil {
+		return nil, fmt.Errorf("invalid options '%s': expected a JSON object", path)
+	}
+	return options, nil
+}
+
+// position returns the line and column of the byte preceding an offset in a document, it is
+// the byte which caused a syntax error. Lines and columns start at 1.
+func position(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// ValidationError is returned when options don't match the config schema of a package.
+type ValidationError struct {
+	Errors []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "invalid options:\n  - " + strings.Join(e.Errors, "\n  - ")
+}
+
+// ValidateOptions validates options against the config schema of a package. It checks
+// the types of values, required properties without default, and unknown properties
+// in objects which don't allow additional properties.
+func ValidateOptions(configSchema json.RawMessage, options map[string]interface{}) error {
+	if len(configSchema) == 0 {
+		return nil
+	}
+	var s schema
+	if err := json.Unmarshal(configSchema, &s); err != nil {
+		return err
+	}
+
+	var errs []string
+	s.validate("", options, &errs)
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// validate appends the errors of a value at a given path to errs.
+func (s *schema) validate(path string, val interface{}, errs *[]string) {
+	if types := s.types(); len(types) > 0 {
+		actual := jsonType(val)
+		if !typeMatches(types, actual) {
+			*errs = append(*errs, fmt.Sprintf("%s: expected %s, got %s", displayPath(path), strings.Join(types, " or "), actual))
+			return
+		}
+	}
+
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, name := range s.Required {
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		if prop := s.Properties[name]; prop == nil || prop.defaults() == nil {
+			*errs = append(*errs, fmt.Sprintf("%s: missing required property", displayPath(join(path, name))))
+		}
+	}
+	for name, propVal := range obj {
+		prop, ok := s.Properties[name]
+		if !ok || prop == nil {
+			if additional, ok := s.AdditionalProperties.(bool); ok && !additional {
+				*errs = append(*errs, fmt.Sprintf("%s: unknown property", displayPath(join(path, name))))
+			}
+			continue
+		}
+		prop.validate(join(path, name), propVal, errs)
+	}
+}
+
+// types returns the types allowed by a schema, its type is either a string or a list of strings.
+func (s *schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if str, ok := item.(string); ok {
+				types = append(types, str)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+// typeMatches indicates whether or not a JSON type is part of the allowed types, integers are also numbers.
+func typeMatches(types []string, actual string) bool {
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON schema type of a decoded JSON value.
+func jsonType(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
+
+// join joins a property name to a path of properties.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayPath returns the path of a property for error messages.
+func displayPath(path string) string {
+	if path == "" {
+		return "options"
+	}
+	return path
+}
+
+// ResolveOptions returns the options a package would be installed with, they are the default
+// options of its config schema overridden by the given options.
+func ResolveOptions(configSchema json.RawMessage, options map[string]interface{}) (map[string]interface{}, error) {
+	defaults, err := DefaultOptions(configSchema)
+	if err != nil {
+		return nil, err
+	}
+	return mergeOptions(defaults, options), nil
+}
+
+// mergeOptions deep-merges options into defaults, nested objects are merged recursively.
+func mergeOptions(defaults, options map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults))
+	for key, val := range defaults {
+		merged[key] = val
+	}
+	for key, val := range options {
+		defaultObj, ok1 := merged[key].(map[string]interface{})
+		obj, ok2 := val.(map[string]interface{})
+		if ok1 && ok2 {
+			merged[key] = mergeOptions(defaultObj, obj)
+			continue
+		}
+		merged[key] = val
+	}
+	return merged
+}
@@ -0,0 +1,172 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+const kafkaConfigSchema = `{
+	"type": "object",
+	"properties": {
+		"service": {
+			"type": "object",
+			"additionalProperties": false,
+			"required": ["name", "principal"],
+			"properties": {
+				"principal": {"type": "string"},
+				"name": {"type": "string", "default": "kafka"},
+				"user": {"type": "string"},
+				"checks": {
+					"type": "object",
+					"properties": {
+						"interval": {"type": "integer"}
+					}
+				}
+			}
+		},
+		"brokers": {
+			"type": "object",
+			"properties": {
+				"count": {"type": "integer", "default": 3},
+				"cpus": {"type": "number", "default": 1.5},
+				"ports": {"type": "array", "default": [9092, 9093]}
+			}
+		},
+		"tls": {"type": "object", "default": {"enabled": false}}
+	}
+}`
+
+func TestDefaultOptions(t *testing.T) {
+	defaults, err := DefaultOptions(json.RawMessage(kafkaConfigSchema))
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"service": map[string]interface{}{"name": "kafka"},
+		"brokers": map[string]interface{}{
+			"count": float64(3),
+			"cpus":  1.5,
+			"ports": []interface{}{float64(9092), float64(9093)},
+		},
+		"tls": map[string]interface{}{"enabled": false},
+	}
+	require.Equal(t, expected, defaults)
+
+	defaults, err = DefaultOptions(nil)
+	require.NoError(t, err)
+	require.Empty(t, defaults)
+
+	_, err = DefaultOptions(json.RawMessage(`{"properties": []}`))
+	require.Error(t, err)
+}
+
+func TestRenderPackage(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/package/render", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(
+			t,
+			"application/vnd.dcos.package.render-request+json;charset=utf-8;version=v1",
+			req.Header.Get("Content-Type"),
+		)
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		assert.Equal(t, "kafka", payload["packageName"])
+		assert.Equal(t, "2.5.0", payload["packageVersion"])
+		assert.Equal(t, map[string]interface{}{"service": map[string]interface{}{"name": "kafka-dev"}}, payload["options"])
+
+		w.Write([]byte(`{"marathonJson": {"id": "/kafka-dev", "instances": 1}}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	app, err := c.RenderPackage("kafka", "2.5.0", map[string]interface{}{
+		"service": map[string]interface{}{"name": "kafka-dev"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "/kafka-dev", "instances": 1}`, string(app))
+}
+
+func TestReadOptions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/options.json", []byte(`{"service": {"name": "kafka-dev"}}`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/invalid.json", []byte("{\n  \"service\": {\n    \"name\": \"kafka\",\n  }\n}"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/array.json", []byte(`[]`), 0644))
+
+	options, err := ReadOptions(fs, nil, "/options.json")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"service": map[string]interface{}{"name": "kafka-dev"}}, options)
+
+	options, err = ReadOptions(fs, strings.NewReader(`{"brokers": {"count": 5}}`), "-")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"brokers": map[string]interface{}{"count": float64(5)}}, options)
+
+	_, err = ReadOptions(fs, nil, "/invalid.json")
+	require.EqualError(t, err, "invalid options '/invalid.json': invalid character '}' looking for beginning of object key string (line 4, column 3)")
+
+	_, err = ReadOptions(fs, nil, "/array.json")
+	require.EqualError(t, err, "invalid options '/array.json': expected a JSON object, got array")
+
+	_, err = ReadOptions(fs, strings.NewReader("null"), "-")
+	require.EqualError(t, err, "invalid options '-': expected a JSON object")
+}
+
+func TestValidateOptions(t *testing.T) {
+	configSchema := json.RawMessage(kafkaConfigSchema)
+
+	valid := map[string]interface{}{
+		"service": map[string]interface{}{"principal": "kafka-principal"},
+		"brokers": map[string]interface{}{"count": float64(5), "cpus": float64(2)},
+		"extra":   true,
+	}
+	require.NoError(t, ValidateOptions(configSchema, valid))
+
+	invalid := map[string]interface{}{
+		"service": map[string]interface{}{"nmae": "kafka-dev", "name": float64(1)},
+		"brokers": map[string]interface{}{"count": 2.5, "ports": "9092"},
+	}
+	err := ValidateOptions(configSchema, invalid)
+	require.IsType(t, &ValidationError{}, err)
+	require.Equal(t, []string{
+		"brokers.count: expected integer, got number",
+		"brokers.ports: expected array, got string",
+		"service.name: expected string, got integer",
+		"service.nmae: unknown property",
+		"service.principal: missing required property",
+	}, err.(*ValidationError).Errors)
+	require.Contains(t, err.Error(), "invalid options:\n  - brokers.count")
+
+	require.NoError(t, ValidateOptions(nil, invalid))
+}
+
+func TestResolveOptions(t *testing.T) {
+	resolved, err := ResolveOptions(json.RawMessage(kafkaConfigSchema), map[string]interface{}{
+		"service": map[string]interface{}{"name": "kafka-dev"},
+		"brokers": map[string]interface{}{"count": float64(5)},
+		"tls":     map[string]interface{}{"enabled": true},
+	})
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"service": map[string]interface{}{"name": "kafka-dev"},
+		"brokers": map[string]interface{}{
+			"count": float64(5),
+			"cpus":  1.5,
+			"ports": []interface{}{float64(9092), float64(9093)},
+		},
+		"tls": map[string]interface{}{"enabled": true},
+	}
+	require.Equal(t, expected, resolved)
+}
@@ -0,0 +1,109 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// SearchResult is a package matching a search query.
+// It is mirroring an item of the `/package/search` endpoint response.
+type SearchResult struct {
+	Name           string   `json:"name"`
+	CurrentVersion string   `json:"currentVersion"`
+	Versions       []string `json:"versions,omitempty"`
+	Description    string   `json:"description"`
+	Framework      bool     `json:"framework"`
+	Tags           []string `json:"tags"`
+	Selected       bool     `json:"selected"`
+}
+
+// SearchOpts are the options for a package search.
+type SearchOpts struct {
+	// Query is a substring or a "*" wildcard pattern matched against the name, description and tags of packages.
+	Query string
+
+	// Selected only keeps packages selected (certified) by the catalog maintainers.
+	Selected bool
+}
+
+// SearchPackages returns the packages of the catalog matching the search options.
+//
+// The query is sent to Cosmos which filters packages server-side. Results are also filtered
+// client-side, older Cosmos versions return the whole catalog regardless of the query.
+// Cosmos doesn't paginate search responses, a single response holds every match.
+func (c *Client) SearchPackages(opts SearchOpts) ([]SearchResult, error) {
+	var reqBody bytes.Buffer
+	if err := json.NewEncoder(&reqBody).Encode(map[string]string{"query": opts.Query}); err != nil {
+		return nil, err
+	}
+
+	req, err := c.http.NewRequest("POST", "/package/search", &reqBody, httpclient.FailOnErrStatus(true))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(
+		"Content-Type",
+		"application/vnd.dcos.package.search-request+json;charset=utf-8;version=v1",
+	)
+	req.Header.Set(
+		"Accept",
+		"application/vnd.dcos.package.search-response+json;charset=utf-8;version=v1",
+	)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var searchResp struct {
+		Packages []SearchResult `json:"packages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+		return nil, err
+	}
+
+	results := []SearchResult{}
+	for _, pkg := range searchResp.Packages {
+		if opts.Selected && !pkg.Selected {
+			continue
+		}
+		if !pkg.matches(opts.Query) {
+			continue
+		}
+		results = append(results, pkg)
+	}
+	return results, nil
+}
+
+// matches indicates whether or not the name, description or tags of a package match the query.
+//
+// As for Cosmos, a query containing "*" is a wildcard pattern which must match a whole field (eg. "kaf*"),
+// otherwise fields must contain the query. The comparison is case-insensitive and an empty query matches
+// every package.
+func (r *SearchResult) matches(query string) bool {
+	match := func(field string) bool {
+		return strings.Contains(strings.ToLower(field), strings.ToLower(query))
+	}
+	if strings.Contains(query, "*") {
+		parts := strings.Split(query, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		pattern := regexp.MustCompile("(?is)^" + strings.Join(parts, ".*") + "$")
+		match = pattern.MatchString
+	}
+
+	if match(r.Name) || match(r.Description) {
+		return true
+	}
+	for _, tag := range r.Tags {
+		if match(tag) {
+			return true
+		}
+	}
+	return false
+}
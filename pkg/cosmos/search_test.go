@@ -0,0 +1,72 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+func TestSearchPackages(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/package/search", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(
+			t,
+			"application/vnd.dcos.package.search-request+json;charset=utf-8;version=v1",
+			req.Header.Get("Content-Type"),
+		)
+		payload := map[string]string{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		assert.Contains(t, []string{"kafka", "kaf*", "*orchestration", "mess*"}, payload["query"])
+
+		// Simulate a Cosmos version which ignores the query.
+		w.Write([]byte(`{"packages": [
+			{"name": "kafka", "currentVersion": "2.5.0", "description": "Apache Kafka", "selected": true, "tags": ["message", "broker"]},
+			{"name": "confluent-kafka", "currentVersion": "2.4.0", "description": "Confluent Kafka", "tags": ["message"]},
+			{"name": "cassandra", "currentVersion": "2.3.0", "description": "Apache Cassandra", "selected": true, "tags": ["kafka-compatible"]},
+			{"name": "marathon", "currentVersion": "1.6.0", "description": "Container orchestration", "selected": true}
+		]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	results, err := c.SearchPackages(SearchOpts{Query: "kafka"})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, "kafka", results[0].Name)
+	require.Equal(t, "2.5.0", results[0].CurrentVersion)
+	require.Equal(t, []string{"message", "broker"}, results[0].Tags)
+	require.Equal(t, "confluent-kafka", results[1].Name)
+	require.Equal(t, "cassandra", results[2].Name)
+
+	results, err = c.SearchPackages(SearchOpts{Query: "kafka", Selected: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "kafka", results[0].Name)
+	require.Equal(t, "cassandra", results[1].Name)
+
+	// Wildcard queries match whole fields, as with Cosmos, including tags and descriptions.
+	for query, names := range map[string][]string{
+		"kaf*":           {"kafka", "cassandra"},
+		"*orchestration": {"marathon"},
+		"mess*":          {"kafka", "confluent-kafka"},
+	} {
+		results, err = c.SearchPackages(SearchOpts{Query: query})
+		require.NoError(t, err)
+		var resultNames []string
+		for _, result := range results {
+			resultNames = append(resultNames, result.Name)
+		}
+		require.Equal(t, names, resultNames, query)
+	}
+}
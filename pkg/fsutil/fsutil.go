@@ -68,6 +68,33 @@ func CopyReader(fs afero.Fs, r io.Reader, dest string, perm os.FileMode) error {
 	return err
 }
 
+// WriteFileAtomic writes data to a temporary file which is synced to the disk and then renamed
+// to the given path. Concurrent readers thus never see a partially written file and a crash
+// doesn't leave an empty or truncated one behind.
+func WriteFileAtomic(fs afero.Fs, path string, data []byte, perm os.FileMode) error {
+	tmpFile, err := afero.TempFile(fs, filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = fs.Chmod(tmpFile.Name(), perm)
+	}
+	if err == nil {
+		err = fs.Rename(tmpFile.Name(), path)
+	}
+	if err != nil {
+		fs.Remove(tmpFile.Name())
+	}
+	return err
+}
+
 // ReadSecureFile ensures a file has 0400 or 0600 permissions before reading it.
 // Permissions check is skipped on Windows as it uses a different mechanism than UNIX.
 func ReadSecureFile(fs afero.Fs, filename string) ([]byte, error) {
@@ -0,0 +1,48 @@
+package fsutil
+
+import (
+	"os"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// locks holds a mutex per lock path, it synchronizes goroutines of the current process.
+// File locks are held per open file and wouldn't be enough on filesystems such as afero.MemMapFs.
+var locks sync.Map
+
+// Lock acquires an exclusive lock on a given path, it blocks until the lock is available.
+// The returned function releases the lock. On the OS filesystem, the lock also applies
+// to other processes, it is acquired with flock on UNIX and LockFileEx on Windows.
+//
+// The lock file is created if it doesn't exist and is not removed when the lock is released.
+func Lock(fs afero.Fs, path string) (unlock func() error, err error) {
+	mu, _ := locks.LoadOrStore(path, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+
+	f, err := fs.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		mu.(*sync.Mutex).Unlock()
+		return nil, err
+	}
+
+	osFile, isOsFile := f.(*os.File)
+	if isOsFile {
+		if err := lockFile(osFile); err != nil {
+			f.Close()
+			mu.(*sync.Mutex).Unlock()
+			return nil, err
+		}
+	}
+
+	return func() error {
+		defer mu.(*sync.Mutex).Unlock()
+		if isOsFile {
+			if err := unlockFile(osFile); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		return f.Close()
+	}, nil
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package fsutil
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile acquires an exclusive lock on an open file.
+func lockFile(f *os.File) error {
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases the lock on an open file.
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,40 @@
+package fsutil
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+// lockfileExclusiveLock is the LOCKFILE_EXCLUSIVE_LOCK flag for LockFileEx.
+const lockfileExclusiveLock = 0x2
+
+// lockFile acquires an exclusive lock on the first byte of an open file.
+func lockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r1, _, err := procLockFileEx.Call(
+		f.Fd(), lockfileExclusiveLock, 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)),
+	)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// unlockFile releases the lock on an open file.
+func unlockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r1, _, err := procUnlockFileEx.Call(
+		f.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)),
+	)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
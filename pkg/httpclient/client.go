@@ -7,12 +7,17 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math/rand"
 	"mime"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +46,18 @@ type Options struct {
 	Logger          *logrus.Logger
 	CheckRedirect   func(req *http.Request, via []*http.Request) error
 	FailOnErrStatus bool
+	BeforeRequest   func(req *http.Request) error
+	Retries         int
+	RetryBaseDelay  time.Duration
+	Proxy           string
+	EnvLookup       func(key string) (string, bool)
+	Context         context.Context
+	Transport       *http.Transport
+
+	// Connection pool settings, they are ignored when a Transport is set.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
 }
 
 // ctxKey is a custom type to set values in request contexts.
@@ -50,6 +67,18 @@ type ctxKey int
 // the HTTP client should return in error when it encounters an HTTP error (4XX / 5XX).
 const ctxKeyFailOnErrStatus ctxKey = 0
 
+// ctxKeyRetryPolicy is a request context key which holds the retryPolicy for a request.
+const ctxKeyRetryPolicy ctxKey = 1
+
+// ctxKeyTimeout is a context key which holds the timeout of a context created through WithTimeout.
+const ctxKeyTimeout ctxKey = 2
+
+// retryPolicy defines how many times and how often a failed request should be retried.
+type retryPolicy struct {
+	retries   int
+	baseDelay time.Duration
+}
+
 // TLS sets the TLS configuration for the HTTP client transport.
 func TLS(tlsConfig *tls.Config) Option {
 	return func(opts *Options) {
@@ -100,6 +129,109 @@ func FailOnErrStatus(failOnErrStatus bool) Option {
 	}
 }
 
+// Retries sets the number of times idempotent requests (GET and HEAD) are retried when they fail because of
+// a connection error or a 429, 502, 503, or 504 response. Retries are delayed by an exponential backoff with jitter,
+// starting at baseDelay, unless the response has a Retry-After header. The request timeout still bounds the total
+// time spent on the request and its retries, a request isn't retried when the delay would exceed its deadline.
+func Retries(retries int, baseDelay time.Duration) Option {
+	return func(opts *Options) {
+		opts.Retries = retries
+		opts.RetryBaseDelay = baseDelay
+	}
+}
+
+// Context sets the context HTTP requests are derived from. Requests are aborted once it is done,
+// this bounds the total time spent on requests, including retries, when it has a deadline.
+func Context(ctx context.Context) Option {
+	return func(opts *Options) {
+		opts.Context = ctx
+	}
+}
+
+// WithTimeout returns a copy of the parent context which times out after a given duration. Requests
+// derived from it through the Context option fail with a "request timed out after <timeout>" error
+// once the timeout is exceeded.
+func WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	return context.WithValue(ctx, ctxKeyTimeout, timeout), cancel
+}
+
+// BeforeRequest sets a hook which is called before sending HTTP requests.
+// When the hook returns an error, the request is aborted.
+func BeforeRequest(hook func(req *http.Request) error) Option {
+	return func(opts *Options) {
+		opts.BeforeRequest = hook
+	}
+}
+
+// Transport sets the transport of the HTTP client. Clients sharing a transport reuse its idle
+// connections, the transport related options (TLS, Proxy, EnvLookup and connection pool) are then ignored.
+func Transport(transport *http.Transport) Option {
+	return func(opts *Options) {
+		opts.Transport = transport
+	}
+}
+
+// IdleConns sets the connection pool settings of the transport. Zero values keep the defaults;
+// 30 idle connections in total and per host, closed after 90 seconds of inactivity.
+func IdleConns(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.MaxIdleConns = maxIdleConns
+		opts.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		opts.IdleConnTimeout = idleConnTimeout
+	}
+}
+
+// NewTransport returns a transport based on the TLS, Proxy, EnvLookup and IdleConns options.
+// It is meant to be shared through the Transport option by clients targeting the same cluster.
+func NewTransport(opts ...Option) *http.Transport {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return newTransport(options)
+}
+
+// newTransport returns a transport for the given options.
+func newTransport(options Options) *http.Transport {
+	envLookup := options.EnvLookup
+	if envLookup == nil {
+		envLookup = os.LookupEnv
+	}
+	transport := &http.Transport{
+
+		// Allow http_proxy, https_proxy, and no_proxy, unless a proxy is explicitly set.
+		Proxy: proxyFunc(options.Proxy, envLookup),
+
+		// Set a 10 seconds timeout for the connection to be established.
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+		}).DialContext,
+
+		// Set it to 10 seconds as well for the TLS handshake when using HTTPS.
+		TLSHandshakeTimeout: 10 * time.Second,
+
+		// The client will be dealing with a single host (the one in baseURL),
+		// set max idle connections to 30 regardless of the host.
+		MaxIdleConns:        30,
+		MaxIdleConnsPerHost: 30,
+		IdleConnTimeout:     90 * time.Second,
+
+		// Set the TLS configuration as specified in the context.
+		TLSClientConfig: tlsConfig(options.TLS),
+	}
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	return transport
+}
+
 // New returns a new HTTP client for a given baseURL and functional options.
 func New(baseURL string, opts ...Option) *Client {
 	options := Options{
@@ -115,30 +247,15 @@ func New(baseURL string, opts ...Option) *Client {
 		opt(&options)
 	}
 
+	transport := options.Transport
+	if transport == nil {
+		transport = newTransport(options)
+	}
+
 	return &Client{
 		baseURL: baseURL,
 		baseClient: &http.Client{
-			Transport: &http.Transport{
-
-				// Allow http_proxy, https_proxy, and no_proxy.
-				Proxy: http.ProxyFromEnvironment,
-
-				// Set a 10 seconds timeout for the connection to be established.
-				DialContext: (&net.Dialer{
-					Timeout: 10 * time.Second,
-				}).DialContext,
-
-				// Set it to 10 seconds as well for the TLS handshake when using HTTPS.
-				TLSHandshakeTimeout: 10 * time.Second,
-
-				// The client will be dealing with a single host (the one in baseURL),
-				// set max idle connections to 30 regardless of the host.
-				MaxIdleConns:        30,
-				MaxIdleConnsPerHost: 30,
-
-				// Set the TLS configuration as specified in the context.
-				TLSClientConfig: options.TLS,
-			},
+			Transport: transport,
 
 			// Specify the redirect policy for the client.
 			CheckRedirect: options.CheckRedirect,
@@ -147,6 +264,20 @@ func New(baseURL string, opts ...Option) *Client {
 	}
 }
 
+// tlsConfig returns the TLS configuration for the transport.
+// TLS 1.2 is the minimum version, unless the configuration explicitly sets one.
+func tlsConfig(conf *tls.Config) *tls.Config {
+	if conf == nil {
+		conf = &tls.Config{}
+	} else {
+		conf = conf.Clone()
+	}
+	if conf.MinVersion == 0 {
+		conf.MinVersion = tls.VersionTLS12
+	}
+	return conf
+}
+
 // Get issues a GET to the specified DC/OS cluster path.
 func (c *Client) Get(path string, opts ...Option) (*http.Response, error) {
 	req, err := c.NewRequest("GET", path, nil, opts...)
@@ -193,6 +324,10 @@ func (c *Client) NewRequest(method, path string, body io.Reader, opts ...Option)
 
 	req.Header = options.Header
 
+	if options.Context != nil {
+		req = req.WithContext(options.Context)
+	}
+
 	// Set the default User-Agent unless the header is already set.
 	if _, ok := req.Header["User-Agent"]; !ok {
 		req.Header.Set("User-Agent", defaultUserAgent)
@@ -203,14 +338,28 @@ func (c *Client) NewRequest(method, path string, body io.Reader, opts ...Option)
 		req = req.WithContext(ctx)
 	}
 
+	if options.Retries > 0 {
+		policy := retryPolicy{retries: options.Retries, baseDelay: options.RetryBaseDelay}
+		ctx := context.WithValue(req.Context(), ctxKeyRetryPolicy, policy)
+		req = req.WithContext(ctx)
+	}
+
 	if options.Timeout > 0 {
+		// Keep track of the request timeout unless the parent context has a tighter deadline.
+		parentDeadline, ok := req.Context().Deadline()
+		tighterTimeout := !ok || parentDeadline.After(time.Now().Add(options.Timeout))
+
 		var cancel context.CancelFunc
 		ctx, cancel := context.WithTimeout(req.Context(), options.Timeout)
 		go func() {
 			<-ctx.Done()
 			cancel()
 		}()
-		req = req.WithContext(ctx)
+		if tighterTimeout {
+			req = req.WithContext(context.WithValue(ctx, ctxKeyTimeout, options.Timeout))
+		} else {
+			req = req.WithContext(ctx)
+		}
 	}
 	return req, nil
 }
@@ -219,42 +368,206 @@ func (c *Client) NewRequest(method, path string, body io.Reader, opts ...Option)
 // policy (such as redirects, cookies, auth) as configured on the
 // client.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.opts.BeforeRequest != nil {
+		if err := c.opts.BeforeRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.sendWithRetries(req)
+
+	if err != nil && req.Context().Err() == context.DeadlineExceeded {
+		if timeout, ok := req.Context().Value(ctxKeyTimeout).(time.Duration); ok {
+			return nil, fmt.Errorf("request timed out after %s", timeout)
+		}
+	}
+
+	if err == nil {
+		_, failOnErrStatus := req.Context().Value(ctxKeyFailOnErrStatus).(struct{})
+
+		if failOnErrStatus && resp.StatusCode >= 400 && resp.StatusCode < 600 {
+			return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+		}
+	}
+	return resp, err
+}
+
+// sendWithRetries sends an HTTP request and retries it according to its retry policy, if any.
+// When the request can't be retried anymore, the last response or error is returned.
+func (c *Client) sendWithRetries(req *http.Request) (*http.Response, error) {
+	policy, _ := req.Context().Value(ctxKeyRetryPolicy).(retryPolicy)
+
+	// Only idempotent requests are retried.
+	if req.Method != "GET" && req.Method != "HEAD" {
+		policy.retries = 0
+	}
+
+	// The body of a request can only be sent again if it can be re-created.
+	if req.Body != nil && req.GetBody == nil {
+		policy.retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		if attempt >= policy.retries || !c.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(policy.baseDelay, attempt)
+		if retryAfter, ok := retryAfterDelay(resp, time.Now()); ok {
+			delay = retryAfter
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		if c.opts.Logger != nil {
+			c.opts.Logger.Debugf("Retrying %s request to %s in %s.", req.Method, req.URL, delay)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return resp, err
+		case <-time.After(delay):
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// shouldRetry returns whether or not a request should be retried based on its response or error.
+func (c *Client) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Don't retry requests which have been canceled or timed out.
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case 429, 502, 503, 504:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfterDelay returns the delay indicated by the Retry-After header of a 429 or 503 response. The header
+// is either a number of seconds or an HTTP date. It returns false when the header is absent or invalid.
+func retryAfterDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != 429 && resp.StatusCode != 503) {
+		return 0, false
+	}
+	val := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(val, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// backoff returns the delay before a given retry attempt. The delay grows exponentially
+// from the base delay, it is then randomized between half and the full computed delay.
+func backoff(baseDelay time.Duration, attempt int) time.Duration {
+	delay := baseDelay << uint(attempt)
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// send sends an HTTP request. At debug level, a summary of each request (method, URL, status and
+// duration) is logged along with request and response dumps, secrets are redacted from the dumps.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
 	logger := c.opts.Logger
+	debug := logger != nil && logger.Level >= logrus.DebugLevel
 
-	if logger != nil && logger.Level >= logrus.DebugLevel {
+	if debug {
 		dumpBody := c.isText(req.Header.Get("Content-Type"))
 		reqDump, err := httputil.DumpRequestOut(req, dumpBody)
 		if err != nil {
 			logger.Debugf("Couldn't dump request: %s", err)
 		} else {
-			logger.Debug(string(reqDump))
+			logger.Debug(string(redactDump(reqDump)))
 		}
 	}
 
+	start := time.Now()
 	resp, err := c.baseClient.Do(req)
 
-	if logger != nil && logger.Level >= logrus.DebugLevel {
+	if debug {
+		fields := logrus.Fields{
+			"method":   req.Method,
+			"url":      redactURL(req.URL),
+			"duration": time.Since(start).Round(time.Millisecond),
+		}
 		if err == nil {
+			fields["status"] = resp.StatusCode
 			dumpBody := c.isText(resp.Header.Get("Content-Type"))
 			respDump, err := httputil.DumpResponse(resp, dumpBody)
 			if err != nil {
 				logger.Debugf("Couldn't dump response: %s", err)
 			} else {
-				logger.Debug(string(respDump))
+				logger.Debug(string(redactDump(respDump)))
 			}
 		} else {
-			logger.Debug(err)
+			fields["error"] = err
 		}
+		logger.WithFields(fields).Debug("HTTP request")
 	}
+	return resp, err
+}
 
-	if err == nil {
-		_, failOnErrStatus := req.Context().Value(ctxKeyFailOnErrStatus).(struct{})
+// redactedHeaders matches the lines of headers holding credentials in a request or response dump.
+var redactedHeaders = regexp.MustCompile(`(?im)^(Authorization|Proxy-Authorization|Cookie|Set-Cookie):[^\r\n]*`)
 
-		if failOnErrStatus && resp.StatusCode >= 400 && resp.StatusCode < 600 {
-			return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+// redactedFields matches JSON fields holding credentials in a request or response body (eg. login requests).
+var redactedFields = regexp.MustCompile(`"(token|password|private_key|secret|access_token|id_token|refresh_token)"(\s*):(\s*)"(?:[^"\\]|\\.)*"`)
+
+// redactedParams matches query parameters holding credentials in the request line of a request dump.
+var redactedParams = regexp.MustCompile(`([?&](?:token|access_token|id_token|password)=)[^&\s]*`)
+
+// redactDump removes credentials from a request or response dump.
+func redactDump(dump []byte) []byte {
+	dump = redactedHeaders.ReplaceAll(dump, []byte("$1: [REDACTED]"))
+	dump = redactedParams.ReplaceAll(dump, []byte("${1}REDACTED"))
+	return redactedFields.ReplaceAll(dump, []byte(`"$1"$2:$3"[REDACTED]"`))
+}
+
+// redactURL returns a URL with its user info and sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	query := redacted.Query()
+	for key := range query {
+		switch strings.ToLower(key) {
+		case "token", "access_token", "id_token", "password":
+			query.Set(key, "REDACTED")
 		}
 	}
-	return resp, err
+	if len(query) > 0 {
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
 }
 
 // BaseURL returns the HTTP client's base URL.
@@ -0,0 +1,117 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Proxy sets the proxy URL for HTTP and HTTPS requests. It takes precedence over
+// the HTTP_PROXY and HTTPS_PROXY env vars, NO_PROXY exclusions still apply.
+func Proxy(proxy string) Option {
+	return func(opts *Options) {
+		opts.Proxy = proxy
+	}
+}
+
+// EnvLookup sets the function used to lookup the proxy env vars, it defaults to os.LookupEnv.
+func EnvLookup(envLookup func(key string) (string, bool)) Option {
+	return func(opts *Options) {
+		opts.EnvLookup = envLookup
+	}
+}
+
+// proxyFunc returns the proxy function for an HTTP transport.
+//
+// Unlike http.ProxyFromEnvironment, env vars are read each time this function is called
+// instead of once per process. When proxy is empty the HTTP_PROXY and HTTPS_PROXY env vars
+// (or their lowercase versions) are used. Hosts matching NO_PROXY are requested directly.
+//
+// As for http.ProxyFromEnvironment, HTTP_PROXY is ignored when REQUEST_METHOD is set. In a CGI
+// environment it can be set by a client through the "Proxy" header (see https://httpoxy.org).
+func proxyFunc(proxy string, envLookup func(key string) (string, bool)) func(req *http.Request) (*url.URL, error) {
+	httpProxy, httpsProxy := proxy, proxy
+	if proxy == "" {
+		if requestMethod, _ := envLookup("REQUEST_METHOD"); requestMethod == "" {
+			httpProxy = getenv(envLookup, "HTTP_PROXY")
+		}
+		httpsProxy = getenv(envLookup, "HTTPS_PROXY")
+	}
+	noProxy := getenv(envLookup, "NO_PROXY")
+
+	return func(req *http.Request) (*url.URL, error) {
+		proxy := httpProxy
+		if req.URL.Scheme == "https" {
+			proxy = httpsProxy
+		}
+		if proxy == "" || !useProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		return parseProxyURL(proxy)
+	}
+}
+
+// parseProxyURL parses a proxy URL, the scheme defaults to "http" when it is omitted (eg. "proxy:3128").
+func parseProxyURL(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		if proxyURL, err := url.Parse("http://" + proxy); err == nil {
+			return proxyURL, nil
+		}
+	}
+	return proxyURL, err
+}
+
+// useProxy indicates whether or not requests to a given host should go through a proxy.
+//
+// The host is requested directly if it is a loopback address or if it matches an entry of noProxy.
+// noProxy is a comma-separated list of hostnames, domains (eg. ".example.com"), IPs, and CIDR blocks.
+// IPv6 addresses can be bracketed (eg. "[2001:db8::1]:443"), the host is never bracketed.
+// "*" matches all hosts.
+func useProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return false
+		}
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && ipNet.Contains(ip) {
+				return false
+			}
+			continue
+		}
+		if entryHost, _, err := net.SplitHostPort(entry); err == nil {
+			entry = entryHost
+		}
+		// IPv6 literals can be enclosed in brackets (eg. "[2001:db8::1]"), hosts never are.
+		entry = strings.TrimSuffix(strings.TrimPrefix(entry, "["), "]")
+		// Domains match the domain itself as well as its subdomains.
+		entry = strings.TrimPrefix(strings.TrimPrefix(entry, "*"), ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// getenv returns the value of an env var, falling back to its lowercase version.
+func getenv(envLookup func(key string) (string, bool), key string) string {
+	if val, _ := envLookup(key); val != "" {
+		return val
+	}
+	val, _ := envLookup(strings.ToLower(key))
+	return val
+}
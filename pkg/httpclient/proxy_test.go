@@ -0,0 +1,194 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyFromEnvironment(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	defer setenv(t, "HTTP_PROXY", proxy.URL)()
+	defer setenv(t, "NO_PROXY", "internal.example.com")()
+
+	resp, err := New("http://dcos.example.com").Get("/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "proxied", string(body))
+	require.Equal(t, "dcos.example.com", proxiedHost)
+}
+
+func TestProxyConnectTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	// The stub proxy tunnels CONNECT requests to the TLS server, regardless of the requested host.
+	var connectHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		connectHost = r.Host
+
+		serverConn, err := net.Dial("tcp", ts.Listener.Addr().String())
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer serverConn.Close()
+
+		clientConn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer clientConn.Close()
+
+		clientConn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go io.Copy(serverConn, clientConn)
+		io.Copy(clientConn, serverConn)
+	}))
+	defer proxy.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ts.Certificate())
+
+	// The httptest certificate is valid for "example.com", TLS verification is done against it.
+	client := New("https://example.com", TLS(&tls.Config{RootCAs: rootCAs}), Proxy(proxy.URL))
+
+	resp, err := client.Get("/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(body))
+	require.Equal(t, "example.com:443", connectHost)
+
+	// Verification fails when the CA isn't trusted.
+	client = New("https://example.com", Proxy(proxy.URL))
+	_, err = client.Get("/")
+	require.Error(t, err)
+}
+
+func TestProxyEnvLookup(t *testing.T) {
+	env := map[string]string{
+		"http_proxy":  "http-proxy.example.com:3128",
+		"HTTPS_PROXY": "https://https-proxy.example.com",
+		"NO_PROXY":    "internal.example.com",
+	}
+	envLookup := func(key string) (string, bool) {
+		val, ok := env[key]
+		return val, ok
+	}
+
+	testCases := []struct {
+		url   string
+		proxy string
+	}{
+		{"http://dcos.example.com", "http://http-proxy.example.com:3128"},
+		{"https://dcos.example.com", "https://https-proxy.example.com"},
+		{"https://internal.example.com", ""},
+	}
+	for _, tc := range testCases {
+		req, err := http.NewRequest("GET", tc.url, nil)
+		require.NoError(t, err)
+
+		proxyURL, err := proxyFunc("", envLookup)(req)
+		require.NoError(t, err)
+		if tc.proxy == "" {
+			require.Nil(t, proxyURL, tc.url)
+		} else {
+			require.Equal(t, tc.proxy, proxyURL.String(), tc.url)
+		}
+	}
+
+	// HTTP_PROXY might come from a "Proxy" request header in CGI environments, it is ignored.
+	env["REQUEST_METHOD"] = "GET"
+	req, err := http.NewRequest("GET", "http://dcos.example.com", nil)
+	require.NoError(t, err)
+
+	proxyURL, err := proxyFunc("", envLookup)(req)
+	require.NoError(t, err)
+	require.Nil(t, proxyURL)
+
+	// An explicit proxy still applies.
+	proxyURL, err = proxyFunc("proxy.example.com:3128", envLookup)(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+}
+
+func TestUseProxy(t *testing.T) {
+	testCases := []struct {
+		host     string
+		noProxy  string
+		useProxy bool
+	}{
+		{"dcos.example.com", "", true},
+		{"localhost", "", false},
+		{"127.0.0.1", "", false},
+		{"dcos.example.com", "*", false},
+		{"dcos.example.com", "dcos.example.com", false},
+		{"dcos.example.com", "DCOS.example.com:443", false},
+		{"dcos.example.com", "example.com", false},
+		{"dcos.example.com", ".example.com", false},
+		{"dcos.example.com", "*.example.com", false},
+		{"dcos.example.com", "other.com, example.org", true},
+		{"dcos.example.com", "os.example.com", true},
+		{"10.0.4.2", "10.0.0.0/16", false},
+		{"10.1.4.2", "10.0.0.0/16", true},
+		{"10.0.4.2", "10.0.4.2", false},
+		{"::1", "", false},
+		{"2001:db8::1", "2001:db8::1", false},
+		{"2001:db8::1", "[2001:db8::1]", false},
+		{"2001:db8::1", "[2001:db8::1]:443", false},
+		{"2001:db8::1", "2001:db8::/32", false},
+		{"2001:db9::1", "2001:db8::/32", true},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.useProxy, useProxy(tc.host, tc.noProxy), "%s with NO_PROXY=%s", tc.host, tc.noProxy)
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	proxyURL, err := parseProxyURL("proxy.example.com:3128")
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+
+	proxyURL, err = parseProxyURL("https://proxy.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "https://proxy.example.com", proxyURL.String())
+}
+
+// setenv sets an env var and returns a function restoring its previous value.
+func setenv(t *testing.T, key, value string) func() {
+	prevValue, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, prevValue)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
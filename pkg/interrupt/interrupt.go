@@ -0,0 +1,137 @@
+// Package interrupt handles SIGINT and SIGTERM for the DC/OS CLI.
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ExitCode is the exit code of interrupted commands, as for shells it is 128 + SIGINT.
+const ExitCode = 130
+
+// forceExitWindow is the duration after a signal during which another one force-exits the CLI.
+const forceExitWindow = 3 * time.Second
+
+// ErrInterrupted is returned when a command has been interrupted by a signal.
+var ErrInterrupted = errors.New("interrupted")
+
+// Handler cancels a context when the CLI receives SIGINT or SIGTERM, so that in-flight HTTP requests
+// and streams abort and the command returns. When another signal is received shortly after, the
+// command is considered stuck: the registered cleanup functions are run and the CLI exits right away.
+type Handler struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	errout  io.Writer
+	signals chan os.Signal
+	done    chan struct{}
+
+	// exit and now are variables so that tests can override them.
+	exit func(code int)
+	now  func() time.Time
+
+	mu            sync.Mutex
+	interruptedAt time.Time
+	cleanups      map[int]func()
+	nextCleanupID int
+}
+
+// New returns a handler whose context is derived from a parent context.
+// Signals are only handled once Start has been called.
+func New(parent context.Context, errout io.Writer) *Handler {
+	ctx, cancel := context.WithCancel(parent)
+	return &Handler{
+		ctx:      ctx,
+		cancel:   cancel,
+		errout:   errout,
+		signals:  make(chan os.Signal, 2),
+		done:     make(chan struct{}),
+		exit:     os.Exit,
+		now:      time.Now,
+		cleanups: make(map[int]func()),
+	}
+}
+
+// Context returns the context which is canceled when the CLI is interrupted.
+func (h *Handler) Context() context.Context {
+	return h.ctx
+}
+
+// Start starts handling SIGINT and SIGTERM.
+func (h *Handler) Start() {
+	signal.Notify(h.signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case <-h.signals:
+				h.handle()
+			case <-h.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops handling signals and releases the resources of the context.
+func (h *Handler) Stop() {
+	signal.Stop(h.signals)
+	close(h.done)
+	h.cancel()
+}
+
+// Interrupted indicates whether or not the CLI has received a signal.
+func (h *Handler) Interrupted() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return !h.interruptedAt.IsZero()
+}
+
+// AddCleanup registers a function to run when the CLI is force-exited, eg. to remove temporary files.
+// It returns a function which unregisters it, to be called once the cleanup isn't needed anymore.
+func (h *Handler) AddCleanup(cleanup func()) (remove func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextCleanupID
+	h.nextCleanupID++
+	h.cleanups[id] = cleanup
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.cleanups, id)
+	}
+}
+
+// handle handles a signal. The first one cancels the context, another one received within
+// the force exit window runs the cleanup functions and exits. A signal received after the
+// window is treated as a first one.
+func (h *Handler) handle() {
+	h.mu.Lock()
+	now := h.now()
+	forceExit := !h.interruptedAt.IsZero() && now.Sub(h.interruptedAt) <= forceExitWindow
+	h.interruptedAt = now
+
+	var cleanups []func()
+	if forceExit {
+		for _, cleanup := range h.cleanups {
+			cleanups = append(cleanups, cleanup)
+		}
+		h.cleanups = make(map[int]func())
+	}
+	h.mu.Unlock()
+
+	h.cancel()
+	if forceExit {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+		fmt.Fprintln(h.errout, "Interrupted.")
+		h.exit(ExitCode)
+	}
+}
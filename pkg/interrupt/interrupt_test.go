@@ -0,0 +1,63 @@
+package interrupt
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler() (*Handler, *bytes.Buffer, *time.Time, *[]int) {
+	var errout bytes.Buffer
+	now := time.Date(2019, 2, 15, 10, 30, 0, 0, time.UTC)
+	var exitCodes []int
+
+	h := New(context.Background(), &errout)
+	h.now = func() time.Time { return now }
+	h.exit = func(code int) { exitCodes = append(exitCodes, code) }
+	return h, &errout, &now, &exitCodes
+}
+
+func TestHandleCancelsContext(t *testing.T) {
+	h, errout, _, exitCodes := newTestHandler()
+	require.False(t, h.Interrupted())
+
+	h.handle()
+	require.True(t, h.Interrupted())
+	require.Equal(t, context.Canceled, h.Context().Err())
+	require.Empty(t, *exitCodes)
+	require.Empty(t, errout.String())
+}
+
+func TestHandleForceExit(t *testing.T) {
+	h, errout, now, exitCodes := newTestHandler()
+
+	var cleanups []string
+	h.AddCleanup(func() { cleanups = append(cleanups, "download") })
+	remove := h.AddCleanup(func() { cleanups = append(cleanups, "staging") })
+	remove()
+
+	h.handle()
+	*now = now.Add(time.Second)
+	h.handle()
+
+	require.Equal(t, []int{ExitCode}, *exitCodes)
+	require.Equal(t, []string{"download"}, cleanups)
+	require.Equal(t, "Interrupted.\n", errout.String())
+}
+
+func TestHandleAfterForceExitWindow(t *testing.T) {
+	h, _, now, exitCodes := newTestHandler()
+
+	h.handle()
+	*now = now.Add(forceExitWindow + time.Second)
+	h.handle()
+	require.Empty(t, *exitCodes)
+
+	// The window restarts from the latest signal.
+	*now = now.Add(time.Second)
+	h.handle()
+	require.Equal(t, []int{ExitCode}, *exitCodes)
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+// +build !windows
+
+package interrupt
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart(t *testing.T) {
+	h, _, _, _ := newTestHandler()
+	h.Start()
+	defer h.Stop()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+	select {
+	case <-h.Context().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context wasn't canceled")
+	}
+	require.True(t, h.Interrupted())
+}
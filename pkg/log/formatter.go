@@ -2,6 +2,9 @@ package log
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -9,7 +12,8 @@ import (
 // Formatter is a simple formatter for logrus.
 type Formatter struct{}
 
-// Format returns the log entry message with a trailing newline.
+// Format returns the log entry message with a trailing newline. Fields of the entry,
+// if any, are appended to the message as "key=value" pairs sorted by key.
 func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
 	var b *bytes.Buffer
 	if entry.Buffer != nil {
@@ -17,6 +21,21 @@ func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
 	} else {
 		b = &bytes.Buffer{}
 	}
-	b.WriteString(entry.Message + "\n")
+	b.WriteString(entry.Message)
+
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		val := fmt.Sprint(entry.Data[key])
+		if val == "" || strings.ContainsAny(val, " \t\n\"=") {
+			val = fmt.Sprintf("%q", val)
+		}
+		fmt.Fprintf(b, " %s=%s", key, val)
+	}
+
+	b.WriteString("\n")
 	return b.Bytes(), nil
 }
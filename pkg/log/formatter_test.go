@@ -38,6 +38,20 @@ func TestFormat(t *testing.T) {
 			},
 			"DEBUGMETOO\n",
 		},
+		{
+			// entry with fields
+			&logrus.Entry{
+				Logger:  logger,
+				Message: "HTTP request",
+				Data: logrus.Fields{
+					"url":    "https://dcos.example.com/metadata",
+					"status": 200,
+					"method": "GET",
+					"error":  "connection refused by peer",
+				},
+			},
+			"HTTP request error=\"connection refused by peer\" method=GET status=200 url=https://dcos.example.com/metadata\n",
+		},
 	}
 
 	for _, fixture := range fixtures {
@@ -0,0 +1,266 @@
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/sirupsen/logrus"
+)
+
+// grantTypeDeviceCode is the OAuth 2.0 grant type for device access token requests.
+const grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+const (
+	// defaultDevicePollInterval is the interval between token requests when the
+	// device authorization response doesn't specify one, as defined in RFC 8628.
+	defaultDevicePollInterval = 5 * time.Second
+
+	// deviceSlowDownIncrement is added to the polling interval when the server asks to slow down.
+	deviceSlowDownIncrement = 5 * time.Second
+)
+
+// ErrDeviceCodeExpired is returned when the user didn't complete the device authorization in time.
+var ErrDeviceCodeExpired = errors.New("the device code expired before the authorization was completed")
+
+// DeviceAuthorization is the response to an OAuth 2.0 device authorization request (RFC 8628).
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceTokenResponse is the response to a device access token request.
+type deviceTokenResponse struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// DeviceFlowOpts are options for a DeviceFlow.
+type DeviceFlowOpts struct {
+	// HTTPClient is the HTTP client for the cluster, it is used for endpoints relative to the cluster URL.
+	HTTPClient *httpclient.Client
+	Errout     io.Writer
+	Logger     *logrus.Logger
+
+	// Context, Proxy and EnvLookup are used for requests to absolute endpoints (eg. an external
+	// identity provider). The context also bounds the polling of the token endpoint.
+	Context   context.Context
+	Proxy     string
+	EnvLookup func(key string) (string, bool)
+}
+
+// DeviceFlow implements the OAuth 2.0 device authorization grant, which allows to log in
+// from environments without a browser. The user is given a code to enter on another device,
+// meanwhile the CLI polls the token endpoint until the authorization is completed.
+type DeviceFlow struct {
+	ctx          context.Context
+	http         *httpclient.Client
+	externalHTTP *httpclient.Client
+	errout       io.Writer
+	logger       *logrus.Logger
+	now          func() time.Time
+	sleep        func(ctx context.Context, d time.Duration) error
+}
+
+// NewDeviceFlow creates a new device flow.
+func NewDeviceFlow(opts DeviceFlowOpts) *DeviceFlow {
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	return &DeviceFlow{
+		ctx:  opts.Context,
+		http: opts.HTTPClient,
+		externalHTTP: httpclient.New(
+			"",
+			httpclient.Logger(opts.Logger),
+			httpclient.Context(opts.Context),
+			httpclient.Proxy(opts.Proxy),
+			httpclient.EnvLookup(opts.EnvLookup),
+		),
+		errout: opts.Errout,
+		logger: opts.Logger,
+		now:    time.Now,
+		sleep:  sleep,
+	}
+}
+
+// sleep waits for a given duration, it returns early with the context error when the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// Start initiates the device flow for a given provider config, it returns the OIDC ID token once the user
+// authorized the device. The ID token can then be exchanged for an ACS token through the DC/OS login API.
+func (f *DeviceFlow) Start(config ProviderConfig) (string, error) {
+	if !config.SupportsDeviceFlow() {
+		return "", errors.New("the login provider doesn't support the device authorization grant")
+	}
+
+	authz, err := f.authorize(config)
+	if err != nil {
+		return "", err
+	}
+
+	if authz.VerificationURIComplete != "" {
+		fmt.Fprintf(f.errout, "To log in, open the following link on any device:\n\n    %s\n\n", authz.VerificationURIComplete)
+		fmt.Fprintf(f.errout, "Then confirm that the code %s is displayed.\n\n", authz.UserCode)
+	} else {
+		fmt.Fprintf(f.errout, "To log in, open the following link on any device:\n\n    %s\n\n", authz.VerificationURI)
+		fmt.Fprintf(f.errout, "Then enter the code %s.\n\n", authz.UserCode)
+	}
+	return f.poll(config, authz)
+}
+
+// authorize sends the device authorization request.
+func (f *DeviceFlow) authorize(config ProviderConfig) (*DeviceAuthorization, error) {
+	form := url.Values{}
+	form.Set("client_id", config.ClientID)
+	form.Set("scope", "openid")
+
+	resp, err := f.post(config.DeviceAuthorizationEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("device authorization request failed with HTTP %d", resp.StatusCode)
+	}
+
+	var authz DeviceAuthorization
+	if err := json.NewDecoder(resp.Body).Decode(&authz); err != nil {
+		return nil, err
+	}
+	if authz.DeviceCode == "" || authz.UserCode == "" || authz.VerificationURI == "" {
+		return nil, errors.New("invalid device authorization response")
+	}
+	return &authz, nil
+}
+
+// poll requests the token endpoint until the user completes the authorization, the device code
+// expires or the context is done.
+func (f *DeviceFlow) poll(config ProviderConfig, authz *DeviceAuthorization) (string, error) {
+	interval := time.Duration(authz.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	var expiry time.Time
+	if authz.ExpiresIn > 0 {
+		expiry = f.now().Add(time.Duration(authz.ExpiresIn) * time.Second)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", grantTypeDeviceCode)
+	form.Set("device_code", authz.DeviceCode)
+	form.Set("client_id", config.ClientID)
+
+	for {
+		if !expiry.IsZero() && f.now().Add(interval).After(expiry) {
+			return "", ErrDeviceCodeExpired
+		}
+		if err := f.sleep(f.ctx, interval); err != nil {
+			return "", err
+		}
+
+		token, err := f.requestToken(config.TokenEndpoint, form)
+		if err == nil {
+			return token, nil
+		}
+
+		switch err.Code {
+		case "":
+			// The token endpoint couldn't be reached or returned an unexpected response, eg. because of
+			// a network blip or a restarting IdP. The request is retried until the device code expires.
+			if err.transient {
+				if f.ctx.Err() != nil {
+					return "", err
+				}
+				f.logger.Debugf("Device token request failed: %s.", err)
+				continue
+			}
+			return "", err
+		case "authorization_pending":
+			f.logger.Debug("Device authorization is pending.")
+		case "slow_down":
+			interval += deviceSlowDownIncrement
+			f.logger.Debugf("Slowing down device token requests to every %s.", interval)
+		case "expired_token":
+			return "", ErrDeviceCodeExpired
+		case "access_denied":
+			return "", errors.New("the device authorization was denied")
+		default:
+			return "", err
+		}
+	}
+}
+
+// requestToken sends a device access token request and returns the ID token on success.
+func (f *DeviceFlow) requestToken(tokenEndpoint string, form url.Values) (string, *deviceTokenError) {
+	resp, err := f.post(tokenEndpoint, form)
+	if err != nil {
+		return "", &deviceTokenError{Description: err.Error(), transient: true}
+	}
+	defer resp.Body.Close()
+
+	var tokenResp deviceTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", &deviceTokenError{
+			Description: fmt.Sprintf("invalid token response (HTTP %d)", resp.StatusCode),
+			transient:   resp.StatusCode >= 500,
+		}
+	}
+	if tokenResp.Error != "" {
+		return "", &deviceTokenError{Code: tokenResp.Error, Description: tokenResp.Description}
+	}
+	if tokenResp.IDToken == "" {
+		return "", &deviceTokenError{Description: "the token response doesn't contain an ID token"}
+	}
+	return tokenResp.IDToken, nil
+}
+
+// post sends a form to an endpoint, which can be relative to the cluster URL or absolute.
+func (f *DeviceFlow) post(endpoint string, form url.Values) (*http.Response, error) {
+	httpClient := f.externalHTTP
+	if strings.HasPrefix(endpoint, "/") {
+		httpClient = f.http
+	}
+	return httpClient.Post(endpoint, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+}
+
+// deviceTokenError is an error returned by the token endpoint during the device flow.
+// Transient errors are network errors and server errors without an OAuth error code.
+type deviceTokenError struct {
+	Code        string
+	Description string
+	transient   bool
+}
+
+// Error implements the error interface.
+func (e *deviceTokenError) Error() string {
+	if e.Code == "" {
+		return e.Description
+	}
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
@@ -0,0 +1,301 @@
+package login
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	var tokenRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "dcos-cli", req.FormValue("client_id"))
+		json.NewEncoder(w).Encode(&DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+			ExpiresIn:       600,
+			Interval:        2,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, grantTypeDeviceCode, req.FormValue("grant_type"))
+		assert.Equal(t, "device-code", req.FormValue("device_code"))
+
+		tokenRequests++
+		switch tokenRequests {
+		case 1:
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"authorization_pending"}`))
+		case 2:
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"slow_down"}`))
+		default:
+			w.Write([]byte(`{"id_token":"id-token","access_token":"access-token"}`))
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var errout bytes.Buffer
+	flow, sleeps := newTestDeviceFlow(ts.URL, &errout)
+
+	idToken, err := flow.Start(ProviderConfig{
+		ClientID:                    "dcos-cli",
+		DeviceAuthorizationEndpoint: "/device",
+		TokenEndpoint:               ts.URL + "/token",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "id-token", idToken)
+	require.Equal(t, 3, tokenRequests)
+	require.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second, 7 * time.Second}, *sleeps)
+	require.Contains(t, errout.String(), "https://idp.example.com/device")
+	require.Contains(t, errout.String(), "ABCD-EFGH")
+}
+
+func TestDeviceFlowExpiry(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+			ExpiresIn:       20,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(400)
+		w.Write([]byte(`{"error":"authorization_pending"}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	flow, sleeps := newTestDeviceFlow(ts.URL, ioutil.Discard)
+
+	_, err := flow.Start(ProviderConfig{
+		DeviceAuthorizationEndpoint: "/device",
+		TokenEndpoint:               "/token",
+	})
+	require.Equal(t, ErrDeviceCodeExpired, err)
+
+	// The default interval is 5 seconds, the code expires after 20 seconds.
+	require.Len(t, *sleeps, 4)
+}
+
+func TestDeviceFlowErrors(t *testing.T) {
+	fixtures := []struct {
+		tokenResp string
+		err       string
+	}{
+		{`{"error":"access_denied"}`, "the device authorization was denied"},
+		{`{"error":"expired_token"}`, ErrDeviceCodeExpired.Error()},
+		{`{"error":"invalid_client","error_description":"unknown client"}`, "invalid_client: unknown client"},
+		{`{"access_token":"access-token"}`, "the token response doesn't contain an ID token"},
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.tokenResp, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+				json.NewEncoder(w).Encode(&DeviceAuthorization{
+					DeviceCode:      "device-code",
+					UserCode:        "ABCD-EFGH",
+					VerificationURI: "https://idp.example.com/device",
+				})
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(400)
+				w.Write([]byte(fixture.tokenResp))
+			})
+
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			flow, _ := newTestDeviceFlow(ts.URL, ioutil.Discard)
+			_, err := flow.Start(ProviderConfig{
+				DeviceAuthorizationEndpoint: "/device",
+				TokenEndpoint:               "/token",
+			})
+			require.EqualError(t, err, fixture.err)
+		})
+	}
+}
+
+func TestDeviceFlowRetriesTransientErrors(t *testing.T) {
+	var tokenRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		tokenRequests++
+		if tokenRequests == 1 {
+			w.WriteHeader(502)
+			w.Write([]byte("Bad Gateway"))
+			return
+		}
+		w.Write([]byte(`{"id_token":"id-token"}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	flow, _ := newTestDeviceFlow(ts.URL, ioutil.Discard)
+	idToken, err := flow.Start(ProviderConfig{
+		DeviceAuthorizationEndpoint: "/device",
+		TokenEndpoint:               "/token",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "id-token", idToken)
+	require.Equal(t, 2, tokenRequests)
+}
+
+func TestDeviceFlowCanceled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+			Interval:        3600,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(400)
+		w.Write([]byte(`{"error":"authorization_pending"}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The real sleep is used, it must be interrupted once the context is done.
+	flow := NewDeviceFlow(DeviceFlowOpts{
+		HTTPClient: httpclient.New(ts.URL, httpclient.Context(ctx)),
+		Errout:     ioutil.Discard,
+		Logger:     &logrus.Logger{Out: ioutil.Discard},
+		Context:    ctx,
+	})
+
+	_, err := flow.Start(ProviderConfig{
+		DeviceAuthorizationEndpoint: "/device",
+		TokenEndpoint:               ts.URL + "/token",
+	})
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestDeviceFlowExternalProxy(t *testing.T) {
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		proxied = true
+		assert.Equal(t, "http://idp.example.com/token", req.URL.String())
+		w.Write([]byte(`{"id_token":"id-token"}`))
+	}))
+	defer proxy.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+		})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	flow := NewDeviceFlow(DeviceFlowOpts{
+		HTTPClient: httpclient.New(ts.URL),
+		Errout:     ioutil.Discard,
+		Logger:     &logrus.Logger{Out: ioutil.Discard},
+		Proxy:      proxy.URL,
+		EnvLookup: func(key string) (string, bool) {
+			return "", false
+		},
+	})
+	flow.sleep = func(ctx context.Context, d time.Duration) error {
+		return nil
+	}
+
+	idToken, err := flow.Start(ProviderConfig{
+		DeviceAuthorizationEndpoint: "/device",
+		TokenEndpoint:               "http://idp.example.com/token",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "id-token", idToken)
+	require.True(t, proxied)
+}
+
+func TestUseDeviceFlow(t *testing.T) {
+	deviceProvider := &Provider{
+		ClientMethod: methodBrowserOIDCToken,
+		Config: ProviderConfig{
+			DeviceAuthorizationEndpoint: "/device",
+			TokenEndpoint:               "/token",
+		},
+	}
+	browserProvider := &Provider{ClientMethod: methodBrowserOIDCToken}
+	credentialProvider := &Provider{ClientMethod: methodUserCredential}
+
+	logger := &logrus.Logger{Out: ioutil.Discard}
+	withDisplay := NewFlags(nil, func(key string) (string, bool) {
+		return ":0", key == "DISPLAY"
+	}, logger)
+	withoutDisplay := NewFlags(nil, func(key string) (string, bool) {
+		return "", false
+	}, logger)
+
+	require.False(t, withDisplay.useDeviceFlow(credentialProvider))
+	require.False(t, withDisplay.useDeviceFlow(browserProvider))
+	require.False(t, withDisplay.useDeviceFlow(deviceProvider))
+	require.False(t, withoutDisplay.useDeviceFlow(browserProvider))
+	require.Equal(t, !withoutDisplay.browserAvailable(), withoutDisplay.useDeviceFlow(deviceProvider))
+
+	withDisplay.SetDevice(true)
+	require.True(t, withDisplay.useDeviceFlow(deviceProvider))
+	require.True(t, withDisplay.useDeviceFlow(browserProvider))
+	require.False(t, withDisplay.useDeviceFlow(credentialProvider))
+}
+
+// newTestDeviceFlow returns a device flow with a fake clock, it also returns the recorded sleep durations.
+func newTestDeviceFlow(clusterURL string, errout io.Writer) (*DeviceFlow, *[]time.Duration) {
+	logger := &logrus.Logger{Out: ioutil.Discard}
+	flow := NewDeviceFlow(DeviceFlowOpts{
+		HTTPClient: httpclient.New(clusterURL),
+		Errout:     errout,
+		Logger:     logger,
+	})
+
+	var sleeps []time.Duration
+	clock := time.Now()
+	flow.now = func() time.Time {
+		return clock
+	}
+	flow.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		clock = clock.Add(d)
+		return ctx.Err()
+	}
+	return flow, &sleeps
+}
@@ -3,6 +3,7 @@ package login
 import (
 	"bytes"
 	"crypto/rsa"
+	"runtime"
 	"unicode"
 
 	"github.com/dcos/dcos-cli/pkg/fsutil"
@@ -23,6 +24,7 @@ type Flags struct {
 	passwordFile   string
 	privateKey     *rsa.PrivateKey
 	privateKeyFile string
+	device         bool
 }
 
 // NewFlags creates flags for a login flow.
@@ -66,9 +68,16 @@ func (f *Flags) Register(flags *pflag.FlagSet) {
 		"",
 		"Specify the path to a file that contains the service account private key.",
 	)
+	flags.BoolVar(
+		&f.device,
+		"device",
+		false,
+		"Log in with a code entered on another device, for environments without a browser.",
+	)
 }
 
 // Resolve resolves credentials from --password-env, --password-file and --private-key flags.
+// The private key can also be passed in PEM format through the DCOS_SERVICE_ACCOUNT_KEY env var.
 func (f *Flags) Resolve() error {
 	if f.passwordFile != "" {
 		rawPassword, err := fsutil.ReadSecureFile(f.fs, f.passwordFile)
@@ -93,11 +102,20 @@ func (f *Flags) Resolve() error {
 		}
 	}
 
+	var privateKeyPEM []byte
 	if f.privateKeyFile != "" {
-		privateKeyPEM, err := fsutil.ReadSecureFile(f.fs, f.privateKeyFile)
+		var err error
+		privateKeyPEM, err = fsutil.ReadSecureFile(f.fs, f.privateKeyFile)
 		if err != nil {
 			return err
 		}
+	} else if privateKey, ok := f.envLookup("DCOS_SERVICE_ACCOUNT_KEY"); ok {
+		privateKeyPEM = []byte(privateKey)
+		f.logger.Info("Read service account private key from environment.")
+	}
+
+	if len(privateKeyPEM) > 0 {
+		var err error
 		f.privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
 		if err != nil {
 			return err
@@ -111,6 +129,11 @@ func (f *Flags) SetProviderID(providerID string) {
 	f.providerID = providerID
 }
 
+// SetDevice sets whether or not to use the device authorization grant for OIDC providers.
+func (f *Flags) SetDevice(device bool) {
+	f.device = device
+}
+
 // Supports indicates whether or not a provider is supported based on the specified flags.
 func (f *Flags) Supports(provider *Provider) bool {
 	if provider.Type == DCOSUIDServiceKey {
@@ -120,3 +143,30 @@ func (f *Flags) Supports(provider *Provider) bool {
 	}
 	return f.privateKey == nil
 }
+
+// useDeviceFlow indicates whether or not the device authorization grant should be used for a provider.
+// It is used when the --device flag is passed, or when the provider supports it and no browser is available.
+func (f *Flags) useDeviceFlow(provider *Provider) bool {
+	if provider.ClientMethod != methodBrowserOIDCToken {
+		return false
+	}
+	if f.device {
+		return true
+	}
+	return provider.Config.SupportsDeviceFlow() && !f.browserAvailable()
+}
+
+// browserAvailable indicates whether or not a browser can be opened. On Linux and other
+// Unix systems, a graphical session is assumed to be required in order to open a browser.
+func (f *Flags) browserAvailable() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	}
+	for _, key := range []string{"DISPLAY", "WAYLAND_DISPLAY"} {
+		if val, ok := f.envLookup(key); ok && val != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,10 @@
 package login
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
 	"runtime"
 	"testing"
@@ -81,3 +85,25 @@ func TestResolvePasswordFromEnvVar(t *testing.T) {
 	require.Equal(t, logrus.InfoLevel, entry.Level)
 	require.Equal(t, "Read password from environment.", entry.Message)
 }
+
+func TestResolvePrivateKeyFromEnvVar(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	fs := afero.NewMemMapFs()
+	envLookup := func(key string) (string, bool) {
+		if key == "DCOS_SERVICE_ACCOUNT_KEY" {
+			return string(privateKeyPEM), true
+		}
+		return "", false
+	}
+	logger, _ := logrustest.NewNullLogger()
+	flags := NewFlags(fs, envLookup, logger)
+	require.NoError(t, flags.Resolve())
+	require.Equal(t, privateKey, flags.privateKey)
+}
@@ -1,6 +1,8 @@
 package login
 
 import (
+	"context"
+	"crypto/rsa"
 	"errors"
 	"fmt"
 	"io"
@@ -16,16 +18,27 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// serviceTokenLifetime is the lifetime of service account login tokens.
+const serviceTokenLifetime = 5 * time.Minute
+
 // FlowOpts are functional options for a Flow.
 type FlowOpts struct {
 	Errout io.Writer
 	Prompt *prompt.Prompt
 	Logger *logrus.Logger
 	Opener open.Opener
+
+	// Context and EnvLookup are used for requests which don't go through the cluster HTTP client,
+	// eg. to an external identity provider during a device login.
+	Context   context.Context
+	EnvLookup func(key string) (string, bool)
 }
 
 // Flow represents a login flow.
 type Flow struct {
+	ctx         context.Context
+	envLookup   func(key string) (string, bool)
+	proxy       string
 	client      *Client
 	errout      io.Writer
 	prompt      *prompt.Prompt
@@ -52,14 +65,25 @@ func NewFlow(opts FlowOpts) *Flow {
 	if opts.Opener == nil {
 		opts.Opener = open.NewOsOpener(opts.Logger)
 	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
 	return &Flow{
-		errout: opts.Errout,
-		prompt: opts.Prompt,
-		logger: opts.Logger,
-		opener: opts.Opener,
+		ctx:       opts.Context,
+		envLookup: opts.EnvLookup,
+		errout:    opts.Errout,
+		prompt:    opts.Prompt,
+		logger:    opts.Logger,
+		opener:    opts.Opener,
 	}
 }
 
+// SetProxy sets the proxy for requests which don't go through the cluster HTTP client,
+// it is usually the "core.proxy" of the cluster to log in to.
+func (f *Flow) SetProxy(proxy string) {
+	f.proxy = proxy
+}
+
 // Start initiates the login flow for a given set of flags and HTTP client.
 func (f *Flow) Start(flags *Flags, httpClient *httpclient.Client) (string, error) {
 	if err := flags.Resolve(); err != nil {
@@ -113,7 +137,14 @@ func (f *Flow) selectProvider(providers Providers) (*Provider, error) {
 		// Manual provider selection.
 		i, err := f.prompt.Select("Please select a login method:", providerCandidates)
 		if err != nil {
-			return nil, err
+			var ids []string
+			for _, provider := range providerCandidates {
+				ids = append(ids, provider.ID)
+			}
+			return nil, fmt.Errorf(
+				"couldn't select a login provider (%s), specify one with --provider: %s",
+				err, strings.Join(ids, ", "),
+			)
 		}
 		return providerCandidates[i], nil
 	}
@@ -126,25 +157,28 @@ func (f *Flow) triggerMethod(provider *Provider) (acsToken string, err error) {
 
 		// Read UID and password from command-line flags or prompt for them.
 		case methodCredential, methodUserCredential:
-			acsToken, err = f.client.Login(provider.Config.StartFlowURL, &Credentials{
-				UID:      f.uid(),
-				Password: f.password(),
-			})
-
-		// Read UID from the command-line flags and generate a service login
-		// token from the --private-key. The token has a 5 minutes lifetime.
-		case methodServiceCredential:
 			uid := f.uid()
-			token, err := f.serviceToken(uid)
+			var password string
+			password, err = f.password()
 			if err != nil {
 				return "", err
 			}
-			acsToken, err = f.client.Login("", &Credentials{UID: uid, Token: token})
+			acsToken, err = f.client.Login(provider.Config.StartFlowURL, &Credentials{
+				UID:      uid,
+				Password: password,
+			})
+
+		// Read UID from the command-line flags and log in with the service account private key.
+		case methodServiceCredential:
+			acsToken, err = f.ServiceAccountLogin(f.uid(), f.flags.privateKey)
 
 		// Open the browser at the `start_flow_url` location specified in the provider config.
 		// The user is then expected to continue the flow in the browser and copy paste the
 		// token from the browser to their terminal.
 		case methodBrowserAuthToken, methodBrowserOIDCToken:
+			if f.flags.useDeviceFlow(provider) {
+				return f.deviceLogin(provider)
+			}
 			if attempt == 1 {
 				if err := f.openBrowser(provider.Config.StartFlowURL); err != nil {
 					return "", err
@@ -173,6 +207,9 @@ func (f *Flow) triggerMethod(provider *Provider) (acsToken string, err error) {
 			} else {
 				acsToken = token
 			}
+
+		default:
+			return "", fmt.Errorf("unsupported login method '%s' for provider '%s'", provider.ClientMethod, provider.ID)
 		}
 		// In case of failure, let the user re-enter credentials 2 times.
 		if err != nil {
@@ -194,20 +231,56 @@ func (f *Flow) uid() string {
 }
 
 // password returns the password from the resolved flag or prompts the user for it.
-func (f *Flow) password() string {
+func (f *Flow) password() (string, error) {
 	if f.flags.password != "" {
-		return f.flags.password
+		return f.flags.password, nil
 	}
 	f.interactive = true
-	return f.prompt.Password("Password: ")
+	password, err := f.prompt.Password("Password: ")
+	if err == prompt.ErrPasswordRequired {
+		return "", errors.New("a password is required, pass it through --password-file or the DCOS_PASSWORD environment variable")
+	}
+	return password, err
+}
+
+// ServiceAccountLogin logs in as a service account. It generates a login token based
+// on the UID and private key of the service account and exchanges it for an ACS token.
+// The login token is signed with RS256 and has a 5 minutes lifetime.
+func (f *Flow) ServiceAccountLogin(uid string, privateKey *rsa.PrivateKey) (string, error) {
+	token, err := serviceToken(uid, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return f.client.Login("", &Credentials{UID: uid, Token: token})
 }
 
 // serviceToken generates a login token based on a UID / service account private key.
-func (f *Flow) serviceToken(uid string) (string, error) {
+func serviceToken(uid string, privateKey *rsa.PrivateKey) (string, error) {
 	return jwt.NewWithClaims(jwt.GetSigningMethod("RS256"), jwt.MapClaims{
 		"uid": uid,
-		"exp": time.Now().Add(5 * time.Minute).Unix(),
-	}).SignedString(f.flags.privateKey)
+		"exp": time.Now().Add(serviceTokenLifetime).Unix(),
+	}).SignedString(privateKey)
+}
+
+// deviceLogin logs in through the OAuth 2.0 device authorization grant,
+// the resulting OIDC ID token is then exchanged for an ACS token.
+func (f *Flow) deviceLogin(provider *Provider) (string, error) {
+	if !provider.Config.SupportsDeviceFlow() {
+		return "", fmt.Errorf("login provider '%s' doesn't support device login", provider.ID)
+	}
+	deviceFlow := NewDeviceFlow(DeviceFlowOpts{
+		HTTPClient: f.client.http,
+		Errout:     f.errout,
+		Logger:     f.logger,
+		Context:    f.ctx,
+		Proxy:      f.proxy,
+		EnvLookup:  f.envLookup,
+	})
+	idToken, err := deviceFlow.Start(provider.Config)
+	if err != nil {
+		return "", err
+	}
+	return f.client.Login("", &Credentials{Token: idToken})
 }
 
 // openBrowser opens the browser at a given start flow URL.
@@ -0,0 +1,162 @@
+package login
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/prompt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAccountLogin(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/acs/api/v1/auth/login", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+
+		var credentials Credentials
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&credentials))
+		assert.Equal(t, "bootstrapuser", credentials.UID)
+
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(credentials.Token, claims, func(token *jwt.Token) (interface{}, error) {
+			assert.Equal(t, "RS256", token.Method.Alg())
+			return &privateKey.PublicKey, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "bootstrapuser", claims["uid"])
+
+		exp := time.Unix(int64(claims["exp"].(float64)), 0)
+		assert.WithinDuration(t, time.Now().Add(serviceTokenLifetime), exp, 10*time.Second)
+
+		json.NewEncoder(w).Encode(&JWT{Token: "acsToken"})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	flow := NewFlow(FlowOpts{})
+	flow.client = NewClient(httpclient.New(ts.URL), &logrus.Logger{Out: ioutil.Discard})
+
+	acsToken, err := flow.ServiceAccountLogin("bootstrapuser", privateKey)
+	require.NoError(t, err)
+	require.Equal(t, "acsToken", acsToken)
+}
+
+func TestServiceAccountLoginWithClockSkew(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/acs/api/v1/auth/login", func(w http.ResponseWriter, req *http.Request) {
+		// Simulate a cluster whose clock is 1 hour ahead of the local one.
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(401)
+		w.Write([]byte(`{"title":"Unauthorized","description":"Token expired"}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	flow := NewFlow(FlowOpts{})
+	flow.client = NewClient(httpclient.New(ts.URL), &logrus.Logger{Out: ioutil.Discard})
+
+	_, err = flow.ServiceAccountLogin("bootstrapuser", privateKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "system clock")
+}
+
+func TestLoginProviderSelection(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/acs/api/v1/auth/providers", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{
+			"dcos-oidc": {"authentication-type": "oidc-implicit-flow", "description": "Corporate SSO"},
+			"dcos-users": {"authentication-type": "dcos-uid-password", "description": "Local admin"}
+		}`))
+	})
+	mux.HandleFunc("/acs/api/v1/auth/login", func(w http.ResponseWriter, req *http.Request) {
+		var credentials Credentials
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&credentials))
+		assert.Equal(t, "admin", credentials.UID)
+		assert.Equal(t, "secret", credentials.Password)
+		json.NewEncoder(w).Encode(&JWT{Token: "acsToken"})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	logger := &logrus.Logger{Out: ioutil.Discard}
+	newFlags := func() *Flags {
+		flags := NewFlags(afero.NewMemMapFs(), func(key string) (string, bool) { return "", false }, logger)
+		flags.username = "admin"
+		flags.password = "secret"
+		return flags
+	}
+
+	// The provider is picked from the interactive menu.
+	var out bytes.Buffer
+	flow := NewFlow(FlowOpts{
+		Prompt: prompt.New(strings.NewReader("2\n"), &out),
+		Logger: logger,
+	})
+	acsToken, err := flow.Start(newFlags(), httpclient.New(ts.URL))
+	require.NoError(t, err)
+	require.Equal(t, "acsToken", acsToken)
+	require.Contains(t, out.String(), "(2) Log in using a standard DC/OS user account")
+
+	// The provider is picked through the --provider flag, no menu is displayed.
+	out.Reset()
+	flags := newFlags()
+	flags.SetProviderID("dcos-users")
+	flow = NewFlow(FlowOpts{
+		Prompt: prompt.New(strings.NewReader(""), &out),
+		Logger: logger,
+	})
+	acsToken, err = flow.Start(flags, httpclient.New(ts.URL))
+	require.NoError(t, err)
+	require.Equal(t, "acsToken", acsToken)
+	require.Empty(t, out.String())
+
+	// Without a valid choice, the error hints at the --provider flag.
+	flow = NewFlow(FlowOpts{
+		Prompt: prompt.New(strings.NewReader(""), &out),
+		Logger: logger,
+	})
+	_, err = flow.Start(newFlags(), httpclient.New(ts.URL))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--provider: dcos-oidc, dcos-users")
+}
+
+func TestUnsupportedLoginMethod(t *testing.T) {
+	flow := NewFlow(FlowOpts{Logger: &logrus.Logger{Out: ioutil.Discard}})
+	flow.flags = &Flags{}
+	_, err := flow.triggerMethod(&Provider{ID: "custom", ClientMethod: "unknown"})
+	require.EqualError(t, err, "unsupported login method 'unknown' for provider 'custom'")
+}
+
+func TestLoginWithoutPassword(t *testing.T) {
+	var out bytes.Buffer
+	flow := NewFlow(FlowOpts{
+		Prompt: prompt.New(strings.NewReader(""), &out),
+		Logger: &logrus.Logger{Out: ioutil.Discard},
+	})
+	flow.flags = &Flags{username: "admin"}
+	_, err := flow.triggerMethod(&Provider{ID: "dcos-users", ClientMethod: methodUserCredential})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "a password is required")
+}
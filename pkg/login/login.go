@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/dcos/dcos-cli/pkg/dcos"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,6 +26,16 @@ type JWT struct {
 	Token string `json:"token"`
 }
 
+// User is a DC/OS user account, as returned by the IAM API.
+type User struct {
+	UID          string `json:"uid"`
+	Description  string `json:"description"`
+	IsService    bool   `json:"is_service"`
+	IsRemote     bool   `json:"is_remote"`
+	ProviderType string `json:"provider_type,omitempty"`
+	ProviderID   string `json:"provider_id,omitempty"`
+}
+
 // Client is able to detect available login providers and login to DC/OS.
 type Client struct {
 	http   *httpclient.Client
@@ -77,6 +90,19 @@ func (c *Client) Providers() (Providers, error) {
 	return providers, nil
 }
 
+// User returns the DC/OS user account with the given UID.
+func (c *Client) User(uid string) (*User, error) {
+	resp, err := c.http.Get("/acs/api/v1/users/"+url.PathEscape(uid), httpclient.FailOnErrStatus(true))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var user User
+	err = json.NewDecoder(resp.Body).Decode(&user)
+	return &user, err
+}
+
 // challengeAuth sends an unauthenticated HTTP request to a DC/OS well-known resource.
 // It then expects a 401 response with a WWW-Authenticate header containing the login method to use.
 // This method is used to determine which login provider is available when the /acs/api/v1/auth/providers
@@ -124,6 +150,13 @@ func (c *Client) Login(loginEndpoint string, credentials *Credentials) (string,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
+		// A service login token is rejected when it is already expired according to the cluster,
+		// this is most likely caused by clock skew between the local machine and the cluster.
+		if resp.StatusCode == 401 && credentials.Token != "" {
+			if err := checkClockSkew(resp, credentials.Token); err != nil {
+				return "", err
+			}
+		}
 		var apiError *dcos.Error
 		if err := json.NewDecoder(resp.Body).Decode(&apiError); err != nil {
 			return "", fmt.Errorf("couldn't log in")
@@ -137,3 +170,23 @@ func (c *Client) Login(loginEndpoint string, credentials *Credentials) (string,
 	}
 	return jwt.Token, nil
 }
+
+// checkClockSkew returns an error when the "exp" claim of a login token is
+// prior to the time sent by the cluster in the Date header of its response.
+func checkClockSkew(resp *http.Response, loginToken string) error {
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return nil
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(loginToken, claims); err != nil {
+		return nil
+	}
+	if claims.VerifyExpiresAt(serverTime.Unix(), false) {
+		return nil
+	}
+	return fmt.Errorf(
+		"login token is expired according to the cluster time (%s), please make sure that your system clock is synchronized",
+		serverTime.Format(time.RFC1123),
+	)
+}
@@ -57,6 +57,16 @@ func (provider *Provider) String() string {
 // ProviderConfig holds login provider specific configuration.
 type ProviderConfig struct {
 	StartFlowURL string `json:"start_flow_url"`
+
+	// These are only set by OIDC providers supporting the device authorization grant.
+	ClientID                    string `json:"client_id"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+// SupportsDeviceFlow indicates whether or not the provider supports the OAuth 2.0 device authorization grant.
+func (c ProviderConfig) SupportsDeviceFlow() bool {
+	return c.DeviceAuthorizationEndpoint != "" && c.TokenEndpoint != ""
 }
 
 // Providers is a map of providers as returned by the DC/OS API.
@@ -70,6 +80,9 @@ func (p *Providers) UnmarshalJSON(data []byte) error {
 	}
 	for id, provider := range providers {
 		provider.ID = id
+		if provider.ClientMethod == "" {
+			provider.ClientMethod = defaultClientMethod(provider.Type)
+		}
 	}
 	*p = providers
 	return nil
@@ -90,6 +103,21 @@ func (p Providers) Slice() []*Provider {
 	return providers
 }
 
+// defaultClientMethod returns the client method for an authentication type,
+// it is used for providers which don't specify their client method.
+func defaultClientMethod(providerType string) string {
+	switch providerType {
+	case DCOSUIDPassword, DCOSUIDPasswordLDAP:
+		return methodUserCredential
+	case DCOSUIDServiceKey:
+		return methodServiceCredential
+	case SAMLSpInitiated, OIDCAuthCodeFlow, OIDCImplicitFlow:
+		return methodBrowserOIDCToken
+	default:
+		return ""
+	}
+}
+
 func defaultDCOSUIDPasswordProvider() (provider *Provider) {
 	return &Provider{
 		ID:           "dcos-users",
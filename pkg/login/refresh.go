@@ -0,0 +1,161 @@
+package login
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultRefreshWindow is the default duration before the expiry of an ACS token during which it gets refreshed.
+const DefaultRefreshWindow = 10 * time.Minute
+
+// ErrTokenExpired indicates that the ACS token of a cluster is expired and can't be refreshed.
+var ErrTokenExpired = errors.New("token expired, run `dcos auth login`")
+
+// RefresherOpts are functional options for a Refresher.
+type RefresherOpts struct {
+	// Cluster is the cluster whose ACS token should be refreshed.
+	Cluster *config.Cluster
+
+	// Flags are the login flags used for non-interactive logins, they usually come from the environment.
+	Flags *Flags
+
+	// HTTPClient is the HTTP client used to log in. It shouldn't be configured with a Refresher hook.
+	HTTPClient *httpclient.Client
+
+	// Logger is the logger used by the refresher.
+	Logger *logrus.Logger
+
+	// Window is the duration before the token expiry during which the token gets refreshed.
+	// When not set it defaults to DefaultRefreshWindow.
+	Window time.Duration
+}
+
+// Refresher checks the ACS token of a cluster before HTTP requests are sent. The token is refreshed
+// when it is about to expire and credentials for a non-interactive service account login are available.
+type Refresher struct {
+	cluster    *config.Cluster
+	flags      *Flags
+	httpClient *httpclient.Client
+	logger     *logrus.Logger
+	window     time.Duration
+
+	mu           sync.Mutex
+	acsToken     string
+	refreshed    map[string]string
+	flagsErr     error
+	flagsDone    bool
+	refreshFails bool
+}
+
+// NewRefresher creates a new ACS token refresher.
+func NewRefresher(opts RefresherOpts) *Refresher {
+	if opts.Window == 0 {
+		opts.Window = DefaultRefreshWindow
+	}
+	return &Refresher{
+		cluster:    opts.Cluster,
+		flags:      opts.Flags,
+		httpClient: opts.HTTPClient,
+		logger:     opts.Logger,
+		window:     opts.Window,
+		acsToken:   opts.Cluster.ACSToken(),
+		refreshed:  make(map[string]string),
+	}
+}
+
+// BeforeRequest is an httpclient hook which checks the ACS token of the request.
+//
+// It only acts on requests authenticated with the cluster's ACS token, other requests are left untouched.
+// When the token expires within the refresh window, a service account login is attempted. On success,
+// the new token is persisted in the cluster's config and the request is updated accordingly. When the
+// token is already expired and can't be refreshed, ErrTokenExpired is returned.
+func (r *Refresher) BeforeRequest(req *http.Request) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "token=")
+	if token == "" {
+		return nil
+	}
+
+	// The token might have been refreshed by a previous request of the same HTTP client.
+	if newToken, ok := r.refreshed[token]; ok {
+		token = newToken
+		req.Header.Set("Authorization", "token="+token)
+	}
+
+	if token != r.acsToken {
+		return nil
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil || claims.Expiry().IsZero() {
+		return nil
+	}
+
+	expiry := claims.Expiry()
+	if time.Until(expiry) > r.window {
+		return nil
+	}
+
+	if !r.refreshFails && r.canRefresh() {
+		newToken, err := r.refresh()
+		if err == nil {
+			r.refreshed[token] = newToken
+			r.acsToken = newToken
+			req.Header.Set("Authorization", "token="+newToken)
+			return nil
+		}
+		r.refreshFails = true
+		r.logger.Warnf("Couldn't refresh the ACS token: %s", err)
+	}
+
+	if time.Now().After(expiry) {
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+// canRefresh indicates whether or not the flags allow a non-interactive service account login.
+func (r *Refresher) canRefresh() bool {
+	if r.flags == nil {
+		return false
+	}
+	if !r.flagsDone {
+		r.flagsErr = r.flags.Resolve()
+		r.flagsDone = true
+	}
+	return r.flagsErr == nil && r.flags.username != "" && r.flags.privateKey != nil
+}
+
+// refresh logs in with the service account credentials and persists the new ACS token.
+func (r *Refresher) refresh() (string, error) {
+	r.logger.Info("Refreshing the ACS token.")
+
+	token, err := serviceToken(r.flags.username, r.flags.privateKey)
+	if err != nil {
+		return "", err
+	}
+	acsToken, err := NewClient(r.httpClient, r.logger).Login("", &Credentials{
+		UID:   r.flags.username,
+		Token: token,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = r.cluster.Config().Update(func(conf *config.Config) {
+		r.cluster.SetACSToken(acsToken)
+	})
+	if err != nil {
+		return "", err
+	}
+	return acsToken, nil
+}
@@ -0,0 +1,122 @@
+package login
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dgrijalva/jwt-go"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresherRefreshesTokenWithinWindow(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	oldToken := newACSToken(t, time.Now().Add(time.Minute))
+	newToken := newACSToken(t, time.Now().Add(24*time.Hour))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/acs/api/v1/auth/login", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "", req.Header.Get("Authorization"))
+
+		var credentials Credentials
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&credentials))
+		assert.Equal(t, "ci-account", credentials.UID)
+		json.NewEncoder(w).Encode(&JWT{Token: newToken})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	env := map[string]string{
+		"DCOS_USERNAME":            "ci-account",
+		"DCOS_SERVICE_ACCOUNT_KEY": string(privateKeyPEM),
+	}
+	refresher, cluster := newTestRefresher(t, ts.URL, oldToken, env)
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "token="+oldToken)
+
+		require.NoError(t, refresher.BeforeRequest(req))
+		require.Equal(t, "token="+newToken, req.Header.Get("Authorization"))
+	}
+
+	// The new token must have been persisted.
+	conf := config.New(config.Opts{Fs: cluster.Config().Fs(), EnvLookup: func(string) (string, bool) { return "", false }})
+	require.NoError(t, conf.LoadPath(cluster.Config().Path()))
+	require.Equal(t, newToken, config.NewCluster(conf).ACSToken())
+}
+
+func TestRefresherExpiredToken(t *testing.T) {
+	expiredToken := newACSToken(t, time.Now().Add(-time.Minute))
+	refresher, _ := newTestRefresher(t, "https://dcos.example.com", expiredToken, nil)
+
+	req, err := http.NewRequest("GET", "https://dcos.example.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token="+expiredToken)
+
+	require.Equal(t, ErrTokenExpired, refresher.BeforeRequest(req))
+}
+
+func TestRefresherValidToken(t *testing.T) {
+	validToken := newACSToken(t, time.Now().Add(time.Hour))
+	refresher, _ := newTestRefresher(t, "https://dcos.example.com", validToken, nil)
+
+	req, err := http.NewRequest("GET", "https://dcos.example.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token="+validToken)
+
+	require.NoError(t, refresher.BeforeRequest(req))
+	require.Equal(t, "token="+validToken, req.Header.Get("Authorization"))
+}
+
+func newTestRefresher(t *testing.T, url, acsToken string, env map[string]string) (*Refresher, *config.Cluster) {
+	fs := afero.NewMemMapFs()
+	envLookup := func(key string) (string, bool) {
+		val, ok := env[key]
+		return val, ok
+	}
+	conf := config.New(config.Opts{Fs: fs, EnvLookup: envLookup})
+	conf.SetPath("/dcos/clusters/test/dcos.toml")
+	require.NoError(t, fs.MkdirAll("/dcos/clusters/test", 0755))
+
+	cluster := config.NewCluster(conf)
+	cluster.SetURL(url)
+	cluster.SetACSToken(acsToken)
+	require.NoError(t, conf.Persist())
+
+	logger, _ := logrustest.NewNullLogger()
+	refresher := NewRefresher(RefresherOpts{
+		Cluster:    cluster,
+		Flags:      NewFlags(fs, envLookup, logger),
+		HTTPClient: httpclient.New(url),
+		Logger:     logger,
+	})
+	return refresher, cluster
+}
+
+func newACSToken(t *testing.T, expiry time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid": "ci-account",
+		"exp": expiry.Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return token
+}
@@ -0,0 +1,45 @@
+package login
+
+import (
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// TokenClaims are the claims contained in a DC/OS ACS token.
+type TokenClaims struct {
+	UID string `json:"uid"`
+	jwt.StandardClaims
+}
+
+// ParseToken decodes the claims of an ACS token. The token signature is not verified as the CLI
+// doesn't have the cluster's public key, the claims must thus only be used for informational purposes.
+func ParseToken(token string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Expiry returns the expiry time of the token, it is the zero time when the token doesn't expire.
+func (c *TokenClaims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Subject returns the user the token authenticates as, it is the "uid" claim or the "sub" claim if absent.
+func (c *TokenClaims) Subject() string {
+	if c.UID != "" {
+		return c.UID
+	}
+	return c.StandardClaims.Subject
+}
+
+// Expired indicates whether or not the token is expired at a given time.
+func (c *TokenClaims) Expired(now time.Time) bool {
+	expiry := c.Expiry()
+	return !expiry.IsZero() && now.After(expiry)
+}
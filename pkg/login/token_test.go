@@ -0,0 +1,35 @@
+package login
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToken(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	claims, err := ParseToken(newACSToken(t, expiry))
+	require.NoError(t, err)
+	require.Equal(t, "ci-account", claims.UID)
+	require.Equal(t, expiry.Unix(), claims.Expiry().Unix())
+
+	_, err = ParseToken("not-a-jwt")
+	require.Error(t, err)
+}
+
+func TestTokenClaimsExpired(t *testing.T) {
+	now := time.Now()
+
+	claims, err := ParseToken(newACSToken(t, now.Add(-time.Minute)))
+	require.NoError(t, err)
+	require.True(t, claims.Expired(now))
+	require.Equal(t, "ci-account", claims.Subject())
+
+	claims, err = ParseToken(newACSToken(t, now.Add(time.Minute)))
+	require.NoError(t, err)
+	require.False(t, claims.Expired(now))
+
+	require.False(t, (&TokenClaims{}).Expired(now))
+}
@@ -0,0 +1,139 @@
+package mesos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Agent is a Mesos agent, as returned by the `/mesos/master/state` endpoint.
+type Agent struct {
+	ID                string                     `json:"id"`
+	Hostname          string                     `json:"hostname"`
+	PID               string                     `json:"pid"`
+	Active            bool                       `json:"active"`
+	Attributes        map[string]interface{}     `json:"attributes"`
+	ReservedResources map[string]json.RawMessage `json:"reserved_resources"`
+}
+
+// Address returns the "host:port" address of the agent, it is parsed from its libprocess PID
+// (eg. "slave(1)@10.0.0.1:5051" or "slave(1)@[2001:db8::2]:5051"). IPv6 hosts are bracketed
+// so that the address can be dialed as is.
+func (a *Agent) Address() (string, error) {
+	i := strings.LastIndex(a.PID, "@")
+	if i == -1 {
+		return "", fmt.Errorf("invalid PID '%s' for agent %s", a.PID, a.ID)
+	}
+	host, port, err := net.SplitHostPort(a.PID[i+1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid PID '%s' for agent %s: %s", a.PID, a.ID, err)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// Roles returns the roles the agent has reserved resources for, sorted by name.
+func (a *Agent) Roles() []string {
+	roles := make([]string, 0, len(a.ReservedResources))
+	for role := range a.ReservedResources {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Attribute returns the string representation of an agent attribute and whether or not it is set.
+// Scalar attributes are formatted without trailing zeros (eg. "2" rather than "2.000000").
+func (a *Agent) Attribute(key string) (string, bool) {
+	val, ok := a.Attributes[key]
+	if !ok {
+		return "", false
+	}
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// State is the state of the Mesos master, it only contains the fields used by the CLI.
+type State struct {
+	Agents     []Agent     `json:"slaves"`
+	Frameworks []Framework `json:"frameworks"`
+}
+
+// State returns the `/mesos/master/state` from the Mesos master.
+func (c *Client) State() (*State, error) {
+	resp, err := c.http.Get("/mesos/master/state")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+	}
+
+	var state State
+	err = json.NewDecoder(resp.Body).Decode(&state)
+	return &state, err
+}
+
+// FindAgent returns the agent with the given ID or hostname.
+func (s *State) FindAgent(idOrHostname string) (*Agent, error) {
+	// IPv6 hostnames can be given with brackets (eg. "[2001:db8::2]").
+	hostname := strings.TrimSuffix(strings.TrimPrefix(idOrHostname, "["), "]")
+	for i := range s.Agents {
+		if s.Agents[i].ID == idOrHostname || s.Agents[i].Hostname == hostname {
+			return &s.Agents[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no agent found with ID or hostname '%s'", idOrHostname)
+}
+
+// AgentFilter filters agents by attributes and role. An agent matches the filter when
+// it has all the attributes and has reserved resources for the role, if specified.
+type AgentFilter struct {
+	Attributes map[string]string
+	Role       string
+}
+
+// ParseAttributeFilter parses an attribute filter in the "key:value" format.
+func ParseAttributeFilter(filter string) (key, value string, err error) {
+	parts := strings.SplitN(filter, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", fmt.Errorf("invalid attribute filter '%s', expected key:value", filter)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Match indicates whether or not an agent matches the filter.
+func (f AgentFilter) Match(agent *Agent) bool {
+	for key, expectedVal := range f.Attributes {
+		if val, ok := agent.Attribute(key); !ok || val != expectedVal {
+			return false
+		}
+	}
+	if f.Role != "" {
+		if _, ok := agent.ReservedResources[f.Role]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterAgents returns the agents matching a filter.
+func FilterAgents(agents []Agent, filter AgentFilter) []Agent {
+	matches := []Agent{}
+	for i := range agents {
+		if filter.Match(&agents[i]) {
+			matches = append(matches, agents[i])
+		}
+	}
+	return matches
+}
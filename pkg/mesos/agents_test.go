@@ -0,0 +1,160 @@
+package mesos
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/require"
+)
+
+const stateJSON = `{
+  "slaves": [
+    {
+      "id": "agent-1",
+      "hostname": "10.0.0.1",
+      "active": true,
+      "attributes": {"rack": "r1", "zone": "us-east-1a", "gpus": 2},
+      "reserved_resources": {"slave_public": {"cpus": 4}}
+    },
+    {
+      "id": "agent-2",
+      "hostname": "10.0.0.2",
+      "active": true,
+      "attributes": {"rack": "r1", "zone": "us-east-1b"},
+      "reserved_resources": {}
+    },
+    {
+      "id": "agent-3",
+      "hostname": "10.0.0.3",
+      "active": false,
+      "attributes": {"rack": "r2"}
+    }
+  ]
+}`
+
+func TestFilterAgents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/mesos/master/state", r.URL.Path)
+		w.Write([]byte(stateJSON))
+	}))
+	defer ts.Close()
+
+	state, err := NewClient(httpclient.New(ts.URL)).State()
+	require.NoError(t, err)
+	require.Len(t, state.Agents, 3)
+
+	fixtures := []struct {
+		filter AgentFilter
+		ids    []string
+	}{
+		{AgentFilter{}, []string{"agent-1", "agent-2", "agent-3"}},
+		{AgentFilter{Attributes: map[string]string{"rack": "r1"}}, []string{"agent-1", "agent-2"}},
+		{AgentFilter{Attributes: map[string]string{"rack": "r1", "zone": "us-east-1b"}}, []string{"agent-2"}},
+		{AgentFilter{Attributes: map[string]string{"gpus": "2"}}, []string{"agent-1"}},
+		{AgentFilter{Role: "slave_public"}, []string{"agent-1"}},
+		{AgentFilter{Attributes: map[string]string{"rack": "r2"}, Role: "slave_public"}, []string{}},
+	}
+
+	for _, fixture := range fixtures {
+		ids := []string{}
+		for _, agent := range FilterAgents(state.Agents, fixture.filter) {
+			ids = append(ids, agent.ID)
+		}
+		require.Equal(t, fixture.ids, ids, fixture.filter)
+	}
+}
+
+func TestFindAgent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(stateJSON))
+	}))
+	defer ts.Close()
+
+	state, err := NewClient(httpclient.New(ts.URL)).State()
+	require.NoError(t, err)
+
+	agent, err := state.FindAgent("agent-2")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2", agent.Hostname)
+
+	agent, err = state.FindAgent("10.0.0.3")
+	require.NoError(t, err)
+	require.Equal(t, "agent-3", agent.ID)
+
+	_, err = state.FindAgent("agent-4")
+	require.EqualError(t, err, "no agent found with ID or hostname 'agent-4'")
+}
+
+func TestAgentAddress(t *testing.T) {
+	fixtures := []struct {
+		pid     string
+		address string
+		err     string
+	}{
+		{"slave(1)@10.0.0.1:5051", "10.0.0.1:5051", ""},
+		{"slave(1)@[2001:db8::2]:5051", "[2001:db8::2]:5051", ""},
+		{"slave(1)@[::1]:5051", "[::1]:5051", ""},
+		{"10.0.0.1:5051", "", "invalid PID '10.0.0.1:5051' for agent agent-1"},
+		{"slave(1)@2001:db8::2:5051", "", "invalid PID 'slave(1)@2001:db8::2:5051' for agent agent-1: address 2001:db8::2:5051: too many colons in address"},
+	}
+
+	for _, fixture := range fixtures {
+		agent := Agent{ID: "agent-1", PID: fixture.pid}
+		address, err := agent.Address()
+		if fixture.err != "" {
+			require.EqualError(t, err, fixture.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, fixture.address, address)
+
+		// The address must be dialable as is.
+		_, _, err = net.SplitHostPort(address)
+		require.NoError(t, err)
+	}
+}
+
+func TestAgentRoles(t *testing.T) {
+	agent := Agent{ReservedResources: map[string]json.RawMessage{
+		"slave_public": json.RawMessage(`{"cpus": 4}`),
+		"kafka":        json.RawMessage(`{"mem": 512}`),
+		"hdfs":         json.RawMessage(`{"disk": 1024}`),
+	}}
+	require.Equal(t, []string{"hdfs", "kafka", "slave_public"}, agent.Roles())
+	require.Empty(t, (&Agent{}).Roles())
+}
+
+func TestFindIPv6Agent(t *testing.T) {
+	state := &State{Agents: []Agent{
+		{ID: "agent-1", Hostname: "2001:db8::1", PID: "slave(1)@[2001:db8::1]:5051"},
+		{ID: "agent-2", Hostname: "2001:db8::2", PID: "slave(1)@[2001:db8::2]:5051"},
+	}}
+
+	agent, err := state.FindAgent("2001:db8::2")
+	require.NoError(t, err)
+	require.Equal(t, "agent-2", agent.ID)
+
+	address, err := agent.Address()
+	require.NoError(t, err)
+	require.Equal(t, "[2001:db8::2]:5051", address)
+
+	agent, err = state.FindAgent("[2001:db8::1]")
+	require.NoError(t, err)
+	require.Equal(t, "agent-1", agent.ID)
+}
+
+func TestParseAttributeFilter(t *testing.T) {
+	key, val, err := ParseAttributeFilter("zone:us-east-1:a")
+	require.NoError(t, err)
+	require.Equal(t, "zone", key)
+	require.Equal(t, "us-east-1:a", val)
+
+	for _, filter := range []string{"rack", ":r1", ""} {
+		_, _, err := ParseAttributeFilter(filter)
+		require.Error(t, err, filter)
+	}
+}
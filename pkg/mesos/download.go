@@ -0,0 +1,144 @@
+package mesos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/fsutil"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/progress"
+	"github.com/spf13/afero"
+)
+
+// FileInfo describes a file in a sandbox, as returned by the files API of a Mesos agent.
+type FileInfo struct {
+	Path  string  `json:"path"`
+	Mode  string  `json:"mode"`
+	Size  int64   `json:"size"`
+	MTime float64 `json:"mtime"`
+	UID   string  `json:"uid"`
+	GID   string  `json:"gid"`
+	NLink int     `json:"nlink"`
+}
+
+// IsDir indicates whether or not the file is a directory.
+func (f *FileInfo) IsDir() bool {
+	return strings.HasPrefix(f.Mode, "d")
+}
+
+// FileNotFoundError is returned when a path doesn't exist on a Mesos agent.
+type FileNotFoundError struct {
+	Path string
+}
+
+// Error implements the error interface.
+func (e *FileNotFoundError) Error() string {
+	return fmt.Sprintf("'%s' doesn't exist, the task sandbox might have been garbage collected", e.Path)
+}
+
+// BrowseDir lists the files in a sandbox directory through the files API of a Mesos agent.
+// When the path is a file, the list only contains the file itself.
+func (c *Client) BrowseDir(ctx context.Context, agentID, dir string) ([]FileInfo, error) {
+	resp, err := c.filesRequest(ctx, agentID, "browse", dir)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var files []FileInfo
+	err = json.NewDecoder(resp.Body).Decode(&files)
+	return files, err
+}
+
+// Download downloads a file or recursively a directory from a sandbox into a local directory.
+// The file or directory is created in the output directory under its base name, the structure
+// of a directory is preserved. Files are streamed to the disk as they are downloaded.
+func (c *Client) Download(ctx context.Context, agentID, sandboxPath string, fs afero.Fs, outputDir string) error {
+	sandboxPath = path.Clean(sandboxPath)
+	files, err := c.BrowseDir(ctx, agentID, sandboxPath)
+	if err != nil {
+		return err
+	}
+
+	name, err := localName(sandboxPath)
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(outputDir, name)
+
+	// Browsing a file returns a list with the file itself, otherwise the path is a directory.
+	if len(files) == 1 && path.Clean(files[0].Path) == sandboxPath && !files[0].IsDir() {
+		return c.downloadFile(ctx, agentID, sandboxPath, fs, dest)
+	}
+
+	if err := fs.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			err = c.Download(ctx, agentID, file.Path, fs, dest)
+		} else if name, err = localName(file.Path); err == nil {
+			err = c.downloadFile(ctx, agentID, file.Path, fs, filepath.Join(dest, name))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// localName returns the name under which a sandbox file is created locally, that is its base name.
+// Names which would resolve outside of the destination directory, such as "..", are rejected.
+func localName(sandboxPath string) (string, error) {
+	name := path.Base(path.Clean(sandboxPath))
+	if name == "." || name == ".." || name == "/" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid sandbox path '%s'", sandboxPath)
+	}
+	return name, nil
+}
+
+// downloadFile streams a file from a sandbox to a local destination.
+func (c *Client) downloadFile(ctx context.Context, agentID, sandboxPath string, fs afero.Fs, dest string) error {
+	resp, err := c.filesRequest(ctx, agentID, "download", sandboxPath)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if c.progressOut == nil {
+		return fsutil.CopyReader(fs, resp.Body, dest, 0644)
+	}
+	progressReader := progress.NewReader(resp.Body, c.progressOut, "Downloading "+path.Base(sandboxPath), resp.ContentLength)
+	defer progressReader.Done()
+	return fsutil.CopyReader(fs, progressReader, dest, 0644)
+}
+
+// filesRequest sends a request to an endpoint of the files API of a Mesos agent for a given path.
+func (c *Client) filesRequest(ctx context.Context, agentID, endpoint, sandboxPath string) (*http.Response, error) {
+	query := url.Values{}
+	query.Set("path", sandboxPath)
+
+	resp, err := c.http.Get(
+		"/agent/"+url.PathEscape(agentID)+"/files/"+endpoint+"?"+query.Encode(),
+		httpclient.Context(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp, nil
+	case 404:
+		resp.Body.Close()
+		return nil, &FileNotFoundError{Path: sandboxPath}
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+	}
+}
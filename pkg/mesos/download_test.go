@@ -0,0 +1,133 @@
+package mesos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSandbox serves files through a fake Mesos files API, directories are implied by file paths.
+type fakeSandbox map[string]string
+
+func (s fakeSandbox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Query().Get("path")
+	switch r.URL.Path {
+	case "/agent/agent-1/files/download":
+		content, ok := s[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	case "/agent/agent-1/files/browse":
+		if content, ok := s[p]; ok {
+			json.NewEncoder(w).Encode([]FileInfo{{Path: p, Mode: "-rw-r--r--", Size: int64(len(content))}})
+			return
+		}
+		var files []FileInfo
+		dirs := make(map[string]bool)
+		for filePath, content := range s {
+			if !strings.HasPrefix(filePath, p+"/") {
+				continue
+			}
+			rel := strings.TrimPrefix(filePath, p+"/")
+			if i := strings.Index(rel, "/"); i >= 0 {
+				if dir := path.Join(p, rel[:i]); !dirs[dir] {
+					dirs[dir] = true
+					files = append(files, FileInfo{Path: dir, Mode: "drwxr-xr-x"})
+				}
+				continue
+			}
+			files = append(files, FileInfo{Path: filePath, Mode: "-rw-r--r--", Size: int64(len(content))})
+		}
+		if len(files) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(files)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	sandbox := fakeSandbox{
+		"/sandbox/stdout":            "hello\n",
+		"/sandbox/conf/app.toml":     "debug = true\n",
+		"/sandbox/conf/tls/cert.pem": "cert\n",
+	}
+	ts := httptest.NewServer(sandbox)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	// Download a single file.
+	fs := afero.NewMemMapFs()
+	require.NoError(t, client.Download(context.Background(), "agent-1", "/sandbox/stdout", fs, "/out"))
+	content, err := afero.ReadFile(fs, "/out/stdout")
+	require.NoError(t, err)
+	require.Equal(t, "hello\n", string(content))
+
+	// Download a directory recursively.
+	fs = afero.NewMemMapFs()
+	require.NoError(t, client.Download(context.Background(), "agent-1", "/sandbox/conf/", fs, "/out"))
+	for localPath, expectedContent := range map[string]string{
+		"/out/conf/app.toml":     "debug = true\n",
+		"/out/conf/tls/cert.pem": "cert\n",
+	} {
+		content, err := afero.ReadFile(fs, localPath)
+		require.NoError(t, err)
+		require.Equal(t, expectedContent, string(content))
+	}
+
+	// Download an unexisting path.
+	err = client.Download(context.Background(), "agent-1", "/sandbox/stderr", afero.NewMemMapFs(), "/out")
+	require.IsType(t, &FileNotFoundError{}, err)
+	require.Contains(t, err.Error(), "garbage collected")
+}
+
+func TestDownloadInvalidPath(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]FileInfo{
+			{Path: "/sandbox/stdout", Mode: "-rw-r--r--"},
+			{Path: "/sandbox/..", Mode: "-rw-r--r--"},
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	for _, sandboxPath := range []string{"/", "/sandbox/.."} {
+		err := client.Download(context.Background(), "agent-1", sandboxPath, afero.NewMemMapFs(), "/out")
+		require.EqualError(t, err, "invalid sandbox path '/'")
+	}
+
+	err := client.Download(context.Background(), "agent-1", "/sandbox", afero.NewMemMapFs(), "/out")
+	require.EqualError(t, err, "invalid sandbox path '/sandbox/..'")
+}
+
+func TestLocalName(t *testing.T) {
+	for sandboxPath, expectedName := range map[string]string{
+		"/sandbox/stdout":   "stdout",
+		"/sandbox/conf/":    "conf",
+		"stdout":            "stdout",
+		"/sandbox/./stdout": "stdout",
+	} {
+		name, err := localName(sandboxPath)
+		require.NoError(t, err)
+		require.Equal(t, expectedName, name)
+	}
+
+	for _, sandboxPath := range []string{"", ".", "..", "/", "/sandbox/..", `/sandbox/..\..`} {
+		_, err := localName(sandboxPath)
+		require.EqualError(t, err, "invalid sandbox path '"+sandboxPath+"'")
+	}
+}
@@ -0,0 +1,323 @@
+package mesos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// ExecOpts are options to run a command inside the container of a task.
+type ExecOpts struct {
+	// AgentID is the ID of the agent the task is running on.
+	AgentID string
+
+	// ContainerID is the ID of the container of the task, along with its parents,
+	// as returned by TaskContainer.
+	ContainerID *ContainerID
+
+	// Command is the command to run, along with its arguments.
+	Command string
+	Args    []string
+
+	// TTY allocates a pseudo-terminal for the command, its output is then only written to Stdout.
+	TTY bool
+
+	// Stdin is forwarded to the command when set.
+	Stdin io.Reader
+
+	// Stdout and Stderr receive the output of the command.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// agentCall is a call to the Mesos agent operator API.
+type agentCall struct {
+	Type                         string                        `json:"type"`
+	LaunchNestedContainerSession *launchNestedContainerSession `json:"launch_nested_container_session,omitempty"`
+	AttachContainerInput         *attachContainerInput         `json:"attach_container_input,omitempty"`
+	WaitNestedContainer          *waitNestedContainer          `json:"wait_nested_container,omitempty"`
+}
+
+type launchNestedContainerSession struct {
+	ContainerID *ContainerID   `json:"container_id"`
+	Command     commandInfo    `json:"command"`
+	Container   *containerInfo `json:"container,omitempty"`
+}
+
+type commandInfo struct {
+	Shell     bool     `json:"shell"`
+	Value     string   `json:"value"`
+	Arguments []string `json:"arguments"`
+}
+
+type containerInfo struct {
+	Type    string    `json:"type"`
+	TTYInfo *struct{} `json:"tty_info,omitempty"`
+}
+
+type attachContainerInput struct {
+	Type        string       `json:"type"`
+	ContainerID *ContainerID `json:"container_id,omitempty"`
+	ProcessIO   *processIO   `json:"process_io,omitempty"`
+}
+
+type waitNestedContainer struct {
+	ContainerID *ContainerID `json:"container_id"`
+}
+
+// processIO is a message carrying the input or output of a process.
+type processIO struct {
+	Type string         `json:"type"`
+	Data *processIOData `json:"data,omitempty"`
+}
+
+// processIOData is the data of a process stream (STDIN, STDOUT, or STDERR).
+type processIOData struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// Exec runs a command inside the container of a task and returns its exit code.
+//
+// The command is launched in a nested container session through the Mesos agent operator API,
+// its output is streamed as RecordIO records until it terminates. When Stdin is set, it is
+// forwarded to the command through a separate streaming request.
+func (c *Client) Exec(ctx context.Context, opts ExecOpts) (int, error) {
+	id := &ContainerID{Value: newContainerID(), Parent: opts.ContainerID}
+
+	launch := &launchNestedContainerSession{
+		ContainerID: id,
+		Command: commandInfo{
+			Value:     opts.Command,
+			Arguments: append([]string{opts.Command}, opts.Args...),
+		},
+	}
+	if opts.TTY {
+		launch.Container = &containerInfo{Type: "MESOS", TTYInfo: &struct{}{}}
+	}
+
+	resp, err := c.agentCall(ctx, opts.AgentID, &agentCall{
+		Type:                         "LAUNCH_NESTED_CONTAINER_SESSION",
+		LaunchNestedContainerSession: launch,
+	}, httpclient.Header("Accept", "application/recordio"), httpclient.Header("Message-Accept", "application/json"))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// The output ends once the command terminates, the input request is then aborted
+	// as the input might never be fully consumed (eg. an interactive stdin).
+	inputCtx, cancelInput := context.WithCancel(ctx)
+	defer cancelInput()
+
+	inputErr := make(chan error, 1)
+	if opts.Stdin != nil {
+		go func() {
+			err := c.attachInput(inputCtx, opts.AgentID, id, opts.Stdin)
+			if inputCtx.Err() != nil {
+				err = nil
+			}
+			inputErr <- err
+		}()
+	} else {
+		inputErr <- nil
+	}
+
+	outputErr := streamOutput(resp.Body, opts.Stdout, opts.Stderr)
+	cancelInput()
+	if err := <-inputErr; err != nil && outputErr == nil {
+		outputErr = err
+	}
+	if outputErr != nil {
+		return 0, outputErr
+	}
+	return c.waitNestedContainer(ctx, opts.AgentID, id)
+}
+
+// attachInput forwards an input to a nested container, each chunk of data is sent as a RecordIO record.
+// An empty record is sent once the input is consumed, in order to signal EOF to the process.
+func (c *Client) attachInput(ctx context.Context, agentID string, id *ContainerID, input io.Reader) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeInput(pw, id, input))
+	}()
+
+	// The transport doesn't return from an aborted request until it is done reading its body,
+	// which might block on the input. The body is thus closed once the context is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			pr.CloseWithError(ctx.Err())
+		case <-done:
+		}
+	}()
+
+	resp, err := c.http.Post(
+		agentAPIPath(agentID),
+		"application/recordio",
+		pr,
+		httpclient.Header("Message-Content-Type", "application/json"),
+		httpclient.Context(ctx),
+		httpclient.Timeout(0),
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkAgentResponse(resp.StatusCode, resp.Body)
+}
+
+// writeInput writes the RecordIO records attaching an input to a nested container.
+func writeInput(w io.Writer, id *ContainerID, input io.Reader) error {
+	send := func(msg *attachContainerInput) error {
+		record, err := json.Marshal(&agentCall{Type: "ATTACH_CONTAINER_INPUT", AttachContainerInput: msg})
+		if err != nil {
+			return err
+		}
+		return writeRecord(w, record)
+	}
+	sendData := func(data []byte) error {
+		return send(&attachContainerInput{
+			Type:      "PROCESS_IO",
+			ProcessIO: &processIO{Type: "DATA", Data: &processIOData{Type: "STDIN", Data: data}},
+		})
+	}
+
+	if err := send(&attachContainerInput{Type: "CONTAINER_ID", ContainerID: id}); err != nil {
+		return err
+	}
+	buf := make([]byte, 4096)
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			if err := sendData(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return sendData([]byte{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// streamOutput writes the output of a nested container session to stdout and stderr.
+func streamOutput(body io.Reader, stdout, stderr io.Writer) error {
+	r := bufio.NewReader(body)
+	for {
+		record, err := readRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var msg processIO
+		if err := json.Unmarshal(record, &msg); err != nil {
+			return err
+		}
+		if msg.Type != "DATA" || msg.Data == nil {
+			continue
+		}
+		out := stdout
+		if msg.Data.Type == "STDERR" {
+			out = stderr
+		}
+		if out == nil {
+			continue
+		}
+		if _, err := out.Write(msg.Data.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// waitNestedContainer waits for a nested container to terminate and returns its exit code.
+func (c *Client) waitNestedContainer(ctx context.Context, agentID string, id *ContainerID) (int, error) {
+	resp, err := c.agentCall(ctx, agentID, &agentCall{
+		Type:                "WAIT_NESTED_CONTAINER",
+		WaitNestedContainer: &waitNestedContainer{ContainerID: id},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		WaitNestedContainer struct {
+			ExitStatus *int `json:"exit_status"`
+		} `json:"wait_nested_container"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	if result.WaitNestedContainer.ExitStatus == nil {
+		return 0, fmt.Errorf("no exit status for container %s", id.Value)
+	}
+	return exitCode(*result.WaitNestedContainer.ExitStatus), nil
+}
+
+// agentCall sends a JSON call to the operator API of a Mesos agent.
+func (c *Client) agentCall(ctx context.Context, agentID string, call *agentCall, opts ...httpclient.Option) (*http.Response, error) {
+	body, err := json.Marshal(call)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, httpclient.Context(ctx), httpclient.Timeout(0))
+	resp, err := c.http.Post(agentAPIPath(agentID), "application/json", bytes.NewReader(body), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAgentResponse(resp.StatusCode, resp.Body); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// checkAgentResponse returns an error including the response body for non-200 status codes.
+func checkAgentResponse(statusCode int, body io.Reader) error {
+	if statusCode == 200 {
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(body, 4096))
+	if len(msg) > 0 {
+		return fmt.Errorf("HTTP %d error: %s", statusCode, bytes.TrimSpace(msg))
+	}
+	return fmt.Errorf("HTTP %d error", statusCode)
+}
+
+// agentAPIPath returns the path to the operator API of a Mesos agent.
+func agentAPIPath(agentID string) string {
+	return "/agent/" + url.PathEscape(agentID) + "/api/v1"
+}
+
+// exitCode converts a wait status, as returned by Mesos, into an exit code.
+// Processes terminated by a signal get the 128+signal exit code, as in shells.
+func exitCode(status int) int {
+	if signal := status & 0x7f; signal != 0 {
+		return 128 + signal
+	}
+	return (status >> 8) & 0xff
+}
+
+// newContainerID generates a random ID for a nested container.
+func newContainerID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,252 @@
+package mesos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordIO(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRecord(&buf, []byte("hello")))
+	require.NoError(t, writeRecord(&buf, []byte{}))
+	require.Equal(t, "5\nhello0\n", buf.String())
+
+	r := bufio.NewReader(&buf)
+	record, err := readRecord(r)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(record))
+
+	record, err = readRecord(r)
+	require.NoError(t, err)
+	require.Empty(t, record)
+
+	_, err = readRecord(r)
+	require.Equal(t, io.EOF, err)
+
+	_, err = readRecord(bufio.NewReader(strings.NewReader("10\nhello")))
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = readRecord(bufio.NewReader(strings.NewReader("abc\n")))
+	require.Error(t, err)
+}
+
+func TestExec(t *testing.T) {
+	var stdin bytes.Buffer
+	inputDone := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/agent/agent-1/api/v1", r.URL.Path)
+
+		if r.Header.Get("Content-Type") == "application/recordio" {
+			defer close(inputDone)
+			body := bufio.NewReader(r.Body)
+			for {
+				record, err := readRecord(body)
+				if err != nil {
+					return
+				}
+				var call agentCall
+				assert.NoError(t, json.Unmarshal(record, &call))
+				assert.Equal(t, "ATTACH_CONTAINER_INPUT", call.Type)
+				if call.AttachContainerInput.Type == "PROCESS_IO" {
+					stdin.Write(call.AttachContainerInput.ProcessIO.Data.Data)
+				}
+			}
+		}
+
+		var call agentCall
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+		switch call.Type {
+		case "LAUNCH_NESTED_CONTAINER_SESSION":
+			launch := call.LaunchNestedContainerSession
+			assert.Equal(t, "container-1", launch.ContainerID.Parent.Value)
+			assert.Equal(t, "cat", launch.Command.Value)
+			assert.Equal(t, []string{"cat", "-n"}, launch.Command.Arguments)
+			assert.Nil(t, launch.Container)
+			assert.Equal(t, "application/recordio", r.Header.Get("Accept"))
+
+			for _, msg := range []processIO{
+				{Type: "DATA", Data: &processIOData{Type: "STDOUT", Data: []byte("out")}},
+				{Type: "CONTROL"},
+				{Type: "DATA", Data: &processIOData{Type: "STDERR", Data: []byte("err")}},
+			} {
+				record, _ := json.Marshal(&msg)
+				writeRecord(w, record)
+			}
+			w.(http.Flusher).Flush()
+
+			// Wait for the input to be consumed, as the remote command would before terminating.
+			<-inputDone
+		case "WAIT_NESTED_CONTAINER":
+			w.Write([]byte(`{"type":"WAIT_NESTED_CONTAINER","wait_nested_container":{"exit_status":768}}`))
+		default:
+			w.WriteHeader(400)
+		}
+	}))
+	defer ts.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := NewClient(httpclient.New(ts.URL)).Exec(context.Background(), ExecOpts{
+		AgentID:     "agent-1",
+		ContainerID: &ContainerID{Value: "container-1"},
+		Command:     "cat",
+		Args:        []string{"-n"},
+		Stdin:       strings.NewReader("input"),
+		Stdout:      &stdout,
+		Stderr:      &stderr,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+	require.Equal(t, "out", stdout.String())
+	require.Equal(t, "err", stderr.String())
+	require.Equal(t, "input", stdin.String())
+}
+
+func TestExecPodTask(t *testing.T) {
+	taskContainerID := &ContainerID{Value: "ct-web", Parent: &ContainerID{Value: "ct-pod"}}
+
+	var calls []string
+	var mu sync.Mutex
+	inputAttached := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call agentCall
+		if r.Header.Get("Content-Type") == "application/recordio" {
+			record, err := readRecord(bufio.NewReader(r.Body))
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(record, &call))
+			defer close(inputAttached)
+		} else {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+		}
+
+		// The nested container session is launched under the full container chain of the task.
+		var id *ContainerID
+		switch call.Type {
+		case "LAUNCH_NESTED_CONTAINER_SESSION":
+			id = call.LaunchNestedContainerSession.ContainerID
+			record, _ := json.Marshal(&processIO{Type: "CONTROL"})
+			writeRecord(w, record)
+			w.(http.Flusher).Flush()
+
+			// Wait for the input to be attached, as the remote command would before terminating.
+			defer func() { <-inputAttached }()
+		case "ATTACH_CONTAINER_INPUT":
+			id = call.AttachContainerInput.ContainerID
+		case "WAIT_NESTED_CONTAINER":
+			id = call.WaitNestedContainer.ContainerID
+			w.Write([]byte(`{"type":"WAIT_NESTED_CONTAINER","wait_nested_container":{"exit_status":0}}`))
+		}
+		if assert.NotNil(t, id) {
+			assert.NotEmpty(t, id.Value)
+			assert.Equal(t, taskContainerID, id.Parent)
+		}
+
+		mu.Lock()
+		calls = append(calls, call.Type)
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	exitCode, err := NewClient(httpclient.New(ts.URL)).Exec(context.Background(), ExecOpts{
+		AgentID:     "agent-1",
+		ContainerID: taskContainerID,
+		Command:     "true",
+		Stdin:       strings.NewReader("input"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, exitCode)
+	require.ElementsMatch(t, []string{"LAUNCH_NESTED_CONTAINER_SESSION", "ATTACH_CONTAINER_INPUT", "WAIT_NESTED_CONTAINER"}, calls)
+}
+
+func TestExecAbortsInput(t *testing.T) {
+	inputStarted := make(chan struct{})
+	inputDone := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/recordio" {
+			// Consume the input until the client aborts the request.
+			defer close(inputDone)
+			close(inputStarted)
+			io.Copy(ioutil.Discard, r.Body)
+			return
+		}
+
+		var call agentCall
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+		switch call.Type {
+		case "LAUNCH_NESTED_CONTAINER_SESSION":
+			record, _ := json.Marshal(&processIO{Type: "DATA", Data: &processIOData{Type: "STDOUT", Data: []byte("out")}})
+			writeRecord(w, record)
+			w.(http.Flusher).Flush()
+
+			// The command terminates without reading its input.
+			<-inputStarted
+		case "WAIT_NESTED_CONTAINER":
+			w.Write([]byte(`{"type":"WAIT_NESTED_CONTAINER","wait_nested_container":{"exit_status":0}}`))
+		}
+	}))
+	defer ts.Close()
+
+	// The input is never closed, as an interactive stdin.
+	stdin, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	var stdout bytes.Buffer
+	exitCode, err := NewClient(httpclient.New(ts.URL)).Exec(context.Background(), ExecOpts{
+		AgentID:     "agent-1",
+		ContainerID: &ContainerID{Value: "container-1"},
+		Command:     "true",
+		Stdin:       stdin,
+		Stdout:      &stdout,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, exitCode)
+	require.Equal(t, "out", stdout.String())
+
+	select {
+	case <-inputDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the input request hasn't been aborted")
+	}
+}
+
+func TestExecInputError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/recordio" {
+			w.WriteHeader(500)
+			w.Write([]byte("Failed to attach the input"))
+			return
+		}
+		// The output ends once the command terminates, after the input failed.
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(httpclient.New(ts.URL)).Exec(context.Background(), ExecOpts{
+		AgentID:     "agent-1",
+		ContainerID: &ContainerID{Value: "container-1"},
+		Command:     "cat",
+		Stdin:       strings.NewReader("input"),
+	})
+	require.EqualError(t, err, "HTTP 500 error: Failed to attach the input")
+}
+
+func TestExitCode(t *testing.T) {
+	require.Equal(t, 0, exitCode(0))
+	require.Equal(t, 1, exitCode(256))
+	require.Equal(t, 137, exitCode(9))
+}
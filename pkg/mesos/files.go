@@ -0,0 +1,249 @@
+package mesos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+const (
+	// readLength is the maximum length of a chunk read through the Mesos files API.
+	readLength = 64 * 1024
+
+	// defaultPollInterval is the interval between reads when following a file.
+	defaultPollInterval = time.Second
+)
+
+// FileChunk is a chunk of a file read through the Mesos files API.
+type FileChunk struct {
+	Data   string `json:"data"`
+	Offset int64  `json:"offset"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Mesos writes the bytes of a file as they are in the data string, it only escapes quotes,
+// backslashes and control characters. The data is thus decoded byte for byte rather than as
+// UTF-8 text, which would replace invalid bytes and make offsets drift from those of the file.
+func (chunk *FileChunk) UnmarshalJSON(b []byte) error {
+	var rawChunk struct {
+		Data   json.RawMessage `json:"data"`
+		Offset int64           `json:"offset"`
+	}
+	if err := json.Unmarshal(b, &rawChunk); err != nil {
+		return err
+	}
+	chunk.Offset = rawChunk.Offset
+	chunk.Data = ""
+	if len(rawChunk.Data) == 0 || string(rawChunk.Data) == "null" {
+		return nil
+	}
+	data, err := decodeFileData(rawChunk.Data)
+	if err != nil {
+		return err
+	}
+	chunk.Data = string(data)
+	return nil
+}
+
+// decodeFileData decodes a JSON string written by Mesos into the bytes it holds.
+// Escaped code points up to U+00FF are single bytes, others are encoded as UTF-8.
+func decodeFileData(raw []byte) ([]byte, error) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return nil, errors.New("invalid file data")
+	}
+	raw = raw[1 : len(raw)-1]
+
+	data := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			data = append(data, raw[i])
+			continue
+		}
+		i++
+		if i == len(raw) {
+			return nil, errors.New("invalid file data")
+		}
+		switch raw[i] {
+		case '"', '\\', '/':
+			data = append(data, raw[i])
+		case 'b':
+			data = append(data, '\b')
+		case 'f':
+			data = append(data, '\f')
+		case 'n':
+			data = append(data, '\n')
+		case 'r':
+			data = append(data, '\r')
+		case 't':
+			data = append(data, '\t')
+		case 'u':
+			if i+4 >= len(raw) {
+				return nil, errors.New("invalid file data")
+			}
+			r, err := strconv.ParseUint(string(raw[i+1:i+5]), 16, 16)
+			if err != nil {
+				return nil, errors.New("invalid file data")
+			}
+			i += 4
+			if r <= 0xff {
+				data = append(data, byte(r))
+				continue
+			}
+			var buf [utf8.UTFMax]byte
+			n := utf8.EncodeRune(buf[:], rune(r))
+			data = append(data, buf[:n]...)
+		default:
+			return nil, errors.New("invalid file data")
+		}
+	}
+	return data, nil
+}
+
+// TailOpts are options to tail a file through the Mesos files API.
+type TailOpts struct {
+	// Lines is the number of lines to print from the end of the file, the whole file is printed when negative.
+	Lines int
+
+	// Follow indicates whether new content should be streamed as it is appended to the file.
+	Follow bool
+
+	// PollInterval is the interval between reads when following the file, it defaults to 1 second.
+	PollInterval time.Duration
+}
+
+// ReadFile reads a chunk of a file in a sandbox through the files API of a Mesos agent.
+// When offset is -1, the returned chunk is empty and its offset is the size of the file.
+func (c *Client) ReadFile(ctx context.Context, agentID, path string, offset, length int64) (*FileChunk, error) {
+	query := url.Values{}
+	query.Set("path", path)
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	if length >= 0 {
+		query.Set("length", strconv.FormatInt(length, 10))
+	}
+
+	resp, err := c.http.Get(
+		"/agent/"+url.PathEscape(agentID)+"/files/read?"+query.Encode(),
+		httpclient.Context(ctx),
+		httpclient.FailOnErrStatus(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var chunk FileChunk
+	err = json.NewDecoder(resp.Body).Decode(&chunk)
+	return &chunk, err
+}
+
+// TailFile writes the end of a file in a sandbox to w, according to the tail options.
+//
+// When following the file, it polls for new content until the context is done, in which case it
+// returns without error. A file getting shorter than the current offset is considered rotated,
+// it is then read again from the beginning.
+func (c *Client) TailFile(ctx context.Context, agentID, path string, opts TailOpts, w io.Writer) error {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+
+	size, err := c.fileSize(ctx, agentID, path)
+	if err != nil {
+		return err
+	}
+	offset, err := c.tailOffset(ctx, agentID, path, size, opts.Lines)
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := c.ReadFile(ctx, agentID, path, offset, readLength)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if len(chunk.Data) > 0 {
+			if _, err := io.WriteString(w, chunk.Data); err != nil {
+				return err
+			}
+			offset = chunk.Offset + int64(len(chunk.Data))
+
+			// Keep reading without waiting until we reach the end of the file.
+			if len(chunk.Data) == readLength {
+				continue
+			}
+		}
+		if !opts.Follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.PollInterval):
+		}
+
+		size, err := c.fileSize(ctx, agentID, path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if size < offset {
+			offset = 0
+		}
+	}
+}
+
+// fileSize returns the size of a file in a sandbox.
+func (c *Client) fileSize(ctx context.Context, agentID, path string) (int64, error) {
+	chunk, err := c.ReadFile(ctx, agentID, path, -1, -1)
+	if err != nil {
+		return 0, err
+	}
+	return chunk.Offset, nil
+}
+
+// tailOffset returns the offset of the last n lines of a file, it seeks backward from the end of the file.
+func (c *Client) tailOffset(ctx context.Context, agentID, path string, size int64, lines int) (int64, error) {
+	if lines < 0 {
+		return 0, nil
+	}
+	if lines == 0 {
+		return size, nil
+	}
+
+	var newlines int
+	for end := size; end > 0; {
+		start := end - readLength
+		if start < 0 {
+			start = 0
+		}
+		chunk, err := c.ReadFile(ctx, agentID, path, start, end-start)
+		if err != nil {
+			return 0, err
+		}
+		for i := len(chunk.Data) - 1; i >= 0; i-- {
+			// The newline terminating the last line doesn't count.
+			if chunk.Data[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			newlines++
+			if newlines == lines {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
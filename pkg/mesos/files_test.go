@@ -0,0 +1,230 @@
+package mesos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFile is a file served through a fake Mesos files API.
+type fakeFile struct {
+	mu      sync.Mutex
+	content string
+	reads   int
+}
+
+func (f *fakeFile) set(content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.content = content
+}
+
+func (f *fakeFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+
+	if r.URL.Path != "/agent/agent-1/files/read" || r.URL.Query().Get("path") != "/var/lib/mesos/stdout" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if offset == -1 {
+		json.NewEncoder(w).Encode(FileChunk{Offset: int64(len(f.content))})
+		return
+	}
+	end := int64(len(f.content))
+	if length, err := strconv.ParseInt(r.URL.Query().Get("length"), 10, 64); err == nil && offset+length < end {
+		end = offset + length
+	}
+	var data string
+	if offset < end {
+		data = f.content[offset:end]
+	}
+	w.Write(encodeMesosChunk(data, offset))
+}
+
+// encodeMesosChunk encodes a file chunk as Mesos does, the bytes of the data are written as is
+// apart from quotes, backslashes and control characters.
+func encodeMesosChunk(data string, offset int64) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"data":"`)
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&buf, "\\u%04x", c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	fmt.Fprintf(&buf, `","offset":%d}`, offset)
+	return buf.Bytes()
+}
+
+// syncBuffer is a bytes.Buffer which can be used concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestReadFile(t *testing.T) {
+	file := &fakeFile{content: "hello world\n"}
+	ts := httptest.NewServer(file)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	chunk, err := client.ReadFile(context.Background(), "agent-1", "/var/lib/mesos/stdout", 6, 5)
+	require.NoError(t, err)
+	require.Equal(t, &FileChunk{Data: "world", Offset: 6}, chunk)
+
+	chunk, err = client.ReadFile(context.Background(), "agent-1", "/var/lib/mesos/stdout", -1, -1)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), chunk.Offset)
+
+	_, err = client.ReadFile(context.Background(), "agent-2", "/var/lib/mesos/stdout", 0, 5)
+	require.Error(t, err)
+}
+
+func TestTailFile(t *testing.T) {
+	file := &fakeFile{content: "line 1\nline 2\nline 3\nline 4\n"}
+	ts := httptest.NewServer(file)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	testCases := []struct {
+		lines    int
+		expected string
+	}{
+		{-1, "line 1\nline 2\nline 3\nline 4\n"},
+		{0, ""},
+		{2, "line 3\nline 4\n"},
+		{10, "line 1\nline 2\nline 3\nline 4\n"},
+	}
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		err := client.TailFile(context.Background(), "agent-1", "/var/lib/mesos/stdout", TailOpts{Lines: tc.lines}, &out)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, out.String())
+	}
+
+	// A file without a trailing newline.
+	file.set("line 1\nline 2\nline 3")
+	var out bytes.Buffer
+	err := client.TailFile(context.Background(), "agent-1", "/var/lib/mesos/stdout", TailOpts{Lines: 2}, &out)
+	require.NoError(t, err)
+	require.Equal(t, "line 2\nline 3", out.String())
+}
+
+func TestTailFileFollow(t *testing.T) {
+	file := &fakeFile{content: "line 1\nline 2\n"}
+	ts := httptest.NewServer(file)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out syncBuffer
+	done := make(chan error)
+	go func() {
+		done <- client.TailFile(ctx, "agent-1", "/var/lib/mesos/stdout", TailOpts{
+			Lines:        1,
+			Follow:       true,
+			PollInterval: 10 * time.Millisecond,
+		}, &out)
+	}()
+
+	waitFor(t, func() bool { return out.String() == "line 2\n" })
+
+	file.set("line 1\nline 2\nline 3\n")
+	waitFor(t, func() bool { return out.String() == "line 2\nline 3\n" })
+
+	// The log file gets rotated.
+	file.set("line 4\n")
+	waitFor(t, func() bool { return out.String() == "line 2\nline 3\nline 4\n" })
+
+	// Canceling the context stops following the file without error.
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "TailFile didn't return after the context got canceled")
+	}
+}
+
+func TestTailFileFollowNonASCII(t *testing.T) {
+	// The file holds UTF-8 text and an invalid byte, offsets must match the bytes of the file.
+	file := &fakeFile{content: "caf\u00e9 \"cr\u00e8me\"\n\xff\tline 2\n"}
+	ts := httptest.NewServer(file)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	chunk, err := client.ReadFile(context.Background(), "agent-1", "/var/lib/mesos/stdout", 0, -1)
+	require.NoError(t, err)
+	require.Equal(t, &FileChunk{Data: file.content, Offset: 0}, chunk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out syncBuffer
+	done := make(chan error)
+	go func() {
+		done <- client.TailFile(ctx, "agent-1", "/var/lib/mesos/stdout", TailOpts{
+			Lines:        -1,
+			Follow:       true,
+			PollInterval: 10 * time.Millisecond,
+		}, &out)
+	}()
+
+	waitFor(t, func() bool { return out.String() == "caf\u00e9 \"cr\u00e8me\"\n\xff\tline 2\n" })
+
+	file.set("caf\u00e9 \"cr\u00e8me\"\n\xff\tline 2\nline 3\n")
+	waitFor(t, func() bool { return out.String() == "caf\u00e9 \"cr\u00e8me\"\n\xff\tline 2\nline 3\n" })
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// waitFor waits until a condition is met, it fails the test after 5 seconds.
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, "condition not met within 5 seconds")
+			t.FailNow()
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
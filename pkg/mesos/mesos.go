@@ -2,13 +2,15 @@ package mesos
 
 import (
 	"encoding/json"
+	"io"
 
 	"github.com/dcos/dcos-cli/pkg/httpclient"
 )
 
 // Client is a Mesos client for DC/OS.
 type Client struct {
-	http *httpclient.Client
+	http        *httpclient.Client
+	progressOut io.Writer
 }
 
 // NewClient creates a new Mesos client.
@@ -18,6 +20,12 @@ func NewClient(baseClient *httpclient.Client) *Client {
 	}
 }
 
+// SetProgressOutput sets the writer the progress of sandbox downloads is reported to.
+// When nil, which is the default, the progress is not reported.
+func (c *Client) SetProgressOutput(out io.Writer) {
+	c.progressOut = out
+}
+
 // StateSummary contains a summary of agents, tasks, and registered frameworks in the DC/OS cluster.
 type StateSummary struct {
 	Cluster string `json:"cluster"`
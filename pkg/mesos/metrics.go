@@ -0,0 +1,287 @@
+package mesos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// TaskStatus is a status update of a task, it only contains the fields used by the CLI.
+type TaskStatus struct {
+	State           string          `json:"state"`
+	ContainerStatus ContainerStatus `json:"container_status"`
+}
+
+// ContainerStatus is the status of the container a task runs in.
+type ContainerStatus struct {
+	ContainerID *ContainerID `json:"container_id"`
+}
+
+// ContainerID is the ID of a Mesos container. Nested containers, eg. the tasks of a pod,
+// have the ID of the container they are nested in as a parent.
+type ContainerID struct {
+	Value  string       `json:"value"`
+	Parent *ContainerID `json:"parent,omitempty"`
+}
+
+// hasAncestor indicates whether or not a container is nested in another one, at any depth.
+func (id *ContainerID) hasAncestor(value string) bool {
+	for parent := id.Parent; parent != nil; parent = parent.Parent {
+		if parent.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainerStatistics are the resource usage statistics of a container, as reported by
+// the `/containers` endpoint of its agent. CPU times are cumulative since the container started.
+type ContainerStatistics struct {
+	Timestamp          float64 `json:"timestamp"`
+	CPUsLimit          float64 `json:"cpus_limit"`
+	CPUsUserTimeSecs   float64 `json:"cpus_user_time_secs"`
+	CPUsSystemTimeSecs float64 `json:"cpus_system_time_secs"`
+	MemRSSBytes        uint64  `json:"mem_rss_bytes"`
+	MemLimitBytes      uint64  `json:"mem_limit_bytes"`
+	NetRxBytes         uint64  `json:"net_rx_bytes"`
+	NetRxPackets       uint64  `json:"net_rx_packets"`
+	NetRxErrors        uint64  `json:"net_rx_errors"`
+	NetRxDropped       uint64  `json:"net_rx_dropped"`
+	NetTxBytes         uint64  `json:"net_tx_bytes"`
+	NetTxPackets       uint64  `json:"net_tx_packets"`
+	NetTxErrors        uint64  `json:"net_tx_errors"`
+	NetTxDropped       uint64  `json:"net_tx_dropped"`
+}
+
+// CPUShare returns the share of its CPU limit the container used between
+// a previous sample and this one (eg. 0.5 for half of its CPUs).
+func (s ContainerStatistics) CPUShare(prev ContainerStatistics) float64 {
+	elapsed := s.Timestamp - prev.Timestamp
+	if elapsed <= 0 || s.CPUsLimit <= 0 {
+		return 0
+	}
+	used := (s.CPUsUserTimeSecs + s.CPUsSystemTimeSecs) - (prev.CPUsUserTimeSecs + prev.CPUsSystemTimeSecs)
+	if used < 0 {
+		return 0
+	}
+	return used / elapsed / s.CPUsLimit
+}
+
+// MemShare returns the share of its memory limit the container uses.
+func (s ContainerStatistics) MemShare() float64 {
+	if s.MemLimitBytes == 0 {
+		return 0
+	}
+	return float64(s.MemRSSBytes) / float64(s.MemLimitBytes)
+}
+
+// ContainerMetrics are the metrics of a container running a task.
+type ContainerMetrics struct {
+	ContainerID string `json:"container_id"`
+
+	// ParentID is the ID of the container this one is nested in, if any.
+	ParentID string `json:"parent_id,omitempty"`
+
+	// Statistics are the statistics of the container as returned by the agent.
+	Statistics json.RawMessage `json:"statistics"`
+
+	// Stats are the decoded statistics.
+	Stats ContainerStatistics `json:"-"`
+}
+
+// container is a container of the agent `/containers` endpoint.
+type container struct {
+	ContainerID string          `json:"container_id"`
+	ExecutorID  string          `json:"executor_id"`
+	FrameworkID string          `json:"framework_id"`
+	Statistics  json.RawMessage `json:"statistics"`
+	Status      struct {
+		ContainerID *ContainerID `json:"container_id"`
+	} `json:"status"`
+}
+
+// containerID returns the full ID of a container, along with its parents.
+func (c *container) containerID() *ContainerID {
+	if c.Status.ContainerID != nil {
+		return c.Status.ContainerID
+	}
+	return &ContainerID{Value: c.ContainerID}
+}
+
+// containerID returns the ID of the container a task runs in, according to its latest status.
+// It is nil when the task has no container status.
+func (t *Task) containerID() *ContainerID {
+	for i := len(t.Statuses) - 1; i >= 0; i-- {
+		if id := t.Statuses[i].ContainerStatus.ContainerID; id != nil && id.Value != "" {
+			return id
+		}
+	}
+	return nil
+}
+
+// TaskMetrics resolves a task ID (or an unambiguous prefix) to the containers it runs in
+// and returns their metrics. A task usually runs in a single container. When the task
+// container has nested containers (eg. a pod), each container is listed.
+func (c *Client) TaskMetrics(ctx context.Context, taskID string) (*Task, []ContainerMetrics, error) {
+	state, err := c.State()
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := state.FindTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	metrics, err := c.taskMetrics(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, metrics, nil
+}
+
+// WatchTaskMetrics polls the metrics of a task at a given interval until the context is done.
+// The handler is called with the metrics of each poll along with the statistics of the
+// previous poll, by container ID, so that CPU usage can be computed.
+func (c *Client) WatchTaskMetrics(ctx context.Context, taskID string, interval time.Duration, handler func(metrics []ContainerMetrics, prev map[string]ContainerStatistics) error) error {
+	state, err := c.State()
+	if err != nil {
+		return err
+	}
+	task, err := state.FindTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	prev := map[string]ContainerStatistics{}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// The ticker and the context can be ready at the same time, check the context first so
+		// that no poll is attempted once it is done.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		metrics, err := c.taskMetrics(ctx, task)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if err := handler(metrics, prev); err != nil {
+			return err
+		}
+		prev = make(map[string]ContainerStatistics, len(metrics))
+		for _, m := range metrics {
+			prev[m.ContainerID] = m.Stats
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// TaskContainer returns a task along with the ID of the container it runs in, including
+// the IDs of its parents (eg. the executor container of a pod).
+func (c *Client) TaskContainer(ctx context.Context, taskID string) (*Task, *ContainerID, error) {
+	state, err := c.State()
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := state.FindTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if id := task.containerID(); id != nil {
+		return task, id, nil
+	}
+
+	// Without a container status, the task runs in the top-level container of its executor.
+	containers, err := c.agentContainers(ctx, task.AgentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ct := range task.filterContainers(containers) {
+		if id := ct.containerID(); id.Parent == nil {
+			return task, id, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no container found for task '%s', it might not be running", task.ID)
+}
+
+// agentContainers returns the containers of an agent, including nested ones.
+func (c *Client) agentContainers(ctx context.Context, agentID string) ([]container, error) {
+	resp, err := c.http.Get("/agent/"+url.PathEscape(agentID)+"/containers?nested=true", httpclient.Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+	}
+
+	var containers []container
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// filterContainers returns the containers of a task among the containers of its agent.
+func (t *Task) filterContainers(containers []container) []container {
+	// Tasks launched by the command executor have no executor ID in the master state,
+	// the executor ID is then the task ID.
+	executorID := t.ExecutorID
+	if executorID == "" {
+		executorID = t.ID
+	}
+	taskContainerID := t.containerID()
+
+	var filtered []container
+	for _, ct := range containers {
+		id := ct.containerID()
+		if taskContainerID != nil {
+			if id.Value != taskContainerID.Value && !id.hasAncestor(taskContainerID.Value) {
+				continue
+			}
+		} else if ct.FrameworkID != t.FrameworkID || ct.ExecutorID != executorID {
+			continue
+		}
+		filtered = append(filtered, ct)
+	}
+	return filtered
+}
+
+// taskMetrics returns the metrics of the containers of a task from its agent.
+func (c *Client) taskMetrics(ctx context.Context, task *Task) ([]ContainerMetrics, error) {
+	containers, err := c.agentContainers(ctx, task.AgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []ContainerMetrics
+	for _, ct := range task.filterContainers(containers) {
+		id := ct.containerID()
+		m := ContainerMetrics{ContainerID: id.Value, Statistics: ct.Statistics}
+		if id.Parent != nil {
+			m.ParentID = id.Parent.Value
+		}
+		if len(ct.Statistics) > 0 {
+			if err := json.Unmarshal(ct.Statistics, &m.Stats); err != nil {
+				return nil, err
+			}
+		}
+		metrics = append(metrics, m)
+	}
+	if len(metrics) == 0 {
+		return nil, fmt.Errorf("no container found for task '%s', it might not be running", task.ID)
+	}
+	return metrics, nil
+}
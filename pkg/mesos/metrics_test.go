@@ -0,0 +1,211 @@
+package mesos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metricsStateJSON = `{
+	"frameworks": [
+		{
+			"id": "marathon",
+			"name": "marathon",
+			"tasks": [
+				{
+					"id": "nginx.1234", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING",
+					"statuses": [{"state": "TASK_RUNNING", "container_status": {"container_id": {"value": "ct-nginx"}}}]
+				},
+				{
+					"id": "db_web.instance-1.web", "framework_id": "marathon", "executor_id": "instance-db_web.1",
+					"slave_id": "agent-1", "state": "TASK_RUNNING",
+					"statuses": [{"state": "TASK_RUNNING", "container_status": {"container_id": {"value": "ct-web", "parent": {"value": "ct-pod"}}}}]
+				},
+				{"id": "db_web.instance-1.db", "framework_id": "marathon", "executor_id": "instance-db_web.1", "slave_id": "agent-1", "state": "TASK_RUNNING"},
+				{"id": "redis.1234", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_STAGING"}
+			]
+		}
+	]
+}`
+
+const containersJSON = `[
+	{
+		"container_id": "ct-nginx", "executor_id": "nginx.1234", "framework_id": "marathon",
+		"statistics": {"timestamp": 100, "cpus_limit": 2, "cpus_user_time_secs": 30, "cpus_system_time_secs": 10, "mem_rss_bytes": 67108864, "mem_limit_bytes": 268435456, "net_rx_bytes": 1024, "net_tx_bytes": 2048},
+		"status": {"container_id": {"value": "ct-nginx"}}
+	},
+	{
+		"container_id": "ct-pod", "executor_id": "instance-db_web.1", "framework_id": "marathon",
+		"statistics": {"timestamp": 100, "cpus_limit": 0.1, "mem_rss_bytes": 1048576, "mem_limit_bytes": 33554432},
+		"status": {"container_id": {"value": "ct-pod"}}
+	},
+	{
+		"container_id": "ct-web", "executor_id": "instance-db_web.1", "framework_id": "marathon",
+		"statistics": {"timestamp": 100, "cpus_limit": 1, "mem_rss_bytes": 2097152, "mem_limit_bytes": 134217728},
+		"status": {"container_id": {"value": "ct-web", "parent": {"value": "ct-pod"}}}
+	},
+	{
+		"container_id": "ct-db", "executor_id": "instance-db_web.1", "framework_id": "marathon",
+		"statistics": {"timestamp": 100, "cpus_limit": 1, "mem_rss_bytes": 4194304, "mem_limit_bytes": 134217728},
+		"status": {"container_id": {"value": "ct-db", "parent": {"value": "ct-pod"}}}
+	}
+]`
+
+func newMetricsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mesos/master/state":
+			w.Write([]byte(metricsStateJSON))
+		case "/agent/agent-1/containers":
+			assert.Equal(t, "true", r.URL.Query().Get("nested"))
+			w.Write([]byte(containersJSON))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+}
+
+func TestTaskMetrics(t *testing.T) {
+	ts := newMetricsServer(t)
+	defer ts.Close()
+
+	task, metrics, err := NewClient(httpclient.New(ts.URL)).TaskMetrics(context.Background(), "nginx")
+	require.NoError(t, err)
+	require.Equal(t, "nginx.1234", task.ID)
+	require.Len(t, metrics, 1)
+
+	require.Equal(t, "ct-nginx", metrics[0].ContainerID)
+	require.Empty(t, metrics[0].ParentID)
+	require.Equal(t, 2.0, metrics[0].Stats.CPUsLimit)
+	require.Equal(t, uint64(67108864), metrics[0].Stats.MemRSSBytes)
+	require.Equal(t, 0.25, metrics[0].Stats.MemShare())
+	require.Equal(t, uint64(1024), metrics[0].Stats.NetRxBytes)
+	require.Equal(t, uint64(2048), metrics[0].Stats.NetTxBytes)
+	require.Contains(t, string(metrics[0].Statistics), `"cpus_user_time_secs": 30`)
+}
+
+func TestPodTaskMetrics(t *testing.T) {
+	ts := newMetricsServer(t)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	// The task has a container status, only its nested container is listed.
+	_, metrics, err := client.TaskMetrics(context.Background(), "db_web.instance-1.web")
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	require.Equal(t, "ct-web", metrics[0].ContainerID)
+	require.Equal(t, "ct-pod", metrics[0].ParentID)
+
+	// Without container status, the containers of the pod executor are listed.
+	_, metrics, err = client.TaskMetrics(context.Background(), "db_web.instance-1.db")
+	require.NoError(t, err)
+	ids := []string{}
+	for _, m := range metrics {
+		ids = append(ids, m.ContainerID)
+	}
+	require.Equal(t, []string{"ct-pod", "ct-web", "ct-db"}, ids)
+}
+
+func TestTaskMetricsWithoutContainer(t *testing.T) {
+	ts := newMetricsServer(t)
+	defer ts.Close()
+
+	_, _, err := NewClient(httpclient.New(ts.URL)).TaskMetrics(context.Background(), "redis")
+	require.EqualError(t, err, "no container found for task 'redis.1234', it might not be running")
+}
+
+func TestTaskContainer(t *testing.T) {
+	ts := newMetricsServer(t)
+	defer ts.Close()
+
+	client := NewClient(httpclient.New(ts.URL))
+
+	task, id, err := client.TaskContainer(context.Background(), "nginx")
+	require.NoError(t, err)
+	require.Equal(t, "agent-1", task.AgentID)
+	require.Equal(t, &ContainerID{Value: "ct-nginx"}, id)
+
+	// The parents of a pod task container are kept.
+	_, id, err = client.TaskContainer(context.Background(), "db_web.instance-1.web")
+	require.NoError(t, err)
+	require.Equal(t, &ContainerID{Value: "ct-web", Parent: &ContainerID{Value: "ct-pod"}}, id)
+
+	// Without container status, the executor container is returned.
+	_, id, err = client.TaskContainer(context.Background(), "db_web.instance-1.db")
+	require.NoError(t, err)
+	require.Equal(t, &ContainerID{Value: "ct-pod"}, id)
+
+	_, _, err = client.TaskContainer(context.Background(), "redis")
+	require.EqualError(t, err, "no container found for task 'redis.1234', it might not be running")
+}
+
+func TestCPUShare(t *testing.T) {
+	prev := ContainerStatistics{Timestamp: 100, CPUsLimit: 2, CPUsUserTimeSecs: 30, CPUsSystemTimeSecs: 10}
+	cur := ContainerStatistics{Timestamp: 110, CPUsLimit: 2, CPUsUserTimeSecs: 38, CPUsSystemTimeSecs: 12}
+
+	// 10 CPU seconds used over 10 seconds, with a limit of 2 CPUs.
+	require.Equal(t, 0.5, cur.CPUShare(prev))
+
+	// Samples with the same timestamp don't tell anything about usage.
+	require.Equal(t, 0.0, cur.CPUShare(cur))
+}
+
+func TestWatchTaskMetrics(t *testing.T) {
+	ts := newMetricsServer(t)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls []map[string]ContainerStatistics
+	err := NewClient(httpclient.New(ts.URL)).WatchTaskMetrics(ctx, "nginx", time.Millisecond, func(metrics []ContainerMetrics, prev map[string]ContainerStatistics) error {
+		require.Len(t, metrics, 1)
+		polls = append(polls, prev)
+		if len(polls) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	require.Equal(t, context.Canceled, err)
+	require.Len(t, polls, 2)
+	require.Empty(t, polls[0])
+	require.Equal(t, 2.0, polls[1]["ct-nginx"].CPUsLimit)
+}
+
+func TestWatchTaskMetricsCanceledDuringPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The second poll is blocked until the context is canceled.
+	var polls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mesos/master/state":
+			w.Write([]byte(metricsStateJSON))
+		case "/agent/agent-1/containers":
+			if atomic.AddInt32(&polls, 1) > 1 {
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+			w.Write([]byte(containersJSON))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	err := NewClient(httpclient.New(ts.URL)).WatchTaskMetrics(ctx, "nginx", time.Millisecond, func(metrics []ContainerMetrics, prev map[string]ContainerStatistics) error {
+		return nil
+	})
+	require.Equal(t, context.Canceled, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&polls))
+}
@@ -0,0 +1,48 @@
+package mesos
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxRecordSize is the maximum size of a RecordIO record, larger records are rejected
+// rather than allocating an arbitrary amount of memory based on a length prefix.
+const maxRecordSize = 16 * 1024 * 1024
+
+// writeRecord writes a RecordIO record, that is the record length in bytes followed by a newline and the record.
+func writeRecord(w io.Writer, record []byte) error {
+	if _, err := fmt.Fprintf(w, "%d\n", len(record)); err != nil {
+		return err
+	}
+	_, err := w.Write(record)
+	return err
+}
+
+// readRecord reads a RecordIO record. It returns io.EOF when there are no more records.
+func readRecord(r *bufio.Reader) ([]byte, error) {
+	header, err := r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && header != "" {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	size, err := strconv.ParseUint(strings.TrimSpace(header), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RecordIO header '%s'", strings.TrimSpace(header))
+	}
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("RecordIO record of %d bytes exceeds the maximum size", size)
+	}
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r, record); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,215 @@
+package mesos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+// marathonFramework is the name of the Marathon framework of a DC/OS cluster.
+const marathonFramework = "marathon"
+
+// marathonInstanceSeparator separates the app ID from the instance ID in Marathon task IDs.
+const marathonInstanceSeparator = ".instance-"
+
+// DefaultServiceLogParallelism is the default number of tasks whose logs are fetched concurrently.
+const DefaultServiceLogParallelism = 8
+
+// MarathonAppID returns the ID of the Marathon app or pod a task belongs to. Marathon task IDs
+// are made of the app ID, without its leading slash and with slashes replaced by underscores,
+// followed by a dot and a suffix identifying the instance (eg. "group_nginx.<uuid>"). Pod task IDs
+// also have the container name after the instance (eg. "group_pod.instance-<uuid>.web").
+//
+// App IDs can contain dots, the instance separator is thus the ".instance-" one or, for tasks
+// launched by older Marathon versions, the last dot.
+func MarathonAppID(taskID string) (string, bool) {
+	i := strings.Index(taskID, marathonInstanceSeparator)
+	if i < 0 {
+		i = strings.LastIndex(taskID, ".")
+	}
+	if i <= 0 {
+		return "", false
+	}
+	return "/" + strings.Replace(taskID[:i], "_", "/", -1), true
+}
+
+// ServiceTasks returns the running tasks of a Marathon service. The service ID is either the
+// exact ID of an app or pod (eg. "/group/nginx"), or a pattern matched against service IDs
+// with the syntax of path.Match (eg. "/group/*").
+func (s *State) ServiceTasks(serviceID string) ([]Task, error) {
+	serviceID = "/" + strings.Trim(serviceID, "/")
+	isPattern := strings.ContainsAny(serviceID, "*?[")
+	if isPattern {
+		if _, err := path.Match(serviceID, ""); err != nil {
+			return nil, fmt.Errorf("invalid service ID pattern '%s': %s", serviceID, err)
+		}
+	}
+
+	var tasks []Task
+	for _, framework := range s.Frameworks {
+		if framework.Name != marathonFramework {
+			continue
+		}
+		for _, task := range framework.Tasks {
+			if task.State != "TASK_RUNNING" {
+				continue
+			}
+			appID, ok := MarathonAppID(task.ID)
+			if !ok {
+				continue
+			}
+			if appID == serviceID {
+				tasks = append(tasks, task)
+				continue
+			}
+			if match, _ := path.Match(serviceID, appID); isPattern && match {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no running tasks found for service '%s'", serviceID)
+	}
+	return tasks, nil
+}
+
+// ServiceLogOpts are options to fetch the logs of a Marathon service.
+type ServiceLogOpts struct {
+	TailOpts
+
+	// File is the sandbox file to read, it defaults to "stdout".
+	File string
+
+	// Parallelism is the maximum number of tasks whose logs are fetched concurrently, it
+	// defaults to DefaultServiceLogParallelism. When following, it only applies to the lookup
+	// of task sandboxes as every task is followed.
+	Parallelism int
+}
+
+// ServiceLogs writes the logs of all running tasks of a Marathon service to w, each line is
+// prefixed with the ID of the task it comes from. Lines of different tasks are interleaved
+// in the order they are received.
+//
+// Logs of at most opts.Parallelism tasks are fetched concurrently. As following never ends on
+// its own, the logs of all tasks are then followed concurrently, each polling its own file.
+func (c *Client) ServiceLogs(ctx context.Context, serviceID string, opts ServiceLogOpts, w io.Writer) error {
+	if opts.File == "" {
+		opts.File = "stdout"
+	}
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultServiceLogParallelism
+	}
+
+	state, err := c.State()
+	if err != nil {
+		return err
+	}
+	tasks, err := state.ServiceTasks(serviceID)
+	if err != nil {
+		return err
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, opts.Parallelism)
+
+	for i := range tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			acquired := true
+			release := func() {
+				if acquired {
+					acquired = false
+					<-sem
+				}
+			}
+			defer release()
+
+			task := &tasks[i]
+			sandbox, err := c.taskSandbox(ctx, task)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if opts.Follow {
+				release()
+			}
+
+			pw := &prefixWriter{w: w, mu: &mu, prefix: task.ID + " "}
+			errs[i] = c.TailFile(ctx, sandbox.AgentID, path.Join(sandbox.Directory, opts.File), opts.TailOpts, pw)
+			if err := pw.Flush(); errs[i] == nil {
+				errs[i] = err
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefixWriter prefixes each line written to it before forwarding it to a shared writer.
+// Only complete lines are forwarded so that lines from concurrent writers don't get mixed up.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+// Write implements the io.Writer interface.
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf.Write(p)
+
+	i := bytes.LastIndexByte(pw.buf.Bytes(), '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := pw.buf.Next(i + 1)
+	if err := pw.writeLines(lines); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush forwards the last line when it isn't terminated by a newline.
+func (pw *prefixWriter) Flush() error {
+	if pw.buf.Len() == 0 {
+		return nil
+	}
+	line := append(pw.buf.Bytes(), '\n')
+	pw.buf.Reset()
+	return pw.writeLines(line)
+}
+
+// writeLines writes newline-terminated lines to the shared writer, each with the prefix.
+func (pw *prefixWriter) writeLines(lines []byte) error {
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		out.WriteString(pw.prefix)
+		out.Write(line)
+	}
+
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	_, err := pw.w.Write(out.Bytes())
+	return err
+}
@@ -0,0 +1,236 @@
+package mesos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/require"
+)
+
+const servicesStateJSON = `{
+	"frameworks": [
+		{
+			"id": "marathon",
+			"name": "marathon",
+			"tasks": [
+				{"id": "group_nginx.1234", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"},
+				{"id": "group_nginx.5678", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"},
+				{"id": "group_nginx.9012", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_STAGING"},
+				{"id": "group_nginx-admin.1234", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"},
+				{"id": "group_pod.instance-1234.web", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"}
+			]
+		},
+		{
+			"id": "kafka",
+			"name": "kafka",
+			"tasks": [
+				{"id": "group_nginx.3456", "framework_id": "kafka", "slave_id": "agent-1", "state": "TASK_RUNNING"}
+			]
+		}
+	]
+}`
+
+const servicesAgentStateJSON = `{
+	"frameworks": [
+		{
+			"id": "marathon",
+			"executors": [
+				{"id": "group_nginx.1234", "directory": "/sandbox/1234", "tasks": [{"id": "group_nginx.1234"}]},
+				{"id": "group_nginx.5678", "directory": "/sandbox/5678", "tasks": [{"id": "group_nginx.5678"}]}
+			]
+		}
+	]
+}`
+
+func TestMarathonAppID(t *testing.T) {
+	appID, ok := MarathonAppID("group_nginx.1234")
+	require.True(t, ok)
+	require.Equal(t, "/group/nginx", appID)
+
+	appID, ok = MarathonAppID("group_pod.instance-1234.web")
+	require.True(t, ok)
+	require.Equal(t, "/group/pod", appID)
+
+	appID, ok = MarathonAppID("group_my.app.1234")
+	require.True(t, ok)
+	require.Equal(t, "/group/my.app", appID)
+
+	appID, ok = MarathonAppID("group_my.pod.instance-1234.web")
+	require.True(t, ok)
+	require.Equal(t, "/group/my.pod", appID)
+
+	_, ok = MarathonAppID("kafka-0-broker")
+	require.False(t, ok)
+}
+
+func TestServiceTasks(t *testing.T) {
+	var state State
+	require.NoError(t, json.Unmarshal([]byte(servicesStateJSON), &state))
+
+	fixtures := []struct {
+		serviceID string
+		taskIDs   []string
+	}{
+		{"/group/nginx", []string{"group_nginx.1234", "group_nginx.5678"}},
+		{"group/nginx/", []string{"group_nginx.1234", "group_nginx.5678"}},
+		{"/group/nginx*", []string{"group_nginx-admin.1234", "group_nginx.1234", "group_nginx.5678"}},
+		{"/group/*", []string{"group_nginx-admin.1234", "group_nginx.1234", "group_nginx.5678", "group_pod.instance-1234.web"}},
+		{"/group/pod", []string{"group_pod.instance-1234.web"}},
+	}
+
+	for _, fixture := range fixtures {
+		tasks, err := state.ServiceTasks(fixture.serviceID)
+		require.NoError(t, err, fixture.serviceID)
+
+		var taskIDs []string
+		for _, task := range tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		sort.Strings(taskIDs)
+		require.Equal(t, fixture.taskIDs, taskIDs, fixture.serviceID)
+	}
+
+	_, err := state.ServiceTasks("/group")
+	require.EqualError(t, err, "no running tasks found for service '/group'")
+
+	_, err = state.ServiceTasks("/group/[")
+	require.Error(t, err)
+}
+
+func TestServiceLogs(t *testing.T) {
+	files := map[string]string{
+		"/sandbox/1234/stdout": "first line\nsecond line\n",
+		"/sandbox/5678/stdout": "other task\nno newline",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mesos/master/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(servicesStateJSON))
+	})
+	mux.HandleFunc("/agent/agent-1/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(servicesAgentStateJSON))
+	})
+	mux.HandleFunc("/agent/agent-1/files/read", func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Query().Get("path")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+		if offset == -1 {
+			json.NewEncoder(w).Encode(FileChunk{Offset: int64(len(content))})
+			return
+		}
+		end := int64(len(content))
+		if length, err := strconv.ParseInt(r.URL.Query().Get("length"), 10, 64); err == nil && offset+length < end {
+			end = offset + length
+		}
+		json.NewEncoder(w).Encode(FileChunk{Data: content[offset:end], Offset: offset})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	var out bytes.Buffer
+	err := c.ServiceLogs(context.Background(), "/group/nginx", ServiceLogOpts{TailOpts: TailOpts{Lines: -1}}, &out)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	sort.Strings(lines)
+	require.Equal(t, []string{
+		"group_nginx.1234 first line",
+		"group_nginx.1234 second line",
+		"group_nginx.5678 no newline",
+		"group_nginx.5678 other task",
+	}, lines)
+
+}
+
+func TestServiceLogsFollow(t *testing.T) {
+	files := map[string]string{
+		"/sandbox/1234/stdout": "first line\n",
+		"/sandbox/5678/stdout": "other task\n",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The context is canceled once both files have been polled at their end, which
+	// requires them to be followed concurrently.
+	var mu sync.Mutex
+	polled := make(map[string]bool)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mesos/master/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(servicesStateJSON))
+	})
+	mux.HandleFunc("/agent/agent-1/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(servicesAgentStateJSON))
+	})
+	mux.HandleFunc("/agent/agent-1/files/read", func(w http.ResponseWriter, r *http.Request) {
+		filePath := r.URL.Query().Get("path")
+		content := files[filePath]
+		offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+		if offset == -1 {
+			json.NewEncoder(w).Encode(FileChunk{Offset: int64(len(content))})
+			return
+		}
+		if offset >= int64(len(content)) {
+			mu.Lock()
+			polled[filePath] = true
+			if len(polled) == len(files) {
+				cancel()
+			}
+			mu.Unlock()
+			json.NewEncoder(w).Encode(FileChunk{Offset: offset})
+			return
+		}
+		json.NewEncoder(w).Encode(FileChunk{Data: content[offset:], Offset: offset})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	var out bytes.Buffer
+	opts := ServiceLogOpts{
+		TailOpts:    TailOpts{Lines: -1, Follow: true, PollInterval: time.Millisecond},
+		Parallelism: 1,
+	}
+	err := c.ServiceLogs(ctx, "/group/nginx", opts, &out)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	sort.Strings(lines)
+	require.Equal(t, []string{
+		"group_nginx.1234 first line",
+		"group_nginx.5678 other task",
+	}, lines)
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	pw := &prefixWriter{w: &out, mu: new(sync.Mutex), prefix: "task "}
+
+	pw.Write([]byte("hello"))
+	require.Empty(t, out.String())
+
+	pw.Write([]byte(" world\nfoo\nba"))
+	require.Equal(t, "task hello world\ntask foo\n", out.String())
+
+	require.NoError(t, pw.Flush())
+	require.Equal(t, "task hello world\ntask foo\ntask ba\n", out.String())
+}
@@ -0,0 +1,193 @@
+package mesos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// Task is a Mesos task, it only contains the fields used by the CLI.
+type Task struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	FrameworkID string `json:"framework_id"`
+	ExecutorID  string `json:"executor_id"`
+	AgentID     string `json:"slave_id"`
+	State       string `json:"state"`
+
+	Statuses []TaskStatus `json:"statuses"`
+}
+
+// Framework is a Mesos framework along with its tasks.
+type Framework struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Tasks          []Task `json:"tasks"`
+	CompletedTasks []Task `json:"completed_tasks"`
+}
+
+// Tasks returns the active and completed tasks of all frameworks.
+func (s *State) Tasks() []Task {
+	var tasks []Task
+	for _, framework := range s.Frameworks {
+		tasks = append(tasks, framework.Tasks...)
+		tasks = append(tasks, framework.CompletedTasks...)
+	}
+	return tasks
+}
+
+// FindTask returns the task with the given ID. When there is no exact match, the
+// ID can also be a prefix of a task ID as long as it is not ambiguous.
+func (s *State) FindTask(id string) (*Task, error) {
+	var matches []Task
+	for _, task := range s.Tasks() {
+		if task.ID == id {
+			return &task, nil
+		}
+		if strings.HasPrefix(task.ID, id) {
+			matches = append(matches, task)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no task found with ID '%s'", id)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple tasks match '%s', please use a longer ID", id)
+	}
+}
+
+// Sandbox is the location of a task sandbox, the work directory of its executor on an agent.
+type Sandbox struct {
+	AgentID   string
+	Directory string
+}
+
+// SandboxNotFoundError is returned when the sandbox of a task doesn't exist anymore.
+type SandboxNotFoundError struct {
+	TaskID string
+}
+
+// Error implements the error interface.
+func (e *SandboxNotFoundError) Error() string {
+	return fmt.Sprintf("the sandbox of task '%s' doesn't exist, it might have been garbage collected", e.TaskID)
+}
+
+// agentState is the state of a Mesos agent, it only contains the fields needed to locate sandboxes.
+type agentState struct {
+	Frameworks          []agentFramework `json:"frameworks"`
+	CompletedFrameworks []agentFramework `json:"completed_frameworks"`
+}
+
+type agentFramework struct {
+	ID                 string     `json:"id"`
+	Executors          []executor `json:"executors"`
+	CompletedExecutors []executor `json:"completed_executors"`
+}
+
+type executor struct {
+	ID             string `json:"id"`
+	Directory      string `json:"directory"`
+	Tasks          []Task `json:"tasks"`
+	QueuedTasks    []Task `json:"queued_tasks"`
+	CompletedTasks []Task `json:"completed_tasks"`
+}
+
+// hasTask indicates whether or not the executor runs or ran a given task.
+func (e *executor) hasTask(taskID string) bool {
+	for _, tasks := range [][]Task{e.Tasks, e.QueuedTasks, e.CompletedTasks} {
+		for _, task := range tasks {
+			if task.ID == taskID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TaskSandbox resolves a task ID (or an unambiguous prefix) to the agent and executor directory of its sandbox.
+func (c *Client) TaskSandbox(ctx context.Context, taskID string) (*Task, *Sandbox, error) {
+	state, err := c.State()
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := state.FindTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sandbox, err := c.taskSandbox(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, sandbox, nil
+}
+
+// taskSandbox locates the sandbox of a task from the state of its agent.
+func (c *Client) taskSandbox(ctx context.Context, task *Task) (*Sandbox, error) {
+	resp, err := c.http.Get("/agent/"+url.PathEscape(task.AgentID)+"/state", httpclient.Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+	case 404:
+		// The agent is gone, and so is the sandbox.
+		return nil, &SandboxNotFoundError{TaskID: task.ID}
+	default:
+		return nil, fmt.Errorf("HTTP %d error", resp.StatusCode)
+	}
+
+	var agent agentState
+	if err := json.NewDecoder(resp.Body).Decode(&agent); err != nil {
+		return nil, err
+	}
+
+	for _, frameworks := range [][]agentFramework{agent.Frameworks, agent.CompletedFrameworks} {
+		for _, framework := range frameworks {
+			if framework.ID != task.FrameworkID {
+				continue
+			}
+			for _, executors := range [][]executor{framework.Executors, framework.CompletedExecutors} {
+				for _, e := range executors {
+					if e.Directory != "" && e.hasTask(task.ID) {
+						return &Sandbox{AgentID: task.AgentID, Directory: e.Directory}, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, &SandboxNotFoundError{TaskID: task.ID}
+}
+
+// ListSandbox lists the files of a directory in the sandbox of a task, dir is relative to
+// the sandbox root. File paths are made relative to the sandbox root as well.
+func (c *Client) ListSandbox(ctx context.Context, taskID, dir string) ([]FileInfo, error) {
+	task, sandbox, err := c.TaskSandbox(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = path.Clean("/" + dir)
+	files, err := c.BrowseDir(ctx, sandbox.AgentID, path.Join(sandbox.Directory, dir))
+	if err != nil {
+		if _, ok := err.(*FileNotFoundError); ok {
+			if dir == "/" {
+				return nil, &SandboxNotFoundError{TaskID: task.ID}
+			}
+			return nil, fmt.Errorf("'%s' doesn't exist in the sandbox of task '%s'", strings.TrimPrefix(dir, "/"), task.ID)
+		}
+		return nil, err
+	}
+	for i := range files {
+		files[i].Path = strings.TrimPrefix(strings.TrimPrefix(files[i].Path, sandbox.Directory), "/")
+	}
+	return files, nil
+}
@@ -0,0 +1,126 @@
+package mesos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksStateJSON = `{
+	"frameworks": [
+		{
+			"id": "marathon",
+			"name": "marathon",
+			"tasks": [
+				{"id": "nginx.1234", "name": "nginx", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"},
+				{"id": "nginx.5678", "name": "nginx", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_RUNNING"}
+			],
+			"completed_tasks": [
+				{"id": "redis.1234", "name": "redis", "framework_id": "marathon", "slave_id": "agent-2", "state": "TASK_FINISHED"},
+				{"id": "cron.1234", "name": "cron", "framework_id": "marathon", "slave_id": "agent-1", "state": "TASK_FINISHED"}
+			]
+		}
+	]
+}`
+
+const agentStateJSON = `{
+	"frameworks": [
+		{
+			"id": "marathon",
+			"executors": [
+				{"id": "nginx.1234", "directory": "/sandbox/nginx.1234", "tasks": [{"id": "nginx.1234"}]},
+				{"id": "nginx.5678", "directory": "/sandbox/nginx.5678", "queued_tasks": [{"id": "nginx.5678"}]}
+			]
+		}
+	]
+}`
+
+func newTasksServer() *httptest.Server {
+	sandbox := fakeSandbox{
+		"/sandbox/nginx.1234/stdout":      "hello",
+		"/sandbox/nginx.1234/logs/access": "GET /",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mesos/master/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(tasksStateJSON))
+	})
+	mux.HandleFunc("/agent/agent-1/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(agentStateJSON))
+	})
+	mux.Handle("/agent/agent-1/files/", sandbox)
+	return httptest.NewServer(mux)
+}
+
+func TestFindTask(t *testing.T) {
+	state := &State{Frameworks: []Framework{{
+		Tasks:          []Task{{ID: "nginx.1234"}, {ID: "nginx.5678"}},
+		CompletedTasks: []Task{{ID: "redis.1234"}},
+	}}}
+
+	task, err := state.FindTask("nginx.1234")
+	require.NoError(t, err)
+	require.Equal(t, "nginx.1234", task.ID)
+
+	task, err = state.FindTask("redis")
+	require.NoError(t, err)
+	require.Equal(t, "redis.1234", task.ID)
+
+	_, err = state.FindTask("nginx")
+	require.EqualError(t, err, "multiple tasks match 'nginx', please use a longer ID")
+
+	_, err = state.FindTask("kafka")
+	require.EqualError(t, err, "no task found with ID 'kafka'")
+}
+
+func TestTaskSandbox(t *testing.T) {
+	ts := newTasksServer()
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	task, sandbox, err := c.TaskSandbox(context.Background(), "nginx.5")
+	require.NoError(t, err)
+	require.Equal(t, "nginx.5678", task.ID)
+	require.Equal(t, &Sandbox{AgentID: "agent-1", Directory: "/sandbox/nginx.5678"}, sandbox)
+
+	// The agent of the task is gone.
+	_, _, err = c.TaskSandbox(context.Background(), "redis")
+	require.Equal(t, &SandboxNotFoundError{TaskID: "redis.1234"}, err)
+
+	// The executor of the task isn't known by the agent anymore.
+	_, _, err = c.TaskSandbox(context.Background(), "cron")
+	require.Equal(t, &SandboxNotFoundError{TaskID: "cron.1234"}, err)
+}
+
+func TestListSandbox(t *testing.T) {
+	ts := newTasksServer()
+	defer ts.Close()
+
+	c := NewClient(httpclient.New(ts.URL))
+
+	files, err := c.ListSandbox(context.Background(), "nginx.1234", "")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	paths := map[string]bool{}
+	for _, file := range files {
+		paths[file.Path] = file.IsDir()
+	}
+	require.Equal(t, map[string]bool{"stdout": false, "logs": true}, paths)
+
+	files, err = c.ListSandbox(context.Background(), "nginx.1234", "logs")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "logs/access", files[0].Path)
+
+	_, err = c.ListSandbox(context.Background(), "nginx.1234", "../nginx.5678")
+	require.EqualError(t, err, "'nginx.5678' doesn't exist in the sandbox of task 'nginx.1234'")
+
+	// The sandbox directory has been garbage collected.
+	_, err = c.ListSandbox(context.Background(), "nginx.5678", "")
+	require.Equal(t, &SandboxNotFoundError{TaskID: "nginx.5678"}, err)
+}
@@ -0,0 +1,75 @@
+// Package metronome is a client for Metronome, the DC/OS job scheduler.
+package metronome
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// basePath is the path of the Metronome API through Admin Router.
+const basePath = "/service/metronome/v1"
+
+// Client is a Metronome client for DC/OS.
+type Client struct {
+	http *httpclient.Client
+}
+
+// NewClient creates a new Metronome client.
+func NewClient(baseClient *httpclient.Client) *Client {
+	return &Client{
+		http: baseClient,
+	}
+}
+
+// Error is an error returned by the Metronome API.
+type Error struct {
+	StatusCode int
+	Message    string         `json:"message"`
+	Details    []ErrorDetails `json:"details"`
+}
+
+// ErrorDetails are the details of an error, eg. the validation errors of a job definition.
+type ErrorDetails struct {
+	Path   string   `json:"path"`
+	Errors []string `json:"errors"`
+}
+
+// Error converts a Metronome error to a string.
+func (err *Error) Error() string {
+	msg := err.Message
+	if msg == "" {
+		msg = fmt.Sprintf("HTTP %d error", err.StatusCode)
+	}
+	for _, details := range err.Details {
+		msg += fmt.Sprintf("\n  - %s: %s", details.Path, strings.Join(details.Errors, ", "))
+	}
+	return msg
+}
+
+// do sends a request to the Metronome API and decodes its response into v, unless v is nil.
+// Responses with an unexpected status code are returned as an *Error.
+func (c *Client) do(req *http.Request, expectedStatus int, v interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		metronomeErr := &Error{StatusCode: resp.StatusCode}
+		if body, err := ioutil.ReadAll(resp.Body); err == nil {
+			// The body isn't always JSON, eg. when Metronome is down and Admin Router replies.
+			json.Unmarshal(body, metronomeErr)
+		}
+		return metronomeErr
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
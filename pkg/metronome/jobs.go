@@ -0,0 +1,217 @@
+package metronome
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Job is a Metronome job, its run specification is kept as is.
+type Job struct {
+	ID          string            `json:"id"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Run         json.RawMessage   `json:"run"`
+	ActiveRuns  []Run             `json:"activeRuns,omitempty"`
+	History     *History          `json:"history,omitempty"`
+}
+
+// Run is a run of a job.
+type Run struct {
+	ID          string `json:"id"`
+	JobID       string `json:"jobId"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+	CompletedAt string `json:"completedAt"`
+}
+
+// History is the history of the finished runs of a job.
+type History struct {
+	SuccessCount           int           `json:"successCount"`
+	FailureCount           int           `json:"failureCount"`
+	LastSuccessAt          string        `json:"lastSuccessAt"`
+	LastFailureAt          string        `json:"lastFailureAt"`
+	SuccessfulFinishedRuns []FinishedRun `json:"successfulFinishedRuns"`
+	FailedFinishedRuns     []FinishedRun `json:"failedFinishedRuns"`
+}
+
+// FinishedRun is a finished run in the history of a job.
+type FinishedRun struct {
+	ID         string `json:"id"`
+	CreatedAt  string `json:"createdAt"`
+	FinishedAt string `json:"finishedAt"`
+}
+
+// HistoryRun is a run of a job along with its status, it is either active or finished.
+type HistoryRun struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+
+	// StartedAt is the time the run was created at.
+	StartedAt time.Time `json:"startedAt"`
+
+	// FinishedAt is the time the run finished at, it is nil for active runs.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Run statuses of a job history, active runs keep the status reported by Metronome (eg. "active").
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Jobs returns the jobs defined in Metronome.
+func (c *Client) Jobs() ([]Job, error) {
+	req, err := c.http.NewRequest("GET", basePath+"/jobs", nil)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []Job
+	if err := c.do(req, 200, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// AddJob creates a job from its JSON definition, as read by ReadJob.
+func (c *Client) AddJob(definition json.RawMessage) (*Job, error) {
+	req, err := c.http.NewRequest("POST", basePath+"/jobs", bytes.NewReader(definition))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var job Job
+	if err := c.do(req, 201, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RunJob starts a run of a job right away, regardless of its schedules.
+func (c *Client) RunJob(id string) (*Run, error) {
+	req, err := c.http.NewRequest("POST", jobPath(id)+"/runs", strings.NewReader("{}"))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var run Run
+	if err := c.do(req, 201, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// JobHistory returns the active and finished runs of a job, the most recent first.
+func (c *Client) JobHistory(id string) ([]HistoryRun, error) {
+	req, err := c.http.NewRequest("GET", jobPath(id)+"?embed=activeRuns&embed=history", nil)
+	if err != nil {
+		return nil, err
+	}
+	var job Job
+	if err := c.do(req, 200, &job); err != nil {
+		return nil, err
+	}
+
+	var runs []HistoryRun
+	for _, run := range job.ActiveRuns {
+		runs = append(runs, HistoryRun{
+			ID:        run.ID,
+			Status:    strings.ToLower(run.Status),
+			StartedAt: parseTime(run.CreatedAt),
+		})
+	}
+	if job.History != nil {
+		for _, run := range job.History.SuccessfulFinishedRuns {
+			runs = append(runs, run.historyRun(StatusSuccess))
+		}
+		for _, run := range job.History.FailedFinishedRuns {
+			runs = append(runs, run.historyRun(StatusFailed))
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	return runs, nil
+}
+
+// RemoveJob removes a job. When stopRuns is true its active runs are stopped,
+// otherwise Metronome refuses to remove a job which has active runs.
+func (c *Client) RemoveJob(id string, stopRuns bool) error {
+	path := jobPath(id)
+	if stopRuns {
+		path += "?stopCurrentJobRuns=true"
+	}
+	req, err := c.http.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, 200, nil)
+}
+
+// ReadJob reads a job definition, it must be a JSON object with an ID.
+// When the path is "-", the definition is read from stdin.
+func ReadJob(fs afero.Fs, stdin io.Reader, path string) (json.RawMessage, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(stdin)
+	} else {
+		data, err = afero.ReadFile(fs, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job struct {
+		ID interface{} `json:"id"`
+	}
+	if err := json.Unmarshal(data, &job); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, fmt.Errorf("invalid job definition '%s': expected a JSON object", path)
+		}
+		return nil, fmt.Errorf("invalid job definition '%s': %s", path, err)
+	}
+	if id, ok := job.ID.(string); !ok || id == "" {
+		return nil, fmt.Errorf("invalid job definition '%s': missing job ID", path)
+	}
+	return json.RawMessage(data), nil
+}
+
+// historyRun returns a finished run along with its status.
+func (r FinishedRun) historyRun(status string) HistoryRun {
+	run := HistoryRun{
+		ID:        r.ID,
+		Status:    status,
+		StartedAt: parseTime(r.CreatedAt),
+	}
+	if finishedAt := parseTime(r.FinishedAt); !finishedAt.IsZero() {
+		run.FinishedAt = &finishedAt
+	}
+	return run
+}
+
+// jobPath returns the API path of a job.
+func jobPath(id string) string {
+	return basePath + "/jobs/" + url.PathEscape(id)
+}
+
+// parseTime parses a Metronome timestamp (eg. "2019-02-14T10:23:39.549+0000"),
+// it returns the zero time when the timestamp is empty or invalid.
+func parseTime(timestamp string) time.Time {
+	for _, layout := range []string{"2006-01-02T15:04:05.999-0700", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,179 @@
+package metronome
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jobJSON = `{
+  "id": "backup",
+  "description": "Nightly backup",
+  "run": {"cmd": "backup.sh", "cpus": 0.5, "mem": 128, "disk": 0},
+  "activeRuns": [
+    {"id": "20190215103000abcde", "jobId": "backup", "status": "ACTIVE", "createdAt": "2019-02-15T10:30:00.000+0000", "completedAt": null}
+  ],
+  "history": {
+    "successCount": 1,
+    "failureCount": 1,
+    "lastSuccessAt": "2019-02-14T10:31:12.412+0000",
+    "lastFailureAt": "2019-02-13T10:30:41.008+0000",
+    "successfulFinishedRuns": [
+      {"id": "20190214103000fghij", "createdAt": "2019-02-14T10:30:00.000+0000", "finishedAt": "2019-02-14T10:31:12.412+0000"}
+    ],
+    "failedFinishedRuns": [
+      {"id": "20190213103000klmno", "createdAt": "2019-02-13T10:30:00.000+0000", "finishedAt": "2019-02-13T10:30:41.008+0000"}
+    ]
+  }
+}`
+
+func TestJobs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/service/metronome/v1/jobs", r.URL.Path)
+		w.Write([]byte(`[{"id": "backup", "run": {"cmd": "backup.sh"}}, {"id": "cleanup", "run": {"cmd": "cleanup.sh"}}]`))
+	}))
+	defer ts.Close()
+
+	jobs, err := NewClient(httpclient.New(ts.URL)).Jobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, "backup", jobs[0].ID)
+	require.JSONEq(t, `{"cmd": "backup.sh"}`, string(jobs[0].Run))
+	require.Equal(t, "cleanup", jobs[1].ID)
+}
+
+func TestAddJob(t *testing.T) {
+	definition := `{"id": "backup", "run": {"cmd": "backup.sh", "cpus": 0.5, "mem": 128, "disk": 0}}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/service/metronome/v1/jobs", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, definition, string(body))
+
+		w.WriteHeader(201)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	job, err := NewClient(httpclient.New(ts.URL)).AddJob(json.RawMessage(definition))
+	require.NoError(t, err)
+	require.Equal(t, "backup", job.ID)
+}
+
+func TestAddInvalidJob(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(422)
+		w.Write([]byte(`{"message": "Object is not valid", "details": [{"path": "/run/cpus", "errors": ["error.expected.jsnumber"]}]}`))
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(httpclient.New(ts.URL)).AddJob(json.RawMessage(`{"id": "backup", "run": {"cpus": "1"}}`))
+	require.EqualError(t, err, "Object is not valid\n  - /run/cpus: error.expected.jsnumber")
+
+	metronomeErr, ok := err.(*Error)
+	require.True(t, ok)
+	require.Equal(t, 422, metronomeErr.StatusCode)
+}
+
+func TestRunJob(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/service/metronome/v1/jobs/backup/runs", r.URL.Path)
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id": "20190215103000abcde", "jobId": "backup", "status": "INITIAL", "createdAt": "2019-02-15T10:30:00.000+0000"}`))
+	}))
+	defer ts.Close()
+
+	run, err := NewClient(httpclient.New(ts.URL)).RunJob("backup")
+	require.NoError(t, err)
+	require.Equal(t, "20190215103000abcde", run.ID)
+	require.Equal(t, "INITIAL", run.Status)
+}
+
+func TestRunUnknownJob(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		w.Write([]byte(`{"message": "Job not found"}`))
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(httpclient.New(ts.URL)).RunJob("backup")
+	require.EqualError(t, err, "Job not found")
+}
+
+func TestJobHistory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/service/metronome/v1/jobs/backup", r.URL.Path)
+		assert.Equal(t, []string{"activeRuns", "history"}, r.URL.Query()["embed"])
+		w.Write([]byte(jobJSON))
+	}))
+	defer ts.Close()
+
+	runs, err := NewClient(httpclient.New(ts.URL)).JobHistory("backup")
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+
+	require.Equal(t, "20190215103000abcde", runs[0].ID)
+	require.Equal(t, "active", runs[0].Status)
+	require.Nil(t, runs[0].FinishedAt)
+
+	require.Equal(t, "20190214103000fghij", runs[1].ID)
+	require.Equal(t, StatusSuccess, runs[1].Status)
+	require.Equal(t, time.Date(2019, 2, 14, 10, 30, 0, 0, time.UTC), runs[1].StartedAt.UTC())
+	require.Equal(t, time.Date(2019, 2, 14, 10, 31, 12, 412000000, time.UTC), runs[1].FinishedAt.UTC())
+
+	require.Equal(t, "20190213103000klmno", runs[2].ID)
+	require.Equal(t, StatusFailed, runs[2].Status)
+}
+
+func TestRemoveJob(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/service/metronome/v1/jobs/backup", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("stopCurrentJobRuns"))
+	}))
+	defer ts.Close()
+
+	err := NewClient(httpclient.New(ts.URL)).RemoveJob("backup", true)
+	require.NoError(t, err)
+}
+
+func TestReadJob(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/backup.json", []byte(`{"id": "backup", "run": {"cmd": "backup.sh"}}`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/array.json", []byte(`[{"id": "backup"}]`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/noid.json", []byte(`{"run": {"cmd": "backup.sh"}}`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "/invalid.json", []byte(`{"id": "backup",}`), 0644))
+
+	definition, err := ReadJob(fs, nil, "/backup.json")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "backup", "run": {"cmd": "backup.sh"}}`, string(definition))
+
+	definition, err = ReadJob(fs, strings.NewReader(`{"id": "cleanup"}`), "-")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "cleanup"}`, string(definition))
+
+	_, err = ReadJob(fs, nil, "/array.json")
+	require.EqualError(t, err, "invalid job definition '/array.json': expected a JSON object")
+
+	_, err = ReadJob(fs, nil, "/noid.json")
+	require.EqualError(t, err, "invalid job definition '/noid.json': missing job ID")
+
+	_, err = ReadJob(fs, nil, "/invalid.json")
+	require.EqualError(t, err, "invalid job definition '/invalid.json': invalid character '}' looking for beginning of object key string")
+}
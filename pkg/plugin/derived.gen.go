@@ -5,6 +5,10 @@ package plugin
 // deriveDeepCopy recursively copies the contents of src into dst.
 func deriveDeepCopy(dst, src *Plugin) {
 	dst.Name = src.Name
+	dst.Version = src.Version
+	dst.Source = src.Source
+	dst.Pinned = src.Pinned
+	dst.Asset = src.Asset
 	if src.Commands == nil {
 		dst.Commands = nil
 	} else {
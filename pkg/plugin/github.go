@@ -0,0 +1,283 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// githubPrefix is the prefix of plugin resources referring to a GitHub release (eg. "github:owner/repo@v1.2.3").
+const githubPrefix = "github:"
+
+// githubAPIURL is the base URL of the GitHub API, it is a variable so that tests can override it.
+var githubAPIURL = "https://api.github.com"
+
+// skippedAssetExts are the extensions of release assets which can't be plugins (checksums, signatures, etc.).
+var skippedAssetExts = []string{".asc", ".sig", ".md5", ".sha1", ".sha256", ".sha512", ".txt", ".pem"}
+
+// osAliases are the names under which release assets commonly refer to an OS.
+var osAliases = map[string][]string{
+	"darwin":  {"darwin", "macos", "osx", "mac"},
+	"linux":   {"linux"},
+	"windows": {"windows", "win", "win32", "win64"},
+}
+
+// archAliases are the names under which release assets commonly refer to an architecture.
+// "x86_64" and "x86-64" are normalized to "amd64" before matching, "x86" thus only refers to 32-bit.
+var archAliases = map[string][]string{
+	"386":   {"386", "i386", "i686", "x86"},
+	"amd64": {"amd64", "x64"},
+	"arm":   {"arm", "armv6", "armv7", "armhf"},
+	"arm64": {"arm64", "aarch64"},
+}
+
+// githubResource is a plugin resource referring to a GitHub release.
+type githubResource struct {
+	// releaseURL is the GitHub API URL of the release.
+	releaseURL string
+
+	// repo is the name of the GitHub repository, it is the default plugin name.
+	repo string
+}
+
+// parseGitHubResource parses a plugin resource referring to a GitHub release. It is either in
+// the "github:owner/repo[@tag]" form, the latest release being used when there is no tag,
+// or a release API URL (eg. "https://api.github.com/repos/owner/repo/releases/tags/v1.2.3").
+// It returns nil when the resource doesn't refer to a GitHub release.
+func parseGitHubResource(resource string) (*githubResource, error) {
+	if strings.HasPrefix(resource, githubPrefix) {
+		ref := strings.TrimPrefix(resource, githubPrefix)
+		tag := ""
+		hasTag := false
+		if i := strings.Index(ref, "@"); i != -1 {
+			ref, tag, hasTag = ref[:i], ref[i+1:], true
+		}
+		parts := strings.Split(ref, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" || (hasTag && tag == "") {
+			return nil, fmt.Errorf("invalid GitHub resource '%s', expected github:owner/repo[@tag]", resource)
+		}
+
+		releaseURL := githubAPIURL + "/repos/" + url.PathEscape(parts[0]) + "/" + url.PathEscape(parts[1]) + "/releases/"
+		if hasTag {
+			releaseURL += "tags/" + url.PathEscape(tag)
+		} else {
+			releaseURL += "latest"
+		}
+		return &githubResource{releaseURL: releaseURL, repo: parts[1]}, nil
+	}
+
+	if strings.HasPrefix(resource, githubAPIURL+"/repos/") {
+		parts := strings.Split(strings.TrimPrefix(resource, githubAPIURL+"/repos/"), "/")
+		if len(parts) >= 4 && parts[0] != "" && parts[1] != "" && parts[2] == "releases" {
+			return &githubResource{releaseURL: resource, repo: parts[1]}, nil
+		}
+	}
+	return nil, nil
+}
+
+// githubRelease is a GitHub release, it only contains the fields used by the CLI.
+type githubRelease struct {
+	TagName string        `json:"tag_name"`
+	Assets  []githubAsset `json:"assets"`
+}
+
+// githubAsset is a file attached to a GitHub release.
+type githubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// githubRelease fetches a release from the GitHub API.
+func (m *Manager) githubRelease(releaseURL string) (*githubRelease, error) {
+	resp, err := m.httpClient(releaseURL).Get(releaseURL, httpclient.Header("Accept", "application/vnd.github.v3+json"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get GitHub release %s: %s", releaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("couldn't decode GitHub release %s: %s", releaseURL, err)
+	}
+	return &release, nil
+}
+
+// asset returns the asset of the release to install on a given platform. When a name is given,
+// the asset with this exact name is returned. Otherwise the asset must be the only one referring
+// to the OS and not to another architecture, assets referring to the architecture are preferred.
+// It errors with the candidate names when no or multiple assets match.
+func (r *githubRelease) asset(name, goos, goarch string) (*githubAsset, error) {
+	var names []string
+	for _, asset := range r.Assets {
+		names = append(names, asset.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("release %s has no assets", r.TagName)
+	}
+
+	if name != "" {
+		for i := range r.Assets {
+			if r.Assets[i].Name == name {
+				return &r.Assets[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no asset named '%s' in release %s, available assets: %s", name, r.TagName, strings.Join(names, ", "))
+	}
+
+	var candidates, archCandidates []*githubAsset
+	for i := range r.Assets {
+		asset := &r.Assets[i]
+		assetName := normalizeAssetName(asset.Name)
+		if isSkippedAsset(assetName) || !matchesAliases(assetName, platformAliases(osAliases, goos)) {
+			continue
+		}
+		matchesArch := matchesAliases(assetName, platformAliases(archAliases, goarch))
+		if !matchesArch && matchesForeignArch(assetName, goarch) {
+			continue
+		}
+		candidates = append(candidates, asset)
+		if matchesArch {
+			archCandidates = append(archCandidates, asset)
+		}
+	}
+	if len(candidates) > 1 && len(archCandidates) > 0 {
+		candidates = archCandidates
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf(
+			"no asset of release %s matches %s/%s, use --asset to pick one of: %s",
+			r.TagName, goos, goarch, strings.Join(names, ", "),
+		)
+	case 1:
+		return candidates[0], nil
+	default:
+		var candidateNames []string
+		for _, candidate := range candidates {
+			candidateNames = append(candidateNames, candidate.Name)
+		}
+		return nil, fmt.Errorf(
+			"multiple assets of release %s match %s/%s (%s), use --asset to pick one",
+			r.TagName, goos, goarch, strings.Join(candidateNames, ", "),
+		)
+	}
+}
+
+// checksumFileNames are the names of release assets commonly listing the SHA-256 checksums of other assets,
+// goreleaser also prefixes "checksums.txt" with the project name and version (eg. "dcos-hello_1.2.3_checksums.txt").
+var checksumFileNames = []string{"checksums.txt", "sha256sums", "sha256sums.txt"}
+
+// maxChecksumFileSize is the maximum size of a checksum asset.
+const maxChecksumFileSize = 1 << 20
+
+// checksumAsset returns the asset holding the SHA-256 checksum of another asset, either
+// "<asset>.sha256" or a checksum file listing several assets. It returns nil when there is none.
+func (r *githubRelease) checksumAsset(assetName string) *githubAsset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == assetName+".sha256" {
+			return &r.Assets[i]
+		}
+	}
+	for i := range r.Assets {
+		name := strings.ToLower(r.Assets[i].Name)
+		for _, checksumFileName := range checksumFileNames {
+			if name == checksumFileName || strings.HasSuffix(name, "_"+checksumFileName) {
+				return &r.Assets[i]
+			}
+		}
+	}
+	return nil
+}
+
+// githubChecksum downloads a checksum asset and returns the checksum of a given asset. Checksum files are
+// in the format of the sha256sum command ("<digest>  <name>" lines), an "<asset>.sha256" file might also
+// only contain the digest. It returns an empty checksum when the file doesn't list the asset.
+func (m *Manager) githubChecksum(checksumAsset *githubAsset, assetName string) (Checksum, error) {
+	url := checksumAsset.BrowserDownloadURL
+	resp, err := m.httpClient(url).Get(url)
+	if err != nil {
+		return Checksum{}, fmt.Errorf("couldn't download checksum asset %s: %s", checksumAsset.Name, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+	if err != nil {
+		return Checksum{}, fmt.Errorf("couldn't download checksum asset %s: %s", checksumAsset.Name, err)
+	}
+
+	dedicated := checksumAsset.Name == assetName+".sha256"
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && dedicated:
+		case len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == assetName:
+		default:
+			continue
+		}
+		checksum, err := SHA256Checksum(fields[0])
+		if err != nil {
+			return Checksum{}, fmt.Errorf("invalid checksum asset %s: %s", checksumAsset.Name, err)
+		}
+		return checksum, nil
+	}
+	if dedicated {
+		return Checksum{}, fmt.Errorf("invalid checksum asset %s: no checksum found", checksumAsset.Name)
+	}
+	return Checksum{}, nil
+}
+
+// normalizeAssetName lowercases an asset name and normalizes "x86_64" and "x86-64" to "amd64".
+func normalizeAssetName(name string) string {
+	name = strings.ToLower(name)
+	for _, alias := range []string{"x86_64", "x86-64"} {
+		name = strings.Replace(name, alias, "amd64", -1)
+	}
+	return name
+}
+
+// isSkippedAsset indicates whether or not an asset is a checksum, a signature, or another non-plugin file.
+func isSkippedAsset(name string) bool {
+	for _, ext := range skippedAssetExts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// platformAliases returns the aliases of an OS or architecture, it is the name itself when there are none.
+func platformAliases(aliases map[string][]string, name string) []string {
+	if a, ok := aliases[name]; ok {
+		return a
+	}
+	return []string{name}
+}
+
+// matchesForeignArch indicates whether or not an asset name refers to an architecture other than goarch.
+func matchesForeignArch(name, goarch string) bool {
+	for arch, aliases := range archAliases {
+		if arch != goarch && matchesAliases(name, aliases) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAliases indicates whether or not a name contains one of the aliases as a distinct word,
+// eg. "linux" matches "dcos-hello_linux_amd64.zip" but "arm" doesn't match "dcos-hello-arm64".
+func matchesAliases(name string, aliases []string) bool {
+	for _, alias := range aliases {
+		re := regexp.MustCompile(`(^|[^a-z0-9])` + regexp.QuoteMeta(alias) + `([^a-z0-9]|$)`)
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
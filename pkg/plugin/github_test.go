@@ -0,0 +1,274 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubResource(t *testing.T) {
+	fixtures := []struct {
+		resource   string
+		releaseURL string
+		repo       string
+	}{
+		{"github:dcos/dcos-hello@v1.2.3", "https://api.github.com/repos/dcos/dcos-hello/releases/tags/v1.2.3", "dcos-hello"},
+		{"github:dcos/dcos-hello", "https://api.github.com/repos/dcos/dcos-hello/releases/latest", "dcos-hello"},
+		{"https://api.github.com/repos/dcos/dcos-hello/releases/1234", "https://api.github.com/repos/dcos/dcos-hello/releases/1234", "dcos-hello"},
+	}
+	for _, fixture := range fixtures {
+		resource, err := parseGitHubResource(fixture.resource)
+		require.NoError(t, err)
+		require.NotNil(t, resource)
+		require.Equal(t, fixture.releaseURL, resource.releaseURL)
+		require.Equal(t, fixture.repo, resource.repo)
+	}
+
+	for _, notGitHub := range []string{"https://example.com/dcos-hello.zip", "https://api.github.com/repos/dcos/dcos-hello", "./dcos-hello"} {
+		resource, err := parseGitHubResource(notGitHub)
+		require.NoError(t, err)
+		require.Nil(t, resource)
+	}
+
+	for _, invalid := range []string{"github:dcos", "github:dcos/dcos-hello/extra", "github:dcos/dcos-hello@", "github:/dcos-hello"} {
+		_, err := parseGitHubResource(invalid)
+		require.EqualError(t, err, "invalid GitHub resource '"+invalid+"', expected github:owner/repo[@tag]")
+	}
+}
+
+func TestReleaseAsset(t *testing.T) {
+	release := &githubRelease{TagName: "v1.2.3"}
+	for _, name := range []string{
+		"checksums.txt",
+		"dcos-hello_1.2.3_linux_x86_64.zip",
+		"dcos-hello_1.2.3_linux_x86_64.zip.sha256",
+		"dcos-hello_1.2.3_linux_arm64.zip",
+		"dcos-hello_1.2.3_linux_386.zip",
+		"dcos-hello_1.2.3_macOS.zip",
+		"dcos-hello_1.2.3_windows_amd64.exe",
+		"dcos-hello_1.2.3_windows_x86.exe",
+	} {
+		release.Assets = append(release.Assets, githubAsset{Name: name})
+	}
+
+	fixtures := []struct {
+		goos   string
+		goarch string
+		asset  string
+	}{
+		{"linux", "amd64", "dcos-hello_1.2.3_linux_x86_64.zip"},
+		{"linux", "arm64", "dcos-hello_1.2.3_linux_arm64.zip"},
+		{"linux", "386", "dcos-hello_1.2.3_linux_386.zip"},
+		{"darwin", "amd64", "dcos-hello_1.2.3_macOS.zip"},
+		{"darwin", "arm64", "dcos-hello_1.2.3_macOS.zip"},
+		{"windows", "amd64", "dcos-hello_1.2.3_windows_amd64.exe"},
+		{"windows", "386", "dcos-hello_1.2.3_windows_x86.exe"},
+	}
+	for _, fixture := range fixtures {
+		asset, err := release.asset("", fixture.goos, fixture.goarch)
+		require.NoError(t, err, "%s/%s", fixture.goos, fixture.goarch)
+		require.Equal(t, fixture.asset, asset.Name, "%s/%s", fixture.goos, fixture.goarch)
+	}
+
+	_, err := release.asset("", "linux", "arm")
+	require.EqualError(t, err, "no asset of release v1.2.3 matches linux/arm, use --asset to pick one of: "+
+		"checksums.txt, dcos-hello_1.2.3_linux_x86_64.zip, dcos-hello_1.2.3_linux_x86_64.zip.sha256, "+
+		"dcos-hello_1.2.3_linux_arm64.zip, dcos-hello_1.2.3_linux_386.zip, dcos-hello_1.2.3_macOS.zip, "+
+		"dcos-hello_1.2.3_windows_amd64.exe, dcos-hello_1.2.3_windows_x86.exe")
+
+	// The asset given explicitly is picked regardless of the platform.
+	asset, err := release.asset("dcos-hello_1.2.3_linux_arm64.zip", "linux", "amd64")
+	require.NoError(t, err)
+	require.Equal(t, "dcos-hello_1.2.3_linux_arm64.zip", asset.Name)
+
+	_, err = release.asset("dcos-hello.zip", "linux", "amd64")
+	require.Contains(t, err.Error(), "no asset named 'dcos-hello.zip' in release v1.2.3, available assets: checksums.txt, ")
+
+	_, err = (&githubRelease{TagName: "v1.2.3"}).asset("", "linux", "amd64")
+	require.EqualError(t, err, "release v1.2.3 has no assets")
+}
+
+func TestReleaseAssetAmbiguous(t *testing.T) {
+	release := &githubRelease{
+		TagName: "v1.2.3",
+		Assets: []githubAsset{
+			{Name: "dcos-hello-linux.zip"},
+			{Name: "dcos-hello-linux-static.zip"},
+			{Name: "dcos-hello-darwin.zip"},
+		},
+	}
+	_, err := release.asset("", "linux", "amd64")
+	require.EqualError(t, err, "multiple assets of release v1.2.3 match linux/amd64 (dcos-hello-linux.zip, dcos-hello-linux-static.zip), use --asset to pick one")
+}
+
+func TestInstallFromGitHubRelease(t *testing.T) {
+	version := "1.0.0"
+	assetName := "helloworld_" + runtime.GOOS + "_" + runtime.GOARCH + ".zip"
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/dcos/helloworld/releases/latest":
+			require.Equal(t, "application/vnd.github.v3+json", r.Header.Get("Accept"))
+			json.NewEncoder(w).Encode(githubRelease{
+				TagName: "v" + version,
+				Assets: []githubAsset{
+					{Name: "helloworld_plan9_mips.zip", BrowserDownloadURL: ts.URL + "/download/helloworld_plan9_mips.zip"},
+					{Name: assetName, BrowserDownloadURL: ts.URL + "/download/" + assetName},
+				},
+			})
+		case "/download/" + assetName:
+			w.Write(zipPlugin(t, version))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	defaultGitHubAPIURL := githubAPIURL
+	githubAPIURL = ts.URL
+	defer func() { githubAPIURL = defaultGitHubAPIURL }()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.Install("github:dcos/helloworld", &InstallOpts{}))
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "helloworld", plugins[0].Name)
+	require.Equal(t, "1.0.0", plugins[0].Version)
+	require.Equal(t, "github:dcos/helloworld", plugins[0].Source)
+	require.Empty(t, plugins[0].Asset)
+
+	// Updates pick the asset from the latest release.
+	version = "1.1.0"
+	updated, err := pm.Update("helloworld")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	binary, err := afero.ReadFile(pm.fs, filepath.Join(pm.pluginsDir(), "helloworld", "env", "bin", "dcos-hello"))
+	require.NoError(t, err)
+	require.Equal(t, "hello 1.1.0", string(binary))
+
+	err = pm.Install("github:dcos/unknown@v1.0.0", &InstallOpts{})
+	require.EqualError(t, err, "couldn't get GitHub release "+ts.URL+"/repos/dcos/unknown/releases/tags/v1.0.0: HTTP 404 error")
+}
+
+func TestInstallGitHubReleaseAsset(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/dcos/dcos-hello/releases/tags/v1.0.0":
+			json.NewEncoder(w).Encode(githubRelease{
+				TagName: "v1.0.0",
+				Assets: []githubAsset{
+					{Name: "dcos-hello", BrowserDownloadURL: ts.URL + "/download/dcos-hello"},
+					{Name: "dcos-hello.exe", BrowserDownloadURL: ts.URL + "/download/dcos-hello.exe"},
+				},
+			})
+		case "/download/dcos-hello":
+			w.Write([]byte("hello"))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	defaultGitHubAPIURL := githubAPIURL
+	githubAPIURL = ts.URL
+	defer func() { githubAPIURL = defaultGitHubAPIURL }()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+
+	// No asset refers to a platform, it has to be given explicitly.
+	resource := ts.URL + "/repos/dcos/dcos-hello/releases/tags/v1.0.0"
+	err := pm.Install(resource, &InstallOpts{})
+	require.EqualError(t, err, "no asset of release v1.0.0 matches "+runtime.GOOS+"/"+runtime.GOARCH+
+		", use --asset to pick one of: dcos-hello, dcos-hello.exe")
+
+	require.NoError(t, pm.Install(resource, &InstallOpts{Asset: "dcos-hello"}))
+
+	// The version of binary plugins is read from the release tag.
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "dcos-hello", plugins[0].Name)
+	require.Equal(t, "v1.0.0", plugins[0].Version)
+	require.Equal(t, resource, plugins[0].Source)
+	require.Equal(t, "dcos-hello", plugins[0].Asset)
+}
+
+func TestInstallGitHubReleaseChecksum(t *testing.T) {
+	assetName := "dcos-hello_" + runtime.GOOS + "_" + runtime.GOARCH
+	binary := []byte("hello")
+	digest := sha256.Sum256(binary)
+	checksum := hex.EncodeToString(digest[:])
+
+	checksumFile := checksum + "  " + assetName + "\n"
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/dcos/dcos-hello/releases/tags/v1.0.0":
+			json.NewEncoder(w).Encode(githubRelease{
+				TagName: "v1.0.0",
+				Assets: []githubAsset{
+					{Name: assetName, BrowserDownloadURL: ts.URL + "/download/" + assetName},
+					{Name: "dcos-hello_1.0.0_checksums.txt", BrowserDownloadURL: ts.URL + "/download/checksums.txt"},
+				},
+			})
+		case "/repos/dcos/dcos-hello/releases/tags/v1.1.0":
+			json.NewEncoder(w).Encode(githubRelease{
+				TagName: "v1.1.0",
+				Assets: []githubAsset{
+					{Name: assetName, BrowserDownloadURL: ts.URL + "/download/" + assetName},
+					{Name: assetName + ".sha256", BrowserDownloadURL: ts.URL + "/download/" + assetName + ".sha256"},
+				},
+			})
+		case "/download/" + assetName:
+			w.Write(binary)
+		case "/download/checksums.txt":
+			w.Write([]byte(checksumFile))
+		case "/download/" + assetName + ".sha256":
+			w.Write([]byte(checksum + "\n"))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	defaultGitHubAPIURL := githubAPIURL
+	githubAPIURL = ts.URL
+	defer func() { githubAPIURL = defaultGitHubAPIURL }()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+
+	// The asset is verified against the checksum file of the release.
+	require.NoError(t, pm.Install("github:dcos/dcos-hello@v1.0.0", &InstallOpts{}))
+
+	checksumFile = strings.Repeat("0", 64) + "  " + assetName + "\n"
+	err := pm.Install("github:dcos/dcos-hello@v1.0.0", &InstallOpts{Update: true})
+	require.EqualError(t, err, "computed checksum "+checksum+" for "+ts.URL+"/download/"+assetName+", expected "+strings.Repeat("0", 64))
+
+	// Assets which aren't listed in the checksum file aren't verified.
+	checksumFile = checksum + "  dcos-hello_plan9_mips\n"
+	require.NoError(t, pm.Install("github:dcos/dcos-hello@v1.0.0", &InstallOpts{Update: true}))
+
+	// A checksum given explicitly takes precedence.
+	explicitChecksum, err := SHA256Checksum(strings.Repeat("1", 64))
+	require.NoError(t, err)
+	err = pm.Install("github:dcos/dcos-hello@v1.0.0", &InstallOpts{Update: true, Checksum: explicitChecksum})
+	require.EqualError(t, err, "computed checksum "+checksum+" for "+ts.URL+"/download/"+assetName+", expected "+strings.Repeat("1", 64))
+
+	// The asset is verified against its dedicated checksum asset.
+	require.NoError(t, pm.Install("github:dcos/dcos-hello@v1.1.0", &InstallOpts{Update: true}))
+}
@@ -3,7 +3,8 @@ package plugin
 //go:generate goderive .
 
 import (
-	"crypto/tls"
+	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"hash"
@@ -15,30 +16,52 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/dcos/dcos-cli/pkg/fsutil"
 	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/progress"
 	"github.com/pelletier/go-toml"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
+// detectVersionTimeout is the time given to a plugin command to print its version.
+var detectVersionTimeout = 5 * time.Second
+
+// versionRegex matches version numbers such as "1.2.3", "v0.7" or "1.0.0-rc1".
+var versionRegex = regexp.MustCompile(`v?[0-9]+(\.[0-9]+)+([-+][0-9A-Za-z.-]+)?`)
+
 // Manager retrieves the plugins available for the current cluster
 // by navigating into the filesystem.
 type Manager struct {
-	fs      afero.Fs
-	logger  *logrus.Logger
-	cluster *config.Cluster
+	ctx         context.Context
+	fs          afero.Fs
+	logger      *logrus.Logger
+	cluster     *config.Cluster
+	progressOut io.Writer
+	cleaner     Cleaner
+	envLookup   func(key string) (string, bool)
+	acsToken    func(cluster *config.Cluster) (string, error)
+}
+
+// Cleaner registers cleanup functions which are run when the CLI is force-exited (eg. by a second Ctrl-C).
+// It returns a function which unregisters the cleanup function.
+type Cleaner interface {
+	AddCleanup(cleanup func()) (remove func())
 }
 
 // NewManager returns a new plugin manager.
 func NewManager(fs afero.Fs, logger *logrus.Logger) *Manager {
 	return &Manager{
-		fs:     fs,
-		logger: logger,
+		ctx:      context.Background(),
+		fs:       fs,
+		logger:   logger,
+		acsToken: (*config.Cluster).ResolveACSToken,
 	}
 }
 
@@ -53,12 +76,22 @@ type InstallOpts struct {
 	// Checksum represents a CLI plugin resource content hash.
 	Checksum Checksum
 
+	// PinVersion pins the plugin to its installed version, it is then skipped by bulk updates.
+	PinVersion bool
+
+	// Asset is the name of the asset to install when the resource is a GitHub release.
+	// When empty, the asset matching the current OS and architecture is picked.
+	Asset string
+
 	// PostInstall is a hook which can be invoked after plugin installation.
 	// It is invoked right before the plugin is moved to its final location.
 	PostInstall func(fs afero.Fs, pluginDir string) error
 
 	path       string
+	source     string
+	releaseTag string
 	stagingDir string
+	plugin     *Plugin
 }
 
 // Checksum contains the hash function and the checksum we expect from a plugin.
@@ -67,34 +100,70 @@ type Checksum struct {
 	Value  string
 }
 
+// SHA256Checksum returns a SHA-256 checksum for a hex encoded digest.
+func SHA256Checksum(value string) (Checksum, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	digest, err := hex.DecodeString(value)
+	if err != nil || len(digest) != sha256.Size {
+		return Checksum{}, fmt.Errorf("invalid SHA-256 checksum '%s'", value)
+	}
+	return Checksum{Hasher: sha256.New(), Value: value}, nil
+}
+
 // Install installs a plugin from a resource.
-func (m *Manager) Install(resource string, installOpts *InstallOpts) (err error) {
-	// If it's a remote resource, download it first.
+func (m *Manager) Install(resource string, installOpts *InstallOpts) error {
 	m.logger.Infof("Installing plugin from %s...", resource)
-	if strings.HasPrefix(resource, "https://") || strings.HasPrefix(resource, "http://") {
-		installOpts.path, err = m.downloadPlugin(resource, installOpts.Checksum)
-		if err != nil {
-			return err
-		}
-		// Remove the downloaded resource from the temp dir at the end of installation.
-		defer m.fs.RemoveAll(filepath.Dir(installOpts.path))
-	} else {
-		installOpts.path = resource
-	}
+	defer m.removeTempDirs(installOpts)
+	defer m.addCleanup(func() { m.removeTempDirs(installOpts) })()
 
-	// The staging dir is where the plugin will be constructed
-	// before eventually getting moved to its final location.
-	installOpts.stagingDir, err = afero.TempDir(m.fs, os.TempDir(), "dcos-cli")
-	if err != nil {
+	if err := m.stagePlugin(resource, installOpts); err != nil {
 		return err
 	}
+	return m.installPlugin(installOpts)
+}
 
-	// Build the plugin into the staging directory.
-	err = m.buildPlugin(installOpts)
+// Update updates an installed plugin from the remote resource it was installed from.
+//
+// The plugin is only replaced when the version of the downloaded plugin differs from the installed one,
+// plugins without a version are always replaced. It returns whether or not the plugin has been replaced.
+func (m *Manager) Update(name string) (bool, error) {
+	pluginDir := filepath.Join(m.pluginsDir(), name)
+	pluginDirExists, err := afero.DirExists(m.fs, pluginDir)
 	if err != nil {
-		return err
+		return false, err
 	}
-	return m.installPlugin(installOpts)
+	if !pluginDirExists {
+		return false, fmt.Errorf("'%s' is not installed", name)
+	}
+	installedPlugin, err := m.loadPlugin(name)
+	if err != nil {
+		return false, err
+	}
+	if installedPlugin.Source == "" {
+		return false, fmt.Errorf("no remote source recorded for '%s'", name)
+	}
+
+	m.logger.Infof("Updating plugin %s from %s...", name, installedPlugin.Source)
+	installOpts := &InstallOpts{
+		Name:       name,
+		Update:     true,
+		PinVersion: installedPlugin.Pinned,
+		Asset:      installedPlugin.Asset,
+		PostInstall: func(fs afero.Fs, stagingDir string) error {
+			return m.copyPluginFiles(pluginDir, stagingDir)
+		},
+	}
+	defer m.removeTempDirs(installOpts)
+	defer m.addCleanup(func() { m.removeTempDirs(installOpts) })()
+
+	if err := m.stagePlugin(installedPlugin.Source, installOpts); err != nil {
+		return false, err
+	}
+	if installOpts.plugin.Version != "" && installOpts.plugin.Version == installedPlugin.Version {
+		m.logger.Infof("Plugin %s is already at version %s", name, installedPlugin.Version)
+		return false, nil
+	}
+	return true, m.installPlugin(installOpts)
 }
 
 // SetCluster sets the plugin manager's target cluster.
@@ -102,6 +171,34 @@ func (m *Manager) SetCluster(cluster *config.Cluster) {
 	m.cluster = cluster
 }
 
+// SetContext sets the context plugin downloads are derived from.
+func (m *Manager) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetCleaner sets where the manager registers the removal of its temporary files,
+// so that they get removed even when the CLI is force-exited during an installation.
+func (m *Manager) SetCleaner(cleaner Cleaner) {
+	m.cleaner = cleaner
+}
+
+// SetEnvLookup sets the function used to lookup env vars, eg. the proxy ones for downloads.
+func (m *Manager) SetEnvLookup(envLookup func(key string) (string, bool)) {
+	m.envLookup = envLookup
+}
+
+// SetACSTokenResolver sets the function resolving the ACS token for requests to the cluster.
+// It defaults to Cluster.ResolveACSToken.
+func (m *Manager) SetACSTokenResolver(acsToken func(cluster *config.Cluster) (string, error)) {
+	m.acsToken = acsToken
+}
+
+// SetProgressOutput sets the writer the progress of plugin downloads is reported to.
+// When nil, which is the default, the progress is not reported.
+func (m *Manager) SetProgressOutput(out io.Writer) {
+	m.progressOut = out
+}
+
 // Remove removes a plugin from the filesystem.
 func (m *Manager) Remove(name string) error {
 	pluginDir := filepath.Join(m.pluginsDir(), name)
@@ -129,7 +226,8 @@ func (m *Manager) Plugins() (plugins []*Plugin) {
 	}
 
 	for _, pluginDir := range pluginDirs {
-		if !pluginDir.IsDir() {
+		// Hidden directories are used as temporary locations during installations.
+		if !pluginDir.IsDir() || strings.HasPrefix(pluginDir.Name(), ".") {
 			continue
 		}
 		plugin, err := m.loadPlugin(pluginDir.Name())
@@ -177,14 +275,16 @@ func (m *Manager) loadPlugin(name string) (*Plugin, error) {
 
 	// Compare the normalized plugin with the saved copy to know whether or not the file should be updated.
 	if !reflect.DeepEqual(persistedPlugin, plugin) {
-		m.persistPlugin(plugin, pluginFilePath)
+		if err := m.persistPlugin(plugin, pluginFilePath); err != nil {
+			m.logger.Debug(err)
+		}
 	}
 	return plugin, nil
 }
 
 // findCommands discovers commands in a given directory according to conventions.
 // Each command should be contained in a dedicated binary named `dcos-{command}`.
-// On Windows it must have the `.exe`` extension.
+// On Windows it must have the `.exe` extension.
 func (m *Manager) findCommands(pluginDir string) (commands []Command) {
 	binDir := filepath.Join(pluginDir, "bin")
 	if runtime.GOOS == "windows" {
@@ -231,6 +331,29 @@ func (m *Manager) commandDescription(cmd Command) (desc string) {
 	return desc
 }
 
+// detectVersion gets the version of a plugin by invoking its first command with the `--version` flag.
+// It returns an empty string when the output doesn't contain a version number.
+func (m *Manager) detectVersion(pluginDir string) string {
+	commands := m.findCommands(pluginDir)
+	if len(commands) == 0 {
+		return ""
+	}
+	cmd := commands[0]
+
+	// The command might not know the flag and wait for input instead, it is thus
+	// given no stdin and killed when it doesn't exit in time.
+	ctx, cancel := context.WithTimeout(m.ctx, detectVersionTimeout)
+	defer cancel()
+	versionCmd := exec.CommandContext(ctx, cmd.Path, cmd.Name, "--version")
+	versionCmd.Stdin = nil
+	out, err := versionCmd.Output()
+	if err != nil {
+		m.logger.Debugf("Couldn't get the version of the '%s' command: %s", cmd.Name, err)
+		return ""
+	}
+	return versionRegex.FindString(string(out))
+}
+
 // unmarshalPlugin unmarshals a `plugin.toml` file into a Plugin structure.
 func (m *Manager) unmarshalPlugin(plugin *Plugin, path string) error {
 	data, err := afero.ReadFile(m.fs, path)
@@ -242,14 +365,12 @@ func (m *Manager) unmarshalPlugin(plugin *Plugin, path string) error {
 }
 
 // persistPlugin saves a `plugin.toml` file representing the plugin.
-func (m *Manager) persistPlugin(plugin *Plugin, path string) {
+func (m *Manager) persistPlugin(plugin *Plugin, path string) error {
 	pluginTOML, err := toml.Marshal(*plugin)
-	if err == nil {
-		err = afero.WriteFile(m.fs, path, pluginTOML, 0644)
-	}
 	if err != nil {
-		m.logger.Debug(err)
+		return err
 	}
+	return afero.WriteFile(m.fs, path, pluginTOML, 0644)
 }
 
 // pluginsDir returns the path to the plugins directory.
@@ -257,12 +378,108 @@ func (m *Manager) pluginsDir() string {
 	return filepath.Join(m.cluster.Dir(), "subcommands")
 }
 
+// stagePlugin downloads the resource if it is remote and builds the plugin into a staging directory.
+// For GitHub releases, the asset for the current platform (or the one set in the installation options)
+// is downloaded, the resource is still recorded as the plugin source. The asset is verified against
+// the checksum published with the release, if any, unless a checksum is set in the installation options.
+func (m *Manager) stagePlugin(resource string, installOpts *InstallOpts) (err error) {
+	downloadURL := resource
+	githubResource, err := parseGitHubResource(resource)
+	if err != nil {
+		return err
+	}
+	if githubResource != nil {
+		release, err := m.githubRelease(githubResource.releaseURL)
+		if err != nil {
+			return err
+		}
+		asset, err := release.asset(installOpts.Asset, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			return err
+		}
+		m.logger.Infof("Using asset %s from release %s", asset.Name, release.TagName)
+		downloadURL = asset.BrowserDownloadURL
+
+		// A checksum given explicitly takes precedence over the one published with the release.
+		if checksumAsset := release.checksumAsset(asset.Name); checksumAsset != nil && installOpts.Checksum.Hasher == nil {
+			installOpts.Checksum, err = m.githubChecksum(checksumAsset, asset.Name)
+			if err != nil {
+				return err
+			}
+			if installOpts.Checksum.Hasher != nil {
+				m.logger.Infof("Verifying asset %s against %s", asset.Name, checksumAsset.Name)
+			}
+		}
+		installOpts.releaseTag = release.TagName
+		if installOpts.Name == "" {
+			installOpts.Name = githubResource.repo
+		}
+	}
+
+	if strings.HasPrefix(downloadURL, "https://") || strings.HasPrefix(downloadURL, "http://") {
+		installOpts.path, err = m.downloadPlugin(downloadURL, installOpts.Checksum)
+		if err != nil {
+			return err
+		}
+		installOpts.source = resource
+	} else {
+		installOpts.path = resource
+	}
+
+	// The staging dir is where the plugin will be constructed
+	// before eventually getting moved to its final location.
+	installOpts.stagingDir, err = afero.TempDir(m.fs, os.TempDir(), "dcos-cli")
+	if err != nil {
+		return err
+	}
+	return m.buildPlugin(installOpts)
+}
+
+// removeTempDirs removes the downloaded resource and the staging dir of an installation.
+func (m *Manager) removeTempDirs(installOpts *InstallOpts) {
+	if installOpts.source != "" && installOpts.path != "" {
+		m.fs.RemoveAll(filepath.Dir(installOpts.path))
+	}
+	if installOpts.stagingDir != "" {
+		m.fs.RemoveAll(installOpts.stagingDir)
+	}
+}
+
+// copyPluginFiles copies the files located at the root of an installed plugin directory
+// (eg. package.json) into a staging dir, the env dir is left aside.
+func (m *Manager) copyPluginFiles(pluginDir, stagingDir string) error {
+	entries, err := afero.ReadDir(m.fs, pluginDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		src := filepath.Join(pluginDir, entry.Name())
+		dest := filepath.Join(stagingDir, entry.Name())
+		if err := fsutil.CopyFile(m.fs, src, dest, entry.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // downloadPlugin downloads a plugin and returns the path to the temporary file it stored it to.
-func (m *Manager) downloadPlugin(url string, checksum Checksum) (string, error) {
+//
+// When a checksum is given, it is verified against the downloaded bytes before the resource gets
+// extracted. On failure, the temporary directory is removed and nothing gets installed.
+func (m *Manager) downloadPlugin(url string, checksum Checksum) (downloadedFilePath string, err error) {
 	tmpDir, err := afero.TempDir(m.fs, os.TempDir(), "dcos-cli")
 	if err != nil {
 		return "", err
 	}
+	defer func() {
+		if err != nil {
+			m.fs.RemoveAll(tmpDir)
+		}
+	}()
+	defer m.addCleanup(func() { m.fs.RemoveAll(tmpDir) })()
 
 	resp, err := m.httpClient(url).Get(url)
 	if err != nil {
@@ -270,22 +487,30 @@ func (m *Manager) downloadPlugin(url string, checksum Checksum) (string, error)
 	}
 	defer resp.Body.Close()
 
-	downloadedFilePath := filepath.Join(tmpDir, m.downloadFilename(resp))
+	filename := m.downloadFilename(resp)
+	downloadedFilePath = filepath.Join(tmpDir, filename)
 
-	var respReader io.Reader
+	var respReader io.Reader = resp.Body
 	if checksum.Hasher != nil {
-		respReader = io.TeeReader(resp.Body, checksum.Hasher)
-	} else {
-		respReader = resp.Body
+		respReader = io.TeeReader(respReader, checksum.Hasher)
+	}
+	var progressReader *progress.Reader
+	if m.progressOut != nil {
+		progressReader = progress.NewReader(respReader, m.progressOut, "Downloading "+filename, resp.ContentLength)
+		respReader = progressReader
 	}
-	if err := fsutil.CopyReader(m.fs, respReader, downloadedFilePath, 0644); err != nil {
+	err = fsutil.CopyReader(m.fs, respReader, downloadedFilePath, 0644)
+	if progressReader != nil {
+		progressReader.Done()
+	}
+	if err != nil {
 		return "", err
 	}
 
 	if checksum.Hasher != nil {
 		m.logger.Debugf("Verifying checksum for %s...", url)
 		computedChecksum := hex.EncodeToString(checksum.Hasher.Sum(nil))
-		if computedChecksum != checksum.Value {
+		if computedChecksum != strings.ToLower(checksum.Value) {
 			return "", fmt.Errorf("computed checksum %s for %s, expected %s", computedChecksum, url, checksum.Value)
 		}
 	}
@@ -353,6 +578,28 @@ func (m *Manager) buildPlugin(installOpts *InstallOpts) error {
 		basename := filepath.Base(installOpts.path)
 		installOpts.Name = strings.TrimSuffix(basename, filepath.Ext(basename))
 	}
+	installOpts.plugin = plugin
+
+	// Plugins which don't declare a version in their plugin.toml might print it with the --version flag.
+	// For GitHub releases, the version can also be read from the release tag.
+	if plugin.Version == "" {
+		plugin.Version = m.detectVersion(envDir)
+	}
+	if plugin.Version == "" {
+		plugin.Version = versionRegex.FindString(installOpts.releaseTag)
+	}
+
+	// Record the remote resource, version and pin in the plugin.toml file so that the
+	// plugin can be updated later on. No plugin.toml is written when there is nothing to record.
+	if installOpts.source != "" || installOpts.PinVersion || plugin.Version != "" {
+		plugin.Name = installOpts.Name
+		plugin.Source = installOpts.source
+		plugin.Pinned = installOpts.PinVersion
+		plugin.Asset = installOpts.Asset
+		if err := m.persistPlugin(plugin, filepath.Join(envDir, "plugin.toml")); err != nil {
+			return fmt.Errorf("couldn't save the plugin.toml file: %s", err)
+		}
+	}
 	if installOpts.PostInstall != nil {
 		return installOpts.PostInstall(m.fs, installOpts.stagingDir)
 	}
@@ -364,29 +611,65 @@ func (m *Manager) buildPlugin(installOpts *InstallOpts) error {
 func (m *Manager) installPlugin(installOpts *InstallOpts) error {
 	dest := filepath.Join(m.pluginsDir(), installOpts.Name)
 
-	if installOpts.Update {
-		if err := m.fs.RemoveAll(dest); err != nil {
-			return err
-		}
-	} else {
-		pluginDirExists, err := afero.DirExists(m.fs, dest)
-		if err != nil {
-			m.logger.Debug(err)
-		}
-		if pluginDirExists {
-			return fmt.Errorf("'%s' is already installed", installOpts.Name)
-		}
+	pluginDirExists, err := afero.DirExists(m.fs, dest)
+	if err != nil {
+		m.logger.Debug(err)
+	}
+	if pluginDirExists && !installOpts.Update {
+		return fmt.Errorf("'%s' is already installed", installOpts.Name)
 	}
 
-	if err := m.fs.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+	if err := m.fs.MkdirAll(m.pluginsDir(), 0755); err != nil {
 		return err
 	}
 
-	// Copy the plugin folder to its final location. We don't move it as this causes
+	// Copy the plugin folder next to its final location. We don't move it as this causes
 	// issues when the system's temp dir and the DC/OS dir are on different devices.
 	// See https://groups.google.com/forum/m/#!topic/golang-dev/5w7Jmg_iCJQ.
-	defer m.fs.RemoveAll(installOpts.stagingDir)
-	return fsutil.CopyDir(m.fs, installOpts.stagingDir, dest)
+	tmpDir, err := afero.TempDir(m.fs, m.pluginsDir(), "."+installOpts.Name)
+	if err != nil {
+		return err
+	}
+	defer m.fs.RemoveAll(tmpDir)
+
+	newPluginDir := filepath.Join(tmpDir, "new")
+	oldPluginDir := filepath.Join(tmpDir, "old")
+
+	// On a forced exit, an existing plugin which has been moved aside is put back in place.
+	defer m.addCleanup(func() {
+		if oldPluginDirExists, _ := afero.DirExists(m.fs, oldPluginDir); oldPluginDirExists {
+			if destExists, _ := afero.DirExists(m.fs, dest); !destExists {
+				m.fs.Rename(oldPluginDir, dest)
+			}
+		}
+		m.fs.RemoveAll(tmpDir)
+	})()
+
+	if err := fsutil.CopyDir(m.fs, installOpts.stagingDir, newPluginDir); err != nil {
+		return err
+	}
+
+	// Renames within the plugins dir are atomic, an existing plugin is thus
+	// either left untouched or fully replaced by the new one.
+	if !pluginDirExists {
+		return m.fs.Rename(newPluginDir, dest)
+	}
+	if err := m.fs.Rename(dest, oldPluginDir); err != nil {
+		return err
+	}
+	if err := m.fs.Rename(newPluginDir, dest); err != nil {
+		m.fs.Rename(oldPluginDir, dest)
+		return err
+	}
+	return nil
+}
+
+// addCleanup registers a function to run when the CLI is force-exited, it returns a function which unregisters it.
+func (m *Manager) addCleanup(cleanup func()) (remove func()) {
+	if m.cleaner == nil {
+		return func() {}
+	}
+	return m.cleaner.AddCleanup(cleanup)
 }
 
 // httpClient returns the appropriate HTTP client for a given resource.
@@ -394,16 +677,24 @@ func (m *Manager) httpClient(url string) *httpclient.Client {
 	httpOpts := []httpclient.Option{
 		httpclient.Logger(m.logger),
 		httpclient.FailOnErrStatus(true),
+		httpclient.Context(m.ctx),
+		httpclient.EnvLookup(m.envLookup),
 	}
 	if strings.HasPrefix(url, m.cluster.URL()) {
 		httpOpts = append(
 			httpOpts,
-			httpclient.ACSToken(m.cluster.ACSToken()),
-			httpclient.TLS(&tls.Config{
-				InsecureSkipVerify: m.cluster.TLS().Insecure,
-				RootCAs:            m.cluster.TLS().RootCAs,
-			}),
+			httpclient.TLS(m.cluster.TLSConfig()),
+			httpclient.Proxy(m.cluster.Proxy()),
 		)
+		acsToken, err := m.acsToken(m.cluster)
+		switch {
+		case err != nil:
+			httpOpts = append(httpOpts, httpclient.BeforeRequest(func(req *http.Request) error {
+				return err
+			}))
+		case acsToken != "":
+			httpOpts = append(httpOpts, httpclient.ACSToken(acsToken))
+		}
 	}
 	return httpclient.New("", httpOpts...)
 }
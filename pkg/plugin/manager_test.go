@@ -0,0 +1,306 @@
+package plugin
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	version := "1.0.0"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipPlugin(t, version))
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{}))
+	require.NoError(t, afero.WriteFile(pm.fs, filepath.Join(pm.pluginsDir(), "helloworld", "package.json"), []byte("{}"), 0644))
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "1.0.0", plugins[0].Version)
+	require.Equal(t, ts.URL+"/helloworld.zip", plugins[0].Source)
+
+	updated, err := pm.Update("helloworld")
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	version = "1.1.0"
+	updated, err = pm.Update("helloworld")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	plugins = pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "1.1.0", plugins[0].Version)
+	require.Equal(t, ts.URL+"/helloworld.zip", plugins[0].Source)
+
+	binary, err := afero.ReadFile(pm.fs, filepath.Join(pm.pluginsDir(), "helloworld", "env", "bin", "dcos-hello"))
+	require.NoError(t, err)
+	require.Equal(t, "hello 1.1.0", string(binary))
+
+	// Files outside of the env dir are kept across updates.
+	exists, err := afero.Exists(pm.fs, filepath.Join(pm.pluginsDir(), "helloworld", "package.json"))
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// No temporary directory is left in the plugins dir.
+	entries, err := afero.ReadDir(pm.fs, pm.pluginsDir())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestUpdateFailedDownload(t *testing.T) {
+	fail := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(zipPlugin(t, "1.0.0"))
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{}))
+
+	fail = true
+	_, err := pm.Update("helloworld")
+	require.Error(t, err)
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "1.0.0", plugins[0].Version)
+}
+
+func TestUpdateWithoutRemoteSource(t *testing.T) {
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.fs.MkdirAll(pm.cluster.Dir(), 0755))
+	binPath := filepath.Join(pm.cluster.Dir(), "dcos-hello")
+	require.NoError(t, afero.WriteFile(pm.fs, binPath, []byte("hello"), 0755))
+	require.NoError(t, pm.Install(binPath, &InstallOpts{Name: "hello"}))
+
+	_, err := pm.Update("hello")
+	require.EqualError(t, err, "no remote source recorded for 'hello'")
+
+	_, err = pm.Update("unknown")
+	require.EqualError(t, err, "'unknown' is not installed")
+}
+
+func TestInstallPinVersion(t *testing.T) {
+	version := "1.0.0"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipPlugin(t, version))
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{PinVersion: true}))
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.True(t, plugins[0].Pinned)
+
+	// The pin is kept when the plugin is updated explicitly.
+	version = "1.1.0"
+	updated, err := pm.Update("helloworld")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	plugins = pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "1.1.0", plugins[0].Version)
+	require.True(t, plugins[0].Pinned)
+}
+
+func TestInstallChecksum(t *testing.T) {
+	zipData := zipPlugin(t, "1.0.0")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipData)
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+
+	digest := sha256.Sum256([]byte("corrupted"))
+	checksum, err := SHA256Checksum(hex.EncodeToString(digest[:]))
+	require.NoError(t, err)
+	err = pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{Checksum: checksum})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "computed checksum")
+	require.Len(t, pm.Plugins(), 0)
+
+	digest = sha256.Sum256(zipData)
+	checksum, err = SHA256Checksum(strings.ToUpper(hex.EncodeToString(digest[:])))
+	require.NoError(t, err)
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{Checksum: checksum}))
+	require.Len(t, pm.Plugins(), 1)
+}
+
+func TestInstallReportsProgress(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipPlugin(t, "1.0.0"))
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	pm.SetProgressOutput(&out)
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{}))
+	require.Contains(t, out.String(), "\rDownloading helloworld.zip 100% (")
+	require.True(t, strings.HasSuffix(out.String(), "\n"))
+}
+
+// cleaner is a Cleaner keeping track of the registered cleanup functions.
+type cleaner struct {
+	cleanups map[int]func()
+	nextID   int
+}
+
+func (c *cleaner) AddCleanup(cleanup func()) (remove func()) {
+	id := c.nextID
+	c.nextID++
+	c.cleanups[id] = cleanup
+	return func() { delete(c.cleanups, id) }
+}
+
+func TestInstallRegistersCleanups(t *testing.T) {
+	c := &cleaner{cleanups: make(map[int]func())}
+
+	var cleanupsDuringDownload int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cleanupsDuringDownload = len(c.cleanups)
+		w.Write(zipPlugin(t, "1.0.0"))
+	}))
+	defer ts.Close()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	pm.SetCleaner(c)
+
+	require.NoError(t, pm.Install(ts.URL+"/helloworld.zip", &InstallOpts{}))
+
+	// The temporary files of the installation and of the download are removed on a forced exit.
+	require.Equal(t, 2, cleanupsDuringDownload)
+
+	// The cleanup functions are unregistered once the installation is done.
+	require.Empty(t, c.cleanups)
+}
+
+func TestSHA256Checksum(t *testing.T) {
+	for _, value := range []string{"", "abc", "zz" + strings.Repeat("0", 62)} {
+		_, err := SHA256Checksum(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestInstallDetectsVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.fs.MkdirAll(pm.cluster.Dir(), 0755))
+
+	binPath := filepath.Join(pm.cluster.Dir(), "dcos-hello")
+	script := "#!/bin/sh\n[ \"$2\" = \"--version\" ] && echo 'dcos-hello version v2.3.4-rc1'\n"
+	require.NoError(t, afero.WriteFile(pm.fs, binPath, []byte(script), 0755))
+	require.NoError(t, pm.Install(binPath, &InstallOpts{Name: "hello"}))
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "v2.3.4-rc1", plugins[0].Version)
+	require.Empty(t, plugins[0].Source)
+}
+
+func TestInstallDetectVersionTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin binary is a shell script")
+	}
+	defaultTimeout := detectVersionTimeout
+	detectVersionTimeout = 200 * time.Millisecond
+	defer func() { detectVersionTimeout = defaultTimeout }()
+
+	pm, cleanup := emptyPluginManager(t)
+	defer cleanup()
+	require.NoError(t, pm.fs.MkdirAll(pm.cluster.Dir(), 0755))
+
+	// The command doesn't know the --version flag, it waits for input and then hangs.
+	binPath := filepath.Join(pm.cluster.Dir(), "dcos-hello")
+	script := "#!/bin/sh\n[ \"$2\" = \"--info\" ] && exit 0\nread -r line\nexec sleep 30\n"
+	require.NoError(t, afero.WriteFile(pm.fs, binPath, []byte(script), 0755))
+
+	start := time.Now()
+	require.NoError(t, pm.Install(binPath, &InstallOpts{Name: "hello"}))
+	require.True(t, time.Since(start) < 10*time.Second)
+
+	plugins := pm.Plugins()
+	require.Len(t, plugins, 1)
+	require.Empty(t, plugins[0].Version)
+}
+
+// emptyPluginManager returns a plugin manager for a cluster without plugins, located in a temporary directory.
+// The OS filesystem is used as the in-memory one doesn't support renaming directories.
+func emptyPluginManager(t *testing.T) (*Manager, func()) {
+	fs := afero.NewOsFs()
+	logger, _ := test.NewNullLogger()
+
+	dir, err := afero.TempDir(fs, "", "dcos-cli")
+	require.NoError(t, err)
+
+	conf := config.New(config.Opts{
+		Fs: fs,
+	})
+	conf.SetPath(filepath.Join(dir, "clusters", "79893270-3ccd-4c17-a2ce-32d8eb1b1763", "dcos.toml"))
+
+	pluginManager := NewManager(fs, logger)
+	pluginManager.SetCluster(config.NewCluster(conf))
+	return pluginManager, func() { fs.RemoveAll(dir) }
+}
+
+// zipPlugin creates a ZIP plugin named "helloworld" with a given version.
+func zipPlugin(t *testing.T, version string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	files := map[string]string{
+		"plugin.toml": `name = "helloworld"
+version = "` + version + `"
+
+[[commands]]
+name = "hello"
+path = "bin/dcos-hello"
+description = "Say hello"
+`,
+		"bin/dcos-hello": "hello " + version,
+	}
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
@@ -4,6 +4,10 @@ package plugin
 // It also contains JSON tags for the `dcos plugin list --json` command.
 type Plugin struct {
 	Name     string    `toml:"name" json:"name"`
+	Version  string    `toml:"version,omitempty" json:"version,omitempty"`
+	Source   string    `toml:"source,omitempty" json:"source,omitempty"`
+	Pinned   bool      `toml:"pinned,omitempty" json:"pinned,omitempty"`
+	Asset    string    `toml:"asset,omitempty" json:"asset,omitempty"`
 	Commands []Command `toml:"commands" json:"commands"`
 }
 
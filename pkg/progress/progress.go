@@ -0,0 +1,97 @@
+// Package progress reports the progress of data transfers, such as plugin downloads.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// renderInterval is the minimum interval between two renderings of the progress.
+const renderInterval = 100 * time.Millisecond
+
+// spinnerFrames are the frames of the spinner displayed when the total size is unknown.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Enabled indicates whether or not progress should be reported to a writer.
+// It is only the case for terminals, progress would otherwise garble logs or scripts output.
+func Enabled(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
+// Reader is an io.Reader reporting the number of bytes read from an underlying reader.
+//
+// When the total size is known it renders a percentage, otherwise it renders a spinner along with
+// the number of bytes read. The progress is rendered at most every 100ms, regardless of the number
+// of reads, in order not to slow down the transfer.
+type Reader struct {
+	r          io.Reader
+	out        io.Writer
+	label      string
+	total      int64
+	read       int64
+	frame      int
+	lastRender time.Time
+	now        func() time.Time
+}
+
+// NewReader returns a Reader for a transfer of a given total size, a negative or
+// zero total means the size is unknown (eg. when there is no Content-Length).
+func NewReader(r io.Reader, out io.Writer, label string, total int64) *Reader {
+	return &Reader{
+		r:     r,
+		out:   out,
+		label: label,
+		total: total,
+		now:   time.Now,
+	}
+}
+
+// Read reads from the underlying reader and renders the progress when needed.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+	if now := r.now(); now.Sub(r.lastRender) >= renderInterval {
+		r.lastRender = now
+		r.render()
+	}
+	return n, err
+}
+
+// Done renders the final progress and terminates the progress line.
+func (r *Reader) Done() {
+	r.render()
+	fmt.Fprintln(r.out)
+}
+
+// render renders the current progress, overwriting the current line.
+func (r *Reader) render() {
+	if r.total > 0 {
+		percent := r.read * 100 / r.total
+		if percent > 100 {
+			percent = 100
+		}
+		fmt.Fprintf(r.out, "\r%s %3d%% (%s / %s)", r.label, percent, formatBytes(r.read), formatBytes(r.total))
+		return
+	}
+	fmt.Fprintf(r.out, "\r%s %s %s", r.label, spinnerFrames[r.frame], formatBytes(r.read))
+	r.frame = (r.frame + 1) % len(spinnerFrames)
+}
+
+// formatBytes formats a number of bytes in a human readable form (eg. "4.2 MiB").
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for i := n / unit; i >= unit; i /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,49 @@
+package progress
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	var out bytes.Buffer
+	data := strings.Repeat("a", 2048)
+	r := NewReader(strings.NewReader(data), &out, "Downloading plugin.zip", int64(len(data)))
+
+	// Renderings are throttled, a single rendering happens with a frozen clock.
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	buf := make([]byte, 512)
+	for i := 0; i < 4; i++ {
+		_, err := r.Read(buf)
+		require.NoError(t, err)
+	}
+	require.Equal(t, "\rDownloading plugin.zip  25% (512 B / 2.0 KiB)", out.String())
+
+	r.Done()
+	require.True(t, strings.HasSuffix(out.String(), "\rDownloading plugin.zip 100% (2.0 KiB / 2.0 KiB)\n"))
+}
+
+func TestReaderUnknownSize(t *testing.T) {
+	var out bytes.Buffer
+	r := NewReader(strings.NewReader(strings.Repeat("a", 3*1024*1024)), &out, "Downloading", -1)
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Len(t, data, 3*1024*1024)
+
+	r.Done()
+	require.True(t, strings.HasSuffix(out.String(), " 3.0 MiB\n"))
+	require.Contains(t, out.String(), "\rDownloading | ")
+}
+
+func TestEnabled(t *testing.T) {
+	require.False(t, Enabled(&bytes.Buffer{}))
+	require.False(t, Enabled(nil))
+}
@@ -12,10 +12,17 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+// ErrConfirmationRequired is returned when a confirmation can't be prompted for as the input is not a terminal.
+var ErrConfirmationRequired = errors.New("refusing to proceed without confirmation; pass --yes")
+
+// ErrPasswordRequired is returned when a password is prompted for but the input is not a terminal and has none.
+var ErrPasswordRequired = errors.New("no password provided on the standard input")
+
 // Prompt prompts for interactive questions.
 type Prompt struct {
-	in  io.Reader
-	out io.Writer
+	in        io.Reader
+	out       io.Writer
+	assumeYes bool
 }
 
 // New returns a new prompt.
@@ -26,6 +33,11 @@ func New(input io.Reader, output io.Writer) *Prompt {
 	}
 }
 
+// SetAssumeYes sets whether or not confirmations should be accepted without reading the input.
+func (prompt *Prompt) SetAssumeYes(assumeYes bool) {
+	prompt.assumeYes = assumeYes
+}
+
 // Input prompts for a string input.
 func (prompt *Prompt) Input(msg string) string {
 	fmt.Fprint(prompt.out, msg)
@@ -35,18 +47,33 @@ func (prompt *Prompt) Input(msg string) string {
 	return scanner.Text()
 }
 
-// Password prompts for a password. It is similar to input, except that it doesn't echo back to the terminal.
-func (prompt *Prompt) Password(msg string) string {
+// Password prompts for a password. When the input is a terminal, echo is disabled while the
+// password is typed so that it doesn't appear on screen or in the scrollback. Otherwise (eg.
+// when the password is piped in), a line is read from the input and ErrPasswordRequired is
+// returned when it is empty.
+func (prompt *Prompt) Password(msg string) (string, error) {
 	f, ok := prompt.in.(*os.File)
 	if !ok || !terminal.IsTerminal(int(f.Fd())) {
-		return prompt.Input(msg)
+		fmt.Fprint(prompt.out, msg)
+		scanner := bufio.NewScanner(prompt.in)
+		if !scanner.Scan() || scanner.Text() == "" {
+			fmt.Fprint(prompt.out, "\n")
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", ErrPasswordRequired
+		}
+		return scanner.Text(), nil
 	}
 
 	fmt.Fprint(prompt.out, msg)
 	defer fmt.Fprint(prompt.out, "\n")
 
-	pass, _ := terminal.ReadPassword(int(f.Fd()))
-	return string(pass)
+	pass, err := terminal.ReadPassword(int(f.Fd()))
+	if err != nil {
+		return "", err
+	}
+	return string(pass), nil
 }
 
 // Select allows to pick an item from a list of choices. For example :
@@ -86,7 +113,19 @@ func (prompt *Prompt) Select(msg string, choices interface{}) (int, error) {
 //
 // defaultChoice is the default answer to fallback to in case
 // no explicit input is provided (eg. user just pressed ENTER).
+//
+// When the prompt assumes yes, the confirmation is accepted without reading the input.
+// Otherwise, when the input is a file which is not a terminal (eg. in a script), the
+// confirmation fails with ErrConfirmationRequired rather than reading the file.
 func (prompt *Prompt) Confirm(msg, defaultChoice string) error {
+	if prompt.assumeYes {
+		fmt.Fprintf(prompt.out, "%syes\n", msg)
+		return nil
+	}
+	if f, ok := prompt.in.(*os.File); ok && !terminal.IsTerminal(int(f.Fd())) {
+		return ErrConfirmationRequired
+	}
+
 	reader := bufio.NewReader(prompt.in)
 
 ConfirmLoop:
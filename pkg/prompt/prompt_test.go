@@ -2,6 +2,8 @@ package prompt
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 
@@ -19,11 +21,33 @@ func TestInput(t *testing.T) {
 func TestPassword(t *testing.T) {
 	var buf bytes.Buffer
 	prompt := New(strings.NewReader("pass\n"), &buf)
-	val := prompt.Input("Password:")
+	val, err := prompt.Password("Password:")
+	require.NoError(t, err)
 	require.Equal(t, "Password:", buf.String())
 	require.Equal(t, "pass", val)
 }
 
+func TestPasswordWithoutTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "dcos-cli-prompt")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	var buf bytes.Buffer
+	prompt := New(f, &buf)
+	_, err = prompt.Password("Password: ")
+	require.Equal(t, ErrPasswordRequired, err)
+
+	_, err = f.WriteString("secret\n")
+	require.NoError(t, err)
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+
+	val, err := prompt.Password("Password: ")
+	require.NoError(t, err)
+	require.Equal(t, "secret", val)
+}
+
 func TestSelect(t *testing.T) {
 	fixtures := []struct {
 		input         string
@@ -86,3 +110,29 @@ func TestConfirm(t *testing.T) {
 		}
 	}
 }
+
+func TestConfirmAssumeYes(t *testing.T) {
+	var buf bytes.Buffer
+	prompt := New(strings.NewReader("n\n"), &buf)
+	prompt.SetAssumeYes(true)
+
+	require.NoError(t, prompt.Confirm("Please confirm: ", ""))
+	require.Equal(t, "Please confirm: yes\n", buf.String())
+}
+
+func TestConfirmWithoutTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "dcos-cli-prompt")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	_, err = f.WriteString("y\n")
+	require.NoError(t, err)
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	prompt := New(f, &buf)
+	require.Equal(t, ErrConfirmationRequired, prompt.Confirm("Please confirm: ", "y"))
+	require.Empty(t, buf.String())
+}
@@ -1,6 +1,11 @@
 package setup
 
 import (
+	"crypto/tls"
+	"errors"
+	"path/filepath"
+
+	"github.com/dcos/dcos-cli/pkg/config"
 	"github.com/dcos/dcos-cli/pkg/login"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
@@ -11,7 +16,13 @@ import (
 type Flags struct {
 	caBundle     []byte
 	caBundlePath string
+	clientCert   *tls.Certificate
+	certPath     string
+	keyPath      string
 	name         string
+	fingerprint  string
+	bundlePath   string
+	force        bool
 	noCheck      bool
 	noPlugin     bool
 	insecure     bool
@@ -51,7 +62,25 @@ func (f *Flags) Register(flags *pflag.FlagSet) {
 		&f.caBundlePath,
 		"ca-certs",
 		"",
-		"Specify the path to a file with trusted CAs to verify requests against.",
+		"Specify the path to a file with trusted CAs to verify requests against, they are trusted without confirmation.",
+	)
+	flags.StringVar(
+		&f.certPath,
+		"client-cert",
+		"",
+		"Specify the path to a client certificate for TLS client authentication.",
+	)
+	flags.StringVar(
+		&f.keyPath,
+		"client-key",
+		"",
+		"Specify the path to the private key of the client certificate.",
+	)
+	flags.StringVar(
+		&f.fingerprint,
+		"cert-fingerprint",
+		"",
+		"Specify the SHA-256 fingerprint of the cluster certificate to pin it.",
 	)
 	flags.StringVar(
 		&f.name,
@@ -59,6 +88,18 @@ func (f *Flags) Register(flags *pflag.FlagSet) {
 		"",
 		"Specify a custom name for the cluster.",
 	)
+	flags.StringVar(
+		&f.bundlePath,
+		"from-bundle",
+		"",
+		"Specify the path to a cluster bundle created by `dcos cluster export`.",
+	)
+	flags.BoolVar(
+		&f.force,
+		"force",
+		false,
+		"Overwrite the cluster config when it is already configured (with --from-bundle).",
+	)
 	f.loginFlags.Register(flags)
 }
 
@@ -74,9 +115,45 @@ func (f *Flags) Resolve() error {
 		// Don't prompt for fingerprint confirmation when a CA is explicitly passed.
 		f.noCheck = true
 	}
+	if f.fingerprint != "" {
+		if _, err := config.ParseCertFingerprint(f.fingerprint); err != nil {
+			return err
+		}
+	}
+	if f.certPath != "" || f.keyPath != "" {
+		if f.certPath == "" || f.keyPath == "" {
+			return errors.New("--client-cert and --client-key must be passed together")
+		}
+		clientCert, err := config.LoadClientCertificate(f.fs, f.certPath, f.keyPath)
+		if err != nil {
+			return err
+		}
+		f.clientCert = clientCert
+
+		// Store absolute paths as the config is not related to the current working directory.
+		if f.certPath, err = filepath.Abs(f.certPath); err != nil {
+			return err
+		}
+		if f.keyPath, err = filepath.Abs(f.keyPath); err != nil {
+			return err
+		}
+	}
 	return f.loginFlags.Resolve()
 }
 
+// clientCertificates returns the client certificates to use for TLS client authentication.
+func (f *Flags) clientCertificates() []tls.Certificate {
+	if f.clientCert == nil {
+		return nil
+	}
+	return []tls.Certificate{*f.clientCert}
+}
+
+// FromBundle indicates whether or not the cluster is set up from a bundle.
+func (f *Flags) FromBundle() bool {
+	return f.bundlePath != ""
+}
+
 // LoginFlags returns the login flags.
 func (f *Flags) LoginFlags() *login.Flags {
 	return f.loginFlags
@@ -1,7 +1,7 @@
 package setup
 
 import (
-	"bytes"
+	"context"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
@@ -17,6 +17,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dcos/dcos-cli/pkg/config"
@@ -33,6 +34,7 @@ import (
 
 // Opts are options for a setup.
 type Opts struct {
+	Context       context.Context
 	Fs            afero.Fs
 	Errout        io.Writer
 	Prompt        *prompt.Prompt
@@ -41,10 +43,15 @@ type Opts struct {
 	ConfigManager *config.Manager
 	PluginManager *plugin.Manager
 	EnvLookup     func(key string) (string, bool)
+
+	// ACSToken resolves the ACS token of a cluster once it is logged in,
+	// it defaults to Cluster.ResolveACSToken.
+	ACSToken func(cluster *config.Cluster) (string, error)
 }
 
 // Setup represents a cluster setup.
 type Setup struct {
+	ctx           context.Context
 	fs            afero.Fs
 	errout        io.Writer
 	prompt        *prompt.Prompt
@@ -53,11 +60,19 @@ type Setup struct {
 	configManager *config.Manager
 	pluginManager *plugin.Manager
 	envLookup     func(key string) (string, bool)
+	acsToken      func(cluster *config.Cluster) (string, error)
 }
 
 // New creates a new setup.
 func New(opts Opts) *Setup {
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	if opts.ACSToken == nil {
+		opts.ACSToken = (*config.Cluster).ResolveACSToken
+	}
 	return &Setup{
+		ctx:           opts.Context,
 		fs:            opts.Fs,
 		errout:        opts.Errout,
 		prompt:        opts.Prompt,
@@ -66,6 +81,7 @@ func New(opts Opts) *Setup {
 		configManager: opts.ConfigManager,
 		pluginManager: opts.PluginManager,
 		envLookup:     opts.EnvLookup,
+		acsToken:      opts.ACSToken,
 	}
 }
 
@@ -75,25 +91,56 @@ func (s *Setup) Configure(flags *Flags, clusterURL string, attach bool) (*config
 		return nil, err
 	}
 
+	if flags.bundlePath != "" {
+		return s.importBundle(flags, attach)
+	}
+
 	s.logger.Info("Setting up the cluster...")
 
 	// Create a Cluster and an HTTP client with the few information already available.
 	cluster := config.NewCluster(nil)
 	cluster.SetURL(clusterURL)
 	cluster.SetTLS(config.TLS{Insecure: flags.insecure})
+	if flags.clientCert != nil {
+		cluster.SetClientCertificate(flags.certPath, flags.keyPath)
+	}
+	if flags.fingerprint != "" {
+		cluster.SetTLSCertFingerprint(flags.fingerprint)
+	}
 
 	httpOpts := []httpclient.Option{
 		httpclient.Timeout(5 * time.Second),
+		httpclient.Context(s.ctx),
 		httpclient.Logger(s.logger),
+		httpclient.EnvLookup(s.envLookup),
 		httpclient.NoFollow(),
+		httpclient.TLS(&tls.Config{Certificates: flags.clientCertificates()}),
 	}
 
 	// Create the TLS configuration if it's an HTTPS URL.
+	var certFingerprint string
+	var recordFingerprint sync.Once
 	if strings.HasPrefix(cluster.URL(), "https://") {
 		tlsConfig, err := s.configureTLS(cluster.URL(), httpOpts, flags)
 		if err != nil {
 			return nil, err
 		}
+
+		// Record the fingerprint of the server certificate, verifying it when it is pinned.
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if flags.fingerprint != "" {
+				verify := config.VerifyCertFingerprint(flags.fingerprint)
+				if err := verify(rawCerts, verifiedChains); err != nil {
+					return err
+				}
+			}
+			if len(rawCerts) > 0 {
+				recordFingerprint.Do(func() {
+					certFingerprint = config.CertFingerprint(rawCerts[0])
+				})
+			}
+			return nil
+		}
 		httpOpts = append(httpOpts, httpclient.TLS(tlsConfig))
 	}
 
@@ -102,13 +149,18 @@ func (s *Setup) Configure(flags *Flags, clusterURL string, attach bool) (*config
 	if acsToken == "" {
 		httpClient := httpclient.New(cluster.URL(), httpOpts...)
 		var err error
+		s.loginFlow.SetProxy(cluster.Proxy())
 		acsToken, err = s.loginFlow.Start(flags.loginFlags, httpClient)
 		if err != nil {
 			return nil, err
 		}
 	}
 	cluster.SetACSToken(acsToken)
-	httpClient := httpclient.New(cluster.URL(), append(httpOpts, httpclient.ACSToken(cluster.ACSToken()))...)
+	acsToken, err := s.acsToken(cluster)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := httpclient.New(cluster.URL(), append(httpOpts, httpclient.ACSToken(acsToken))...)
 
 	// Read cluster ID from cluster metadata.
 	metadata, err := dcos.NewClient(httpClient).Metadata()
@@ -149,15 +201,95 @@ func (s *Setup) Configure(flags *Flags, clusterURL string, attach bool) (*config
 		}
 	}
 
+	if certFingerprint != "" {
+		fmt.Fprintf(s.errout, "Cluster certificate SHA256 fingerprint: %s\n", certFingerprint)
+	}
 	s.logger.Infof("%s is now setup", clusterURL)
 	return cluster, nil
 }
 
+// importBundle configures a cluster from a bundle created by `dcos cluster export`. The TLS configuration
+// comes from the bundle, the user is thus not prompted to trust the cluster CA. When the bundle doesn't
+// contain an ACS token, the login flow is started.
+func (s *Setup) importBundle(flags *Flags, attach bool) (*config.Cluster, error) {
+	bundle, err := config.ReadBundle(s.fs, flags.bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	httpOpts := []httpclient.Option{
+		httpclient.Timeout(5 * time.Second),
+		httpclient.Context(s.ctx),
+		httpclient.Logger(s.logger),
+		httpclient.EnvLookup(s.envLookup),
+	}
+
+	// The login happens before the config is moved into place, a failed setup thus
+	// leaves an existing config of the cluster untouched.
+	s.logger.Infof("Setting up cluster %s from %s...", bundle.ID, flags.bundlePath)
+	cluster, err := s.configManager.Import(bundle, config.ImportOpts{
+		Force: flags.force,
+		Setup: func(cluster *config.Cluster) error {
+			if cluster.ACSToken() != "" {
+				return nil
+			}
+			acsToken, _ := s.envLookup("DCOS_CLUSTER_SETUP_ACS_TOKEN")
+			if acsToken == "" {
+				var err error
+				loginHTTPOpts := append(httpOpts, httpclient.TLS(cluster.TLSConfig()), httpclient.Proxy(cluster.Proxy()))
+				s.loginFlow.SetProxy(cluster.Proxy())
+				acsToken, err = s.loginFlow.Start(flags.loginFlags, httpclient.New(cluster.URL(), loginHTTPOpts...))
+				if err != nil {
+					return err
+				}
+			}
+			return cluster.Config().Update(func(conf *config.Config) {
+				cluster.SetACSToken(acsToken)
+			})
+		},
+	})
+	if err == config.ErrClusterExists {
+		return nil, fmt.Errorf("cluster %s is already configured, pass --force to overwrite it", bundle.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The CA bundle has been moved along with the config.
+	httpOpts = append(httpOpts, httpclient.TLS(cluster.TLSConfig()), httpclient.Proxy(cluster.Proxy()))
+
+	if attach {
+		if err := s.configManager.Attach(cluster.Config()); err != nil {
+			return nil, err
+		}
+		s.logger.Infof("You are now attached to cluster %s", cluster.ID())
+	}
+
+	if !flags.noPlugin {
+		acsToken, err := s.acsToken(cluster)
+		if err != nil {
+			return nil, err
+		}
+		httpClient := httpclient.New(cluster.URL(), append(httpOpts, httpclient.ACSToken(acsToken))...)
+		s.pluginManager.SetCluster(cluster)
+		if err := s.installDefaultPlugins(httpClient); err != nil {
+			return nil, err
+		}
+	}
+	s.logger.Infof("%s is now setup", cluster.URL())
+	return cluster, nil
+}
+
 // configureTLS creates the TLS configuration for a given cluster URL and set of flags.
 func (s *Setup) configureTLS(clusterURL string, httpOpts []httpclient.Option, flags *Flags) (*tls.Config, error) {
 	// Return early with an insecure TLS config when `--insecure` is passed.
 	if flags.insecure {
-		return &tls.Config{InsecureSkipVerify: true}, nil
+		fmt.Fprintln(s.errout, "WARNING: TLS certificate verification is disabled (--insecure), there is no guarantee "+
+			"that the remote end is your actual DC/OS cluster. This should only be used during development / testing.")
+		return &tls.Config{
+			InsecureSkipVerify: true,
+			Certificates:       flags.clientCertificates(),
+		}, nil
 	}
 
 	// If no custom CA bundle is explicitly provided, download the cluster's CA bundle.
@@ -167,7 +299,7 @@ func (s *Setup) configureTLS(clusterURL string, httpOpts []httpclient.Option, fl
 			return nil, err
 		}
 		if needsDCOSCABundle {
-			flags.caBundle, err = s.downloadDCOSCABundle(clusterURL, httpOpts, !flags.noCheck)
+			flags.caBundle, err = s.downloadDCOSCABundle(clusterURL, httpOpts, flags.clientCertificates())
 			if err != nil {
 				return nil, err
 			}
@@ -183,8 +315,17 @@ func (s *Setup) configureTLS(clusterURL string, httpOpts []httpclient.Option, fl
 		if err != nil {
 			return nil, err
 		}
+		if certPool == nil {
+			if flags.caBundlePath != "" {
+				return nil, fmt.Errorf("no certificate found in CA bundle '%s'", flags.caBundlePath)
+			}
+			return nil, errors.New("no certificate found in the CA bundle downloaded from the cluster")
+		}
 	}
-	return &tls.Config{RootCAs: certPool}, nil
+	return &tls.Config{
+		RootCAs:      certPool,
+		Certificates: flags.clientCertificates(),
+	}, nil
 }
 
 // needsDCOSCABundle checks whether or not the cluster certificate is already trusted
@@ -201,13 +342,9 @@ func (s *Setup) needsDCOSCABundle(clusterURL string, httpOpts []httpclient.Optio
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		urlErr, ok := err.(*url.Error)
-		if !ok {
+		if _, ok := err.(*url.Error); !ok || !isUnknownAuthority(err) {
 			return false, err
 		}
-		if _, ok := urlErr.Err.(x509.UnknownAuthorityError); !ok {
-			return false, urlErr
-		}
 		return true, nil
 	}
 
@@ -215,10 +352,27 @@ func (s *Setup) needsDCOSCABundle(clusterURL string, httpOpts []httpclient.Optio
 	return false, nil
 }
 
+// isUnknownAuthority indicates whether or not an error is due to a certificate signed by an unknown
+// authority. Depending on the Go version, the x509 error can be wrapped in a TLS verification error.
+func isUnknownAuthority(err error) bool {
+	for err != nil {
+		if _, ok := err.(x509.UnknownAuthorityError); ok {
+			return true
+		}
+		wrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = wrapper.Unwrap()
+	}
+	return false
+}
+
 // downloadDCOSCABundle downloads the cluster certificate authority at "/ca/dcos-ca.crt".
-func (s *Setup) downloadDCOSCABundle(clusterURL string, httpOpts []httpclient.Option, prompt bool) ([]byte, error) {
+func (s *Setup) downloadDCOSCABundle(clusterURL string, httpOpts []httpclient.Option, clientCerts []tls.Certificate) ([]byte, error) {
 	insecureHTTPClient := httpclient.New(clusterURL, append(httpOpts, httpclient.TLS(&tls.Config{
 		InsecureSkipVerify: true,
+		Certificates:       clientCerts,
 	}))...)
 
 	resp, err := insecureHTTPClient.Get("/ca/dcos-ca.crt")
@@ -236,6 +390,7 @@ func (s *Setup) downloadDCOSCABundle(clusterURL string, httpOpts []httpclient.Op
 
 // decodePEMCerts creates a x509.CertPool struct based on a PEM certificate authority bundle.
 // When prompt is set to true, the first certificate in the bundle is prompted for user confirmation.
+// The returned pool is nil when the bundle doesn't contain any certificate.
 func (s *Setup) decodePEMCerts(caPEM []byte, prompt bool) (*x509.CertPool, error) {
 	var certPool *x509.CertPool
 	for len(caPEM) > 0 {
@@ -362,19 +517,10 @@ func (s *Setup) installPlugin(name string, httpClient *httpclient.Client) error
 // promptCA prompts information about the certificate authority to the user.
 // They are then expected to manually confirm that they trust it.
 func (s *Setup) promptCA(cert *x509.Certificate) error {
-	var fingerprintBuf bytes.Buffer
-
-	for i, val := range sha256.Sum256(cert.Raw) {
-		fingerprintBuf.WriteString(fmt.Sprintf("%02X", val))
-
-		if i != sha256.Size-1 {
-			fingerprintBuf.WriteString(":")
-		}
-	}
-
 	msg := `Cluster Certificate Authority:
 
-  Issuer: %s
+  Subject: %s
+  Issuer:  %s
 
   Validity:
     From:  %s
@@ -386,10 +532,11 @@ Do you trust it? [y/n] `
 
 	return s.prompt.Confirm(fmt.Sprintf(
 		msg,
+		cert.Subject,
 		cert.Issuer,
 		cert.NotBefore,
 		cert.NotAfter,
-		fingerprintBuf.String(),
+		config.CertFingerprint(cert.Raw),
 	), "")
 }
 
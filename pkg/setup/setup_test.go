@@ -0,0 +1,131 @@
+package setup
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcos/dcos-cli/pkg/config"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+	"github.com/dcos/dcos-cli/pkg/prompt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSServer returns a TLS server serving its own certificate as the DC/OS CA bundle.
+func newTLSServer() *httptest.Server {
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ca/dcos-ca.crt" {
+			pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+		}
+	})
+	ts.StartTLS()
+	return ts
+}
+
+func newSetup(input string, errout *bytes.Buffer) *Setup {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	return New(Opts{
+		Fs:     afero.NewMemMapFs(),
+		Errout: errout,
+		Prompt: prompt.New(strings.NewReader(input), errout),
+		Logger: logger,
+	})
+}
+
+func newFlags(fs afero.Fs) *Flags {
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	return NewFlags(fs, func(key string) (string, bool) { return "", false }, logger)
+}
+
+func httpOpts() []httpclient.Option {
+	return []httpclient.Option{httpclient.Timeout(5 * time.Second)}
+}
+
+func TestConfigureTLSWithConfirmedCA(t *testing.T) {
+	ts := newTLSServer()
+	defer ts.Close()
+
+	var errout bytes.Buffer
+	flags := newFlags(afero.NewMemMapFs())
+
+	tlsConfig, err := newSetup("y\n", &errout).configureTLS(ts.URL, httpOpts(), flags)
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig.RootCAs)
+
+	require.Contains(t, errout.String(), "Subject: O=Acme Co")
+	require.Contains(t, errout.String(), "SHA256 fingerprint: "+config.CertFingerprint(ts.Certificate().Raw))
+	require.Equal(t, flags.caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+
+	// The confirmed CA is used to verify the cluster certificate.
+	resp, err := httpclient.New(ts.URL, append(httpOpts(), httpclient.TLS(tlsConfig))...).Get("/")
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestConfigureTLSWithRefusedCA(t *testing.T) {
+	ts := newTLSServer()
+	defer ts.Close()
+
+	var errout bytes.Buffer
+	flags := newFlags(afero.NewMemMapFs())
+
+	_, err := newSetup("n\n", &errout).configureTLS(ts.URL, httpOpts(), flags)
+	require.EqualError(t, err, "couldn't get confirmation")
+}
+
+func TestConfigureTLSWithCACerts(t *testing.T) {
+	ts := newTLSServer()
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	require.NoError(t, afero.WriteFile(fs, "/ca.crt", caPEM, 0600))
+
+	var errout bytes.Buffer
+	flags := newFlags(fs)
+	flags.caBundlePath = "/ca.crt"
+	require.NoError(t, flags.Resolve())
+
+	tlsConfig, err := newSetup("", &errout).configureTLS(ts.URL, httpOpts(), flags)
+	require.NoError(t, err)
+	require.Empty(t, errout.String())
+
+	expectedPool := x509.NewCertPool()
+	expectedPool.AddCert(ts.Certificate())
+	require.Equal(t, expectedPool.Subjects(), tlsConfig.RootCAs.Subjects())
+}
+
+func TestConfigureTLSWithEmptyCACerts(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/ca.crt", []byte("not a certificate"), 0600))
+
+	var errout bytes.Buffer
+	flags := newFlags(fs)
+	flags.caBundlePath = "/ca.crt"
+	require.NoError(t, flags.Resolve())
+
+	_, err := newSetup("", &errout).configureTLS("https://dcos.example.com", httpOpts(), flags)
+	require.EqualError(t, err, "no certificate found in CA bundle '/ca.crt'")
+}
+
+func TestConfigureTLSInsecure(t *testing.T) {
+	var errout bytes.Buffer
+	flags := newFlags(afero.NewMemMapFs())
+	flags.insecure = true
+
+	tlsConfig, err := newSetup("", &errout).configureTLS("https://dcos.example.com", httpOpts(), flags)
+	require.NoError(t, err)
+	require.True(t, tlsConfig.InsecureSkipVerify)
+	require.Contains(t, errout.String(), "WARNING: TLS certificate verification is disabled (--insecure)")
+}
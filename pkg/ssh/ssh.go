@@ -0,0 +1,100 @@
+// Package ssh builds the ssh commands used to connect to the nodes of a DC/OS cluster.
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultUser is the default user to log in as on DC/OS nodes.
+const DefaultUser = "core"
+
+// safeArg matches arguments which don't need to be quoted in a shell.
+var safeArg = regexp.MustCompile(`^[A-Za-z0-9@%_+=:,./-]+$`)
+
+// Opts are options for an ssh command.
+type Opts struct {
+	// Host is the host to connect to, eg. the IP of an agent. IPv6 addresses may be bracketed.
+	Host string
+
+	// User is the user to log in as, it defaults to DefaultUser.
+	User string
+
+	// ConfigFile is an alternative ssh config file (-F).
+	ConfigFile string
+
+	// Options are ssh options in the "key=value" format (-o).
+	Options []string
+
+	// ProxyHost is a jump host to connect through (-J), eg. a master for private agents.
+	// When it doesn't specify a user, the user of the command is used. IPv6 addresses are bracketed.
+	ProxyHost string
+
+	// Command is an optional remote command along with its arguments.
+	Command []string
+}
+
+// Args returns the arguments to pass to the ssh binary for the given options.
+func Args(opts Opts) []string {
+	if opts.User == "" {
+		opts.User = DefaultUser
+	}
+
+	var args []string
+	if opts.ConfigFile != "" {
+		args = append(args, "-F", opts.ConfigFile)
+	}
+	for _, option := range opts.Options {
+		args = append(args, "-o", option)
+	}
+	if opts.ProxyHost != "" {
+		proxy := opts.ProxyHost
+		if !strings.Contains(proxy, "@") {
+			proxy = opts.User + "@" + proxy
+		}
+		args = append(args, "-J", bracketIPv6(proxy))
+	}
+	// The destination is a bare host, ssh doesn't strip brackets around IPv6 addresses.
+	host := strings.TrimSuffix(strings.TrimPrefix(opts.Host, "["), "]")
+	args = append(args, opts.User+"@"+host)
+	return append(args, opts.Command...)
+}
+
+// bracketIPv6 encloses the host of a "user@host" jump host in brackets when it is an IPv6
+// address, otherwise ssh would mistake the last group of the address for a port.
+func bracketIPv6(userHost string) string {
+	i := strings.LastIndex(userHost, "@")
+	host := userHost[i+1:]
+	if strings.Contains(host, ":") && net.ParseIP(host) != nil {
+		return userHost[:i+1] + "[" + host + "]"
+	}
+	return userHost
+}
+
+// Command returns the ssh command for the given options, it relies on the system ssh binary.
+func Command(opts Opts) (*exec.Cmd, error) {
+	if opts.Host == "" {
+		return nil, fmt.Errorf("no host to connect to")
+	}
+	sshPath, err := exec.LookPath("ssh")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find the ssh binary: %s", err)
+	}
+	return exec.Command(sshPath, Args(opts)...), nil
+}
+
+// CommandLine returns a printable command line for a binary and its arguments, arguments
+// are quoted when needed so that the command can be copy-pasted into a shell.
+func CommandLine(name string, args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{name}, args...) {
+		if !safeArg.MatchString(arg) {
+			arg = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
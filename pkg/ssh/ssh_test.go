@@ -0,0 +1,51 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgs(t *testing.T) {
+	fixtures := []struct {
+		opts Opts
+		args []string
+	}{
+		{Opts{Host: "10.0.0.1"}, []string{"core@10.0.0.1"}},
+		{Opts{Host: "10.0.0.1", User: "centos"}, []string{"centos@10.0.0.1"}},
+		{
+			Opts{
+				Host:       "10.0.0.1",
+				ConfigFile: "/home/user/.ssh/dcos_config",
+				Options:    []string{"StrictHostKeyChecking=no", "UserKnownHostsFile=/dev/null"},
+				Command:    []string{"journalctl", "-f"},
+			},
+			[]string{
+				"-F", "/home/user/.ssh/dcos_config",
+				"-o", "StrictHostKeyChecking=no",
+				"-o", "UserKnownHostsFile=/dev/null",
+				"core@10.0.0.1", "journalctl", "-f",
+			},
+		},
+		{Opts{Host: "10.0.0.1", ProxyHost: "dcos.example.com"}, []string{"-J", "core@dcos.example.com", "core@10.0.0.1"}},
+		{Opts{Host: "10.0.0.1", ProxyHost: "admin@dcos.example.com"}, []string{"-J", "admin@dcos.example.com", "core@10.0.0.1"}},
+		{Opts{Host: "2001:db8::2"}, []string{"core@2001:db8::2"}},
+		{Opts{Host: "[2001:db8::2]"}, []string{"core@2001:db8::2"}},
+		{Opts{Host: "2001:db8::2", ProxyHost: "2001:db8::1"}, []string{"-J", "core@[2001:db8::1]", "core@2001:db8::2"}},
+		{Opts{Host: "2001:db8::2", ProxyHost: "admin@[2001:db8::1]:2222"}, []string{"-J", "admin@[2001:db8::1]:2222", "core@2001:db8::2"}},
+	}
+
+	for _, fixture := range fixtures {
+		require.Equal(t, fixture.args, Args(fixture.opts))
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	cmdLine := CommandLine("ssh", []string{"-o", "ProxyCommand=ssh -W %h:%p master", "core@10.0.0.1", "echo 'hi'"})
+	require.Equal(t, `ssh -o 'ProxyCommand=ssh -W %h:%p master' core@10.0.0.1 'echo '\''hi'\'''`, cmdLine)
+}
+
+func TestCommandWithoutHost(t *testing.T) {
+	_, err := Command(Opts{})
+	require.Error(t, err)
+}
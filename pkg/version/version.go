@@ -0,0 +1,128 @@
+// Package version compares DC/OS CLI and cluster versions.
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version (eg. "1.13.0" or "v2.0.0-beta1"). DC/OS versions don't always have
+// a patch number (eg. "1.13-dev"), missing minor and patch numbers are 0.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// Parse parses a semantic version, an optional "v" prefix and build metadata are ignored.
+func Parse(rawVersion string) (Version, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(rawVersion), "v")
+	if i := strings.Index(s, "+"); i != -1 {
+		s = s[:i]
+	}
+
+	var v Version
+	if i := strings.Index(s, "-"); i != -1 {
+		s, v.Prerelease = s[:i], s[i+1:]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version '%s'", rawVersion)
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s'", rawVersion)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// mustParse is like Parse but panics when the version is invalid.
+func mustParse(rawVersion string) Version {
+	v, err := Parse(rawVersion)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compare returns -1, 0 or 1 when the version is respectively lower than, equal to or greater than another one.
+// A pre-release is lower than its release, pre-releases of the same version are compared lexically.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	case v.Prerelease < other.Prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// String returns the version in its "major.minor.patch[-prerelease]" form.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// minDCOSVersion is the oldest DC/OS version supported by the CLI, older clusters lack the
+// endpoints used to install the core plugin.
+var minDCOSVersion = mustParse("1.10")
+
+// requirements are the minimum CLI versions known to be required by DC/OS versions.
+var requirements = []struct {
+	dcos Version
+	cli  Version
+}{
+	{dcos: mustParse("2.0"), cli: mustParse("1.0.0")},
+}
+
+// Check returns an error when a CLI version is known to be incompatible with a DC/OS version.
+// Versions which can't be parsed (eg. development builds of the CLI) are considered compatible.
+func Check(cliVersion, dcosVersion string) error {
+	cli, err := Parse(cliVersion)
+	if err != nil {
+		return nil
+	}
+	dcos, err := Parse(dcosVersion)
+	if err != nil {
+		return nil
+	}
+
+	// Pre-releases of a DC/OS version have the same requirements as the version itself.
+	dcos.Prerelease = ""
+
+	if dcos.Compare(minDCOSVersion) < 0 {
+		return fmt.Errorf("DC/OS %s is not supported by the DC/OS CLI %s, it requires DC/OS %d.%d or later", dcosVersion, cliVersion, minDCOSVersion.Major, minDCOSVersion.Minor)
+	}
+	for i := len(requirements) - 1; i >= 0; i-- {
+		req := requirements[i]
+		if dcos.Compare(req.dcos) >= 0 {
+			if cli.Compare(req.cli) < 0 {
+				return fmt.Errorf("the DC/OS CLI %s is too old for DC/OS %s, please update it to %s or later", cliVersion, dcosVersion, req.cli)
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	fixtures := []struct {
+		rawVersion string
+		version    Version
+	}{
+		{"1.13.0", Version{Major: 1, Minor: 13}},
+		{"v0.8.3", Version{Minor: 8, Patch: 3}},
+		{"1.12", Version{Major: 1, Minor: 12}},
+		{"1.14-dev", Version{Major: 1, Minor: 14, Prerelease: "dev"}},
+		{"2.0.0-beta1+build.42", Version{Major: 2, Prerelease: "beta1"}},
+	}
+	for _, fixture := range fixtures {
+		version, err := Parse(fixture.rawVersion)
+		require.NoError(t, err, fixture.rawVersion)
+		require.Equal(t, fixture.version, version, fixture.rawVersion)
+	}
+
+	for _, invalid := range []string{"", "SNAPSHOT", "1.2.3.4", "1.x", "4b8c2a3e5f"} {
+		_, err := Parse(invalid)
+		require.EqualError(t, err, "invalid version '"+invalid+"'")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	fixtures := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.13.0", "1.13", 0},
+		{"1.13.1", "1.13.0", 1},
+		{"1.9", "1.10", -1},
+		{"2.0.0", "1.13.5", 1},
+		{"2.0.0-beta1", "2.0.0", -1},
+		{"2.0.0-beta1", "2.0.0-beta2", -1},
+		{"2.0.0-rc1", "2.0.0-beta2", 1},
+	}
+	for _, fixture := range fixtures {
+		require.Equal(t, fixture.expected, mustParse(fixture.a).Compare(mustParse(fixture.b)), "%s <=> %s", fixture.a, fixture.b)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	compatible := []struct{ cli, dcos string }{
+		{"0.8.3", "1.13.0"},
+		{"1.0.0", "2.0.1"},
+		{"1.1.0", "1.12.4"},
+		{"SNAPSHOT", "2.0.0"},
+		{"0.8.3", "unknown"},
+	}
+	for _, fixture := range compatible {
+		require.NoError(t, Check(fixture.cli, fixture.dcos), "%s with DC/OS %s", fixture.cli, fixture.dcos)
+	}
+
+	err := Check("0.8.3", "2.0.0-beta1")
+	require.EqualError(t, err, "the DC/OS CLI 0.8.3 is too old for DC/OS 2.0.0-beta1, please update it to 1.0.0 or later")
+
+	err = Check("1.0.0", "1.9.4")
+	require.EqualError(t, err, "DC/OS 1.9.4 is not supported by the DC/OS CLI 1.0.0, it requires DC/OS 1.10 or later")
+}